@@ -0,0 +1,273 @@
+package common
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/binary"
+	"encoding/gob"
+	"math"
+	"reflect"
+)
+
+// binaryFormatVersion is the first byte of every encoded collection
+const binaryFormatVersion byte = 1
+
+// EncodeElements encodes elements as a version byte, an element count and length-prefixed elements
+// Booleans, integers, floats, complex numbers, strings and byte slices use a compact encoding;
+// types implementing encoding.BinaryMarshaler use their own encoding and everything else falls back to gob
+func EncodeElements[E any](elements []E) ([]byte, error) {
+	buf := make([]byte, 0, 2+len(elements)*4)
+	buf = append(buf, binaryFormatVersion)
+	buf = appendUvarint(buf, uint64(len(elements)))
+	var err error
+	for _, element := range elements {
+		if buf, err = appendElement(buf, element); err != nil {
+			return nil, err
+		}
+	}
+	return buf, nil
+}
+
+// DecodeElements decodes data produced by EncodeElements
+func DecodeElements[E any](data []byte) ([]E, error) {
+	rest, count, err := readHeader(data)
+	if err != nil {
+		return nil, err
+	}
+	elements := make([]E, 0, count)
+	for i := 0; i < count; i++ {
+		var element E
+		if rest, err = readElement(rest, &element); err != nil {
+			return nil, err
+		}
+		elements = append(elements, element)
+	}
+	if len(rest) != 0 {
+		return nil, MalformedDataError("trailing bytes")
+	}
+	return elements, nil
+}
+
+// EncodeEntries encodes entries like EncodeElements, writing each key followed by its value
+func EncodeEntries[K, V any](entries []Entry[K, V]) ([]byte, error) {
+	buf := make([]byte, 0, 2+len(entries)*8)
+	buf = append(buf, binaryFormatVersion)
+	buf = appendUvarint(buf, uint64(len(entries)))
+	var err error
+	for _, entry := range entries {
+		if buf, err = appendElement(buf, entry.Key); err != nil {
+			return nil, err
+		}
+		if buf, err = appendElement(buf, entry.Value); err != nil {
+			return nil, err
+		}
+	}
+	return buf, nil
+}
+
+// DecodeEntries decodes data produced by EncodeEntries
+func DecodeEntries[K, V any](data []byte) ([]Entry[K, V], error) {
+	rest, count, err := readHeader(data)
+	if err != nil {
+		return nil, err
+	}
+	entries := make([]Entry[K, V], 0, count)
+	for i := 0; i < count; i++ {
+		var entry Entry[K, V]
+		if rest, err = readElement(rest, &entry.Key); err != nil {
+			return nil, err
+		}
+		if rest, err = readElement(rest, &entry.Value); err != nil {
+			return nil, err
+		}
+		entries = append(entries, entry)
+	}
+	if len(rest) != 0 {
+		return nil, MalformedDataError("trailing bytes")
+	}
+	return entries, nil
+}
+
+// readHeader checks the version byte and returns the element count
+// The count is bounded by the remaining length, since every element takes at least one byte
+func readHeader(data []byte) ([]byte, int, error) {
+	if len(data) == 0 {
+		return nil, 0, MalformedDataError("empty input")
+	}
+	if data[0] != binaryFormatVersion {
+		return nil, 0, MalformedDataError("unsupported format version")
+	}
+	count, n := binary.Uvarint(data[1:])
+	if n <= 0 {
+		return nil, 0, MalformedDataError("bad element count")
+	}
+	rest := data[1+n:]
+	if count > uint64(len(rest)) {
+		return nil, 0, MalformedDataError("element count exceeds input length")
+	}
+	return rest, int(count), nil
+}
+
+// appendElement appends the length-prefixed encoding of v to buf
+func appendElement[T any](buf []byte, v T) ([]byte, error) {
+	payload, err := marshalValue(reflect.ValueOf(&v).Elem())
+	if err != nil {
+		return nil, err
+	}
+	buf = appendUvarint(buf, uint64(len(payload)))
+	return append(buf, payload...), nil
+}
+
+// readElement decodes one length-prefixed element from data into v and returns the remaining bytes
+func readElement[T any](data []byte, v *T) ([]byte, error) {
+	size, n := binary.Uvarint(data)
+	if n <= 0 || size > uint64(len(data)-n) {
+		return nil, MalformedDataError("truncated element")
+	}
+	payload := data[n : n+int(size)]
+	if err := unmarshalValue(payload, reflect.ValueOf(v).Elem()); err != nil {
+		return nil, err
+	}
+	return data[n+int(size):], nil
+}
+
+// appendUvarint appends the uvarint encoding of v to buf
+func appendUvarint(buf []byte, v uint64) []byte {
+	var tmp [binary.MaxVarintLen64]byte
+	return append(buf, tmp[:binary.PutUvarint(tmp[:], v)]...)
+}
+
+// appendVarint appends the zig-zag varint encoding of v to buf
+func appendVarint(buf []byte, v int64) []byte {
+	var tmp [binary.MaxVarintLen64]byte
+	return append(buf, tmp[:binary.PutVarint(tmp[:], v)]...)
+}
+
+// appendUint32 appends v to buf in little-endian order
+func appendUint32(buf []byte, v uint32) []byte {
+	var tmp [4]byte
+	binary.LittleEndian.PutUint32(tmp[:], v)
+	return append(buf, tmp[:]...)
+}
+
+// appendUint64 appends v to buf in little-endian order
+func appendUint64(buf []byte, v uint64) []byte {
+	var tmp [8]byte
+	binary.LittleEndian.PutUint64(tmp[:], v)
+	return append(buf, tmp[:]...)
+}
+
+var binaryMarshalerType = reflect.TypeOf((*encoding.BinaryMarshaler)(nil)).Elem()
+
+// marshalValue encodes a single value without a length prefix
+func marshalValue(rv reflect.Value) ([]byte, error) {
+	if rv.Type().Implements(binaryMarshalerType) && rv.Kind() != reflect.Interface {
+		if rv.Kind() == reflect.Ptr && rv.IsNil() {
+			return nil, InvalidArgumentError("element", "nil pointer cannot be encoded")
+		}
+		return rv.Interface().(encoding.BinaryMarshaler).MarshalBinary()
+	}
+	switch rv.Kind() {
+	case reflect.Bool:
+		if rv.Bool() {
+			return []byte{1}, nil
+		}
+		return []byte{0}, nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return appendVarint(nil, rv.Int()), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return appendUvarint(nil, rv.Uint()), nil
+	case reflect.Float32:
+		return appendUint32(nil, math.Float32bits(float32(rv.Float()))), nil
+	case reflect.Float64:
+		return appendUint64(nil, math.Float64bits(rv.Float())), nil
+	case reflect.Complex64, reflect.Complex128:
+		c := rv.Complex()
+		buf := appendUint64(nil, math.Float64bits(real(c)))
+		return appendUint64(buf, math.Float64bits(imag(c))), nil
+	case reflect.String:
+		return []byte(rv.String()), nil
+	case reflect.Slice:
+		if rv.Type().Elem().Kind() == reflect.Uint8 {
+			return append([]byte(nil), rv.Bytes()...), nil
+		}
+	}
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).EncodeValue(rv); err != nil {
+		return nil, InvalidArgumentError("element", err.Error())
+	}
+	return buf.Bytes(), nil
+}
+
+// unmarshalValue decodes payload into rv, which must be settable
+func unmarshalValue(payload []byte, rv reflect.Value) error {
+	if rv.Kind() == reflect.Ptr && rv.Type().Implements(binaryMarshalerType) {
+		target := reflect.New(rv.Type().Elem())
+		if u, ok := target.Interface().(encoding.BinaryUnmarshaler); ok {
+			if err := u.UnmarshalBinary(payload); err != nil {
+				return err
+			}
+			rv.Set(target)
+			return nil
+		}
+	}
+	if rv.Kind() != reflect.Interface && rv.Type().Implements(binaryMarshalerType) {
+		if u, ok := rv.Addr().Interface().(encoding.BinaryUnmarshaler); ok {
+			return u.UnmarshalBinary(payload)
+		}
+	}
+	switch rv.Kind() {
+	case reflect.Bool:
+		if len(payload) != 1 || payload[0] > 1 {
+			return MalformedDataError("bad bool")
+		}
+		rv.SetBool(payload[0] == 1)
+		return nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		v, n := binary.Varint(payload)
+		if n != len(payload) || n == 0 || rv.OverflowInt(v) {
+			return MalformedDataError("bad integer")
+		}
+		rv.SetInt(v)
+		return nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		v, n := binary.Uvarint(payload)
+		if n != len(payload) || n == 0 || rv.OverflowUint(v) {
+			return MalformedDataError("bad unsigned integer")
+		}
+		rv.SetUint(v)
+		return nil
+	case reflect.Float32:
+		if len(payload) != 4 {
+			return MalformedDataError("bad float32")
+		}
+		rv.SetFloat(float64(math.Float32frombits(binary.LittleEndian.Uint32(payload))))
+		return nil
+	case reflect.Float64:
+		if len(payload) != 8 {
+			return MalformedDataError("bad float64")
+		}
+		rv.SetFloat(math.Float64frombits(binary.LittleEndian.Uint64(payload)))
+		return nil
+	case reflect.Complex64, reflect.Complex128:
+		if len(payload) != 16 {
+			return MalformedDataError("bad complex number")
+		}
+		re := math.Float64frombits(binary.LittleEndian.Uint64(payload))
+		im := math.Float64frombits(binary.LittleEndian.Uint64(payload[8:]))
+		rv.SetComplex(complex(re, im))
+		return nil
+	case reflect.String:
+		rv.SetString(string(payload))
+		return nil
+	case reflect.Slice:
+		if rv.Type().Elem().Kind() == reflect.Uint8 {
+			rv.SetBytes(append([]byte(nil), payload...))
+			return nil
+		}
+	}
+	if err := gob.NewDecoder(bytes.NewReader(payload)).DecodeValue(rv); err != nil {
+		return MalformedDataError(err.Error())
+	}
+	return nil
+}
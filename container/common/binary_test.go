@@ -0,0 +1,130 @@
+package common
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+type binaryPoint struct {
+	X, Y int
+	Tag  string
+}
+
+type userID string
+
+func roundTripElements[E any](t *testing.T, elements []E) []E {
+	t.Helper()
+	data, err := EncodeElements(elements)
+	if err != nil {
+		t.Fatalf("EncodeElements(%v) failed: %v", elements, err)
+	}
+	decoded, err := DecodeElements[E](data)
+	if err != nil {
+		t.Fatalf("DecodeElements failed: %v", err)
+	}
+	return decoded
+}
+
+func TestEncodeElementsRoundTrip(t *testing.T) {
+	ints := []int{0, 1, -1, 1 << 40, -(1 << 62)}
+	if got := roundTripElements(t, ints); !reflect.DeepEqual(got, ints) {
+		t.Errorf("ints: expected %v, got %v", ints, got)
+	}
+
+	strs := []string{"", "a", "héllo", string(make([]byte, 300))}
+	if got := roundTripElements(t, strs); !reflect.DeepEqual(got, strs) {
+		t.Errorf("strings: expected %v, got %v", strs, got)
+	}
+
+	floats := []float64{0, -2.5, 1e300}
+	if got := roundTripElements(t, floats); !reflect.DeepEqual(got, floats) {
+		t.Errorf("floats: expected %v, got %v", floats, got)
+	}
+	f32 := []float32{1.5, -3}
+	if got := roundTripElements(t, f32); !reflect.DeepEqual(got, f32) {
+		t.Errorf("float32s: expected %v, got %v", f32, got)
+	}
+	bools := []bool{true, false}
+	if got := roundTripElements(t, bools); !reflect.DeepEqual(got, bools) {
+		t.Errorf("bools: expected %v, got %v", bools, got)
+	}
+	u8 := []uint8{0, 255}
+	if got := roundTripElements(t, u8); !reflect.DeepEqual(got, u8) {
+		t.Errorf("uint8s: expected %v, got %v", u8, got)
+	}
+	ids := []userID{"u1", "u2"}
+	if got := roundTripElements(t, ids); !reflect.DeepEqual(got, ids) {
+		t.Errorf("named strings: expected %v, got %v", ids, got)
+	}
+	blobs := [][]byte{{1, 2, 3}, {}}
+	if got := roundTripElements(t, blobs); len(got) != 2 || string(got[0]) != "\x01\x02\x03" || len(got[1]) != 0 {
+		t.Errorf("byte slices: expected %v, got %v", blobs, got)
+	}
+
+	// Structs fall back to gob, time.Time uses its own BinaryMarshaler
+	points := []binaryPoint{{1, 2, "a"}, {-3, 4, "b"}}
+	if got := roundTripElements(t, points); !reflect.DeepEqual(got, points) {
+		t.Errorf("structs: expected %v, got %v", points, got)
+	}
+	when := time.Date(2024, 5, 6, 7, 8, 9, 10, time.UTC)
+	if got := roundTripElements(t, []time.Time{when}); !got[0].Equal(when) {
+		t.Errorf("time: expected %v, got %v", when, got[0])
+	}
+
+	if got := roundTripElements(t, []int{}); len(got) != 0 {
+		t.Errorf("empty: expected no elements, got %v", got)
+	}
+}
+
+func TestEncodeEntriesRoundTrip(t *testing.T) {
+	entries := []Entry[string, int]{NewEntry("a", 1), NewEntry("b", -2)}
+	data, err := EncodeEntries(entries)
+	if err != nil {
+		t.Fatalf("EncodeEntries failed: %v", err)
+	}
+	decoded, err := DecodeEntries[string, int](data)
+	if err != nil {
+		t.Fatalf("DecodeEntries failed: %v", err)
+	}
+	if !reflect.DeepEqual(decoded, entries) {
+		t.Errorf("Expected %v, got %v", entries, decoded)
+	}
+}
+
+func TestEncodeElementsCompact(t *testing.T) {
+	data, _ := EncodeElements([]int{1, 2, 3})
+	// version + count + 3 * (length + one varint byte)
+	if len(data) != 8 {
+		t.Errorf("Expected 8 bytes for three small ints, got %d", len(data))
+	}
+}
+
+func TestDecodeElementsMalformed(t *testing.T) {
+	good, _ := EncodeElements([]string{"abc", "def"})
+	cases := map[string][]byte{
+		"empty":     {},
+		"version":   append([]byte{99}, good[1:]...),
+		"truncated": good[:len(good)-1],
+		"trailing":  append(append([]byte{}, good...), 0),
+		"count":     {binaryFormatVersion, 200},
+	}
+	for name, data := range cases {
+		if _, err := DecodeElements[string](data); !errors.Is(err, ErrMalformedData) {
+			t.Errorf("%s: expected ErrMalformedData, got %v", name, err)
+		}
+	}
+
+	// Values that do not fit the target type are rejected
+	big, _ := EncodeElements([]int{300})
+	if _, err := DecodeElements[int8](big); !errors.Is(err, ErrMalformedData) {
+		t.Errorf("Expected overflow to be rejected, got %v", err)
+	}
+}
+
+func TestEncodeElementsNilPointer(t *testing.T) {
+	if _, err := EncodeElements([]*time.Time{nil}); !errors.Is(err, ErrInvalidArgument) {
+		t.Errorf("Expected ErrInvalidArgument for a nil pointer, got %v", err)
+	}
+}
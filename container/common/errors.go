@@ -24,6 +24,7 @@ var (
 	ErrSelfLoopNotAllowed     = errors.New("self-loops are not allowed")
 	ErrParallelEdgeNotAllowed = errors.New("parallel edges are not allowed")
 	ErrInvalidOperation       = errors.New("invalid operation")
+	ErrMalformedData          = errors.New("malformed binary data")
 )
 
 // Error factory functions for specific error scenarios
@@ -111,3 +112,8 @@ func ParallelEdgeNotAllowedError(nodeU, nodeV interface{}) error {
 func InvalidOperationError(operation, reason string) error {
 	return fmt.Errorf("%w: %s - %s", ErrInvalidOperation, operation, reason)
 }
+
+// MalformedDataError creates a specific malformed binary data error
+func MalformedDataError(reason string) error {
+	return fmt.Errorf("%w: %s", ErrMalformedData, reason)
+}
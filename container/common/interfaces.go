@@ -42,3 +42,18 @@ type Comparable interface {
 	//   - positive number: this > other
 	CompareTo(other interface{}) int
 }
+
+// Serializable represents a collection that can be written to and restored from a compact binary form
+// UnmarshalBinary replaces the receiver's contents with the decoded elements
+type Serializable interface {
+	// MarshalBinary encodes the collection
+	MarshalBinary() ([]byte, error)
+	// UnmarshalBinary decodes data produced by MarshalBinary
+	UnmarshalBinary(data []byte) error
+}
+
+// StatsReporter represents a container that can report its size and capacity for introspection
+type StatsReporter interface {
+	// Stats returns a snapshot of the container's length and capacity
+	Stats() ContainerStats
+}
@@ -0,0 +1,290 @@
+package common
+
+import "context"
+
+// Count drains the iterator and returns the number of elements it produced
+// The iterator is consumed: HasNext returns false afterwards
+func Count[E any](it Iterator[E]) int {
+	count := 0
+	for it.HasNext() {
+		if _, ok := it.Next(); !ok {
+			break
+		}
+		count++
+	}
+	return count
+}
+
+// Nth advances the iterator to the element at zero-based position n and returns it
+// Returns zero value and false if n is negative or the iterator has fewer than n+1 elements
+// The elements before the nth one are consumed
+func Nth[E any](it Iterator[E], n int) (E, bool) {
+	var zero E
+	if n < 0 {
+		return zero, false
+	}
+	for it.HasNext() {
+		element, ok := it.Next()
+		if !ok {
+			break
+		}
+		if n == 0 {
+			return element, true
+		}
+		n--
+	}
+	return zero, false
+}
+
+// CollectToSlice filters and maps the elements of c into a new slice in a single pass
+// transform returns the mapped value and whether the element should be kept
+// Elements are visited in the order produced by c's Iterator
+func CollectToSlice[E, R any](c Iterable[E], transform func(E) (R, bool)) []R {
+	result := make([]R, 0)
+	it := c.Iterator()
+	for it.HasNext() {
+		element, ok := it.Next()
+		if !ok {
+			break
+		}
+		if mapped, keep := transform(element); keep {
+			result = append(result, mapped)
+		}
+	}
+	return result
+}
+
+// TakeWhile returns an iterator over the leading elements of it that satisfy pred
+// Iteration stops at the first element rejected by pred; that element is consumed from it
+// but not returned. Remove is not supported
+func TakeWhile[E any](it Iterator[E], pred func(E) bool) Iterator[E] {
+	return &takeWhileIterator[E]{it: it, pred: pred}
+}
+
+// DropWhile returns an iterator that skips the leading elements of it that satisfy pred
+// and then yields every remaining element, including the first one rejected by pred
+// Skipping happens lazily on the first call to HasNext or Next. Remove is not supported
+func DropWhile[E any](it Iterator[E], pred func(E) bool) Iterator[E] {
+	return &dropWhileIterator[E]{it: it, pred: pred}
+}
+
+// takeWhileIterator is the iterator returned by TakeWhile
+type takeWhileIterator[E any] struct {
+	it      Iterator[E]
+	pred    func(E) bool
+	next    E
+	hasNext bool // next holds an element that passed pred
+	done    bool
+}
+
+// HasNext checks if the iterator has a next element
+func (t *takeWhileIterator[E]) HasNext() bool {
+	if t.hasNext {
+		return true
+	}
+	if t.done {
+		return false
+	}
+	if !t.it.HasNext() {
+		t.done = true
+		return false
+	}
+	element, ok := t.it.Next()
+	if !ok || !t.pred(element) {
+		t.done = true
+		return false
+	}
+	t.next, t.hasNext = element, true
+	return true
+}
+
+// Next returns the next element in the iterator
+func (t *takeWhileIterator[E]) Next() (E, bool) {
+	var zero E
+	if !t.HasNext() {
+		return zero, false
+	}
+	element := t.next
+	t.next, t.hasNext = zero, false
+	return element, true
+}
+
+// Remove is not supported by TakeWhile iterators and always returns false
+func (t *takeWhileIterator[E]) Remove() bool {
+	return false
+}
+
+// dropWhileIterator is the iterator returned by DropWhile
+type dropWhileIterator[E any] struct {
+	it      Iterator[E]
+	pred    func(E) bool
+	first   E
+	pending bool // first holds the element that ended the skipped prefix
+	skipped bool
+}
+
+// skip consumes the leading elements that satisfy pred, once
+func (d *dropWhileIterator[E]) skip() {
+	if d.skipped {
+		return
+	}
+	d.skipped = true
+	for d.it.HasNext() {
+		element, ok := d.it.Next()
+		if !ok {
+			return
+		}
+		if !d.pred(element) {
+			d.first, d.pending = element, true
+			return
+		}
+	}
+}
+
+// HasNext checks if the iterator has a next element
+func (d *dropWhileIterator[E]) HasNext() bool {
+	d.skip()
+	return d.pending || d.it.HasNext()
+}
+
+// Next returns the next element in the iterator
+func (d *dropWhileIterator[E]) Next() (E, bool) {
+	d.skip()
+	if d.pending {
+		var zero E
+		element := d.first
+		d.first, d.pending = zero, false
+		return element, true
+	}
+	return d.it.Next()
+}
+
+// Remove is not supported by DropWhile iterators and always returns false
+func (d *dropWhileIterator[E]) Remove() bool {
+	return false
+}
+
+// ToChannel feeds the iterator's elements into a channel from a new goroutine
+// The channel is closed once the iterator is exhausted; a negative bufferSize is treated as 0
+// The goroutine runs until every element is received, so a consumer that may stop early
+// should use ToChannelContext instead
+func ToChannel[E any](it Iterator[E], bufferSize int) <-chan E {
+	return ToChannelContext(context.Background(), it, bufferSize)
+}
+
+// ToChannelContext is like ToChannel but stops feeding and closes the channel when ctx is done
+// Cancelling ctx releases the goroutine even if nobody receives the remaining elements
+func ToChannelContext[E any](ctx context.Context, it Iterator[E], bufferSize int) <-chan E {
+	if bufferSize < 0 {
+		bufferSize = 0
+	}
+	ch := make(chan E, bufferSize)
+	go func() {
+		defer close(ch)
+		for it.HasNext() {
+			element, ok := it.Next()
+			if !ok {
+				return
+			}
+			select {
+			case ch <- element:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return ch
+}
+
+// Tee splits one iterator into n independent iterators that each yield every element of it
+// Elements are pulled from it lazily and buffered until the slowest returned iterator has
+// consumed them, so the memory used grows with the distance between the fastest and slowest cursor
+// The source iterator must not be used after calling Tee, and the returned iterators are not
+// safe for concurrent use. A non-positive n yields an empty slice; Remove is not supported
+func Tee[E any](it Iterator[E], n int) []Iterator[E] {
+	if n <= 0 {
+		return []Iterator[E]{}
+	}
+	source := &teeSource[E]{it: it, positions: make([]int, n)}
+	result := make([]Iterator[E], n)
+	for i := range result {
+		result[i] = &teeIterator[E]{source: source, id: i}
+	}
+	return result
+}
+
+// teeSource holds the shared buffer behind the iterators returned by Tee
+type teeSource[E any] struct {
+	it        Iterator[E]
+	buffer    []E   // elements not yet consumed by every cursor
+	start     int   // stream position of buffer[0]
+	positions []int // stream position of each cursor
+	done      bool
+}
+
+// available reports whether the element at stream position pos is buffered, pulling it if needed
+func (s *teeSource[E]) available(pos int) bool {
+	for pos >= s.start+len(s.buffer) {
+		if s.done || !s.it.HasNext() {
+			s.done = true
+			return false
+		}
+		element, ok := s.it.Next()
+		if !ok {
+			s.done = true
+			return false
+		}
+		s.buffer = append(s.buffer, element)
+	}
+	return true
+}
+
+// trim drops the buffered elements every cursor has already consumed
+func (s *teeSource[E]) trim() {
+	lowest := s.positions[0]
+	for _, pos := range s.positions[1:] {
+		if pos < lowest {
+			lowest = pos
+		}
+	}
+	drop := lowest - s.start
+	if drop <= 0 {
+		return
+	}
+	var zero E
+	for i := 0; i < drop; i++ {
+		s.buffer[i] = zero
+	}
+	s.buffer = s.buffer[drop:]
+	s.start = lowest
+}
+
+// teeIterator is one cursor over a teeSource
+type teeIterator[E any] struct {
+	source *teeSource[E]
+	id     int
+}
+
+// HasNext checks if the iterator has a next element
+func (t *teeIterator[E]) HasNext() bool {
+	return t.source.available(t.source.positions[t.id])
+}
+
+// Next returns the next element in the iterator
+func (t *teeIterator[E]) Next() (E, bool) {
+	s := t.source
+	pos := s.positions[t.id]
+	if !s.available(pos) {
+		var zero E
+		return zero, false
+	}
+	element := s.buffer[pos-s.start]
+	s.positions[t.id]++
+	s.trim()
+	return element, true
+}
+
+// Remove is not supported by Tee iterators and always returns false
+func (t *teeIterator[E]) Remove() bool {
+	return false
+}
@@ -0,0 +1,250 @@
+package common
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+// sliceIterator is a minimal Iterator over a slice used by the tests
+type sliceIterator[E any] struct {
+	elements []E
+	index    int
+}
+
+func newSliceIterator[E any](elements ...E) *sliceIterator[E] {
+	return &sliceIterator[E]{elements: elements}
+}
+
+func (it *sliceIterator[E]) HasNext() bool {
+	return it.index < len(it.elements)
+}
+
+func (it *sliceIterator[E]) Next() (E, bool) {
+	if !it.HasNext() {
+		var zero E
+		return zero, false
+	}
+	element := it.elements[it.index]
+	it.index++
+	return element, true
+}
+
+func (it *sliceIterator[E]) Remove() bool {
+	return false
+}
+
+func TestCount(t *testing.T) {
+	it := newSliceIterator(1, 2, 3, 4)
+	if n := Count[int](it); n != 4 {
+		t.Errorf("Count should return 4, got %d", n)
+	}
+	if it.HasNext() {
+		t.Error("Count should consume the iterator")
+	}
+	if n := Count[int](it); n != 0 {
+		t.Errorf("Count on a drained iterator should return 0, got %d", n)
+	}
+	if n := Count[string](newSliceIterator[string]()); n != 0 {
+		t.Errorf("Count on an empty iterator should return 0, got %d", n)
+	}
+}
+
+func TestNth(t *testing.T) {
+	it := newSliceIterator("a", "b", "c", "d")
+
+	if v, ok := Nth[string](it, 1); !ok || v != "b" {
+		t.Errorf("Nth(1) should return (b, true), got (%s, %v)", v, ok)
+	}
+	// Nth is relative to the current position
+	if v, ok := Nth[string](it, 0); !ok || v != "c" {
+		t.Errorf("Nth(0) after advancing should return (c, true), got (%s, %v)", v, ok)
+	}
+	if _, ok := Nth[string](it, 5); ok {
+		t.Error("Nth past the end should return false")
+	}
+	if _, ok := Nth[string](newSliceIterator("a"), -1); ok {
+		t.Error("Nth with a negative index should return false")
+	}
+}
+
+// sliceIterable is a minimal Iterable over a slice used by the tests
+type sliceIterable[E any] []E
+
+func (s sliceIterable[E]) Iterator() Iterator[E] {
+	return newSliceIterator(s...)
+}
+
+func (s sliceIterable[E]) ForEach(fn func(E)) {
+	for _, element := range s {
+		fn(element)
+	}
+}
+
+func TestCollectToSlice(t *testing.T) {
+	type user struct {
+		ID     int
+		Active bool
+	}
+	users := sliceIterable[user]{{1, true}, {2, false}, {3, true}, {4, false}}
+
+	ids := CollectToSlice[user, int](users, func(u user) (int, bool) {
+		return u.ID, u.Active
+	})
+	if len(ids) != 2 || ids[0] != 1 || ids[1] != 3 {
+		t.Errorf("CollectToSlice should return [1 3], got %v", ids)
+	}
+
+	none := CollectToSlice[user, int](users, func(u user) (int, bool) {
+		return 0, false
+	})
+	if none == nil || len(none) != 0 {
+		t.Errorf("CollectToSlice dropping everything should return an empty slice, got %v", none)
+	}
+}
+
+func TestTakeWhile(t *testing.T) {
+	source := newSliceIterator(1, 3, 5, 8, 9, 11)
+	it := TakeWhile[int](source, func(v int) bool { return v%2 == 1 })
+
+	var taken []int
+	for it.HasNext() {
+		v, _ := it.Next()
+		taken = append(taken, v)
+	}
+	if len(taken) != 3 || taken[0] != 1 || taken[2] != 5 {
+		t.Errorf("Expected [1 3 5], got %v", taken)
+	}
+	if source.index != 4 {
+		t.Errorf("TakeWhile should stop right after the first rejected element, source at %d", source.index)
+	}
+	if _, ok := it.Next(); ok {
+		t.Error("TakeWhile should stay exhausted after the predicate fails")
+	}
+
+	if Count(TakeWhile[int](newSliceIterator(2, 4), func(v int) bool { return v < 0 })) != 0 {
+		t.Error("TakeWhile should be empty when the first element is rejected")
+	}
+}
+
+func TestDropWhile(t *testing.T) {
+	it := DropWhile[int](newSliceIterator(1, 2, 5, 1, 6), func(v int) bool { return v < 3 })
+
+	var rest []int
+	for it.HasNext() {
+		v, _ := it.Next()
+		rest = append(rest, v)
+	}
+	if len(rest) != 3 || rest[0] != 5 || rest[1] != 1 || rest[2] != 6 {
+		t.Errorf("Expected [5 1 6], got %v", rest)
+	}
+
+	if Count(DropWhile[int](newSliceIterator(1, 2), func(v int) bool { return true })) != 0 {
+		t.Error("DropWhile should be empty when every element is dropped")
+	}
+	if v, ok := DropWhile[int](newSliceIterator(7, 1), func(v int) bool { return false }).Next(); !ok || v != 7 {
+		t.Errorf("DropWhile should yield the first element when nothing is dropped, got %d", v)
+	}
+}
+
+func TestToChannel(t *testing.T) {
+	var got []int
+	for v := range ToChannel[int](newSliceIterator(1, 2, 3), 1) {
+		got = append(got, v)
+	}
+	if len(got) != 3 || got[0] != 1 || got[2] != 3 {
+		t.Errorf("ToChannel should deliver [1 2 3] in order, got %v", got)
+	}
+
+	if _, open := <-ToChannel[int](newSliceIterator[int](), -1); open {
+		t.Error("ToChannel over an empty iterator should close immediately")
+	}
+}
+
+func TestToChannelContext(t *testing.T) {
+	elements := make([]int, 1000)
+	ctx, cancel := context.WithCancel(context.Background())
+	ch := ToChannelContext[int](ctx, newSliceIterator(elements...), 0)
+
+	// Stop after two elements; cancelling must release the feeding goroutine
+	<-ch
+	<-ch
+	cancel()
+
+	received := 0
+	timeout := time.After(time.Second)
+	for {
+		select {
+		case _, open := <-ch:
+			if !open {
+				if received >= len(elements)-2 {
+					t.Error("Cancelling should stop the remaining elements from being fed")
+				}
+				return
+			}
+			received++
+		case <-timeout:
+			t.Fatal("Channel should be closed after the context is cancelled")
+		}
+	}
+}
+
+func TestTee(t *testing.T) {
+	its := Tee[int](newSliceIterator(1, 2, 3, 4, 5), 2)
+	if len(its) != 2 {
+		t.Fatalf("Expected 2 iterators, got %d", len(its))
+	}
+
+	// Interleave the cursors at different speeds
+	first, _ := its[0].Next()
+	second, _ := its[0].Next()
+	if first != 1 || second != 2 {
+		t.Errorf("Expected 1, 2 from the first cursor, got %d, %d", first, second)
+	}
+
+	sum := 0
+	for its[1].HasNext() {
+		v, _ := its[1].Next()
+		sum += v
+	}
+	if sum != 15 {
+		t.Errorf("Expected second cursor to see every element, got sum %d", sum)
+	}
+
+	product := first * second
+	for its[0].HasNext() {
+		v, _ := its[0].Next()
+		product *= v
+	}
+	if product != 120 {
+		t.Errorf("Expected first cursor to see every element, got product %d", product)
+	}
+
+	if _, ok := its[0].Next(); ok {
+		t.Error("Exhausted tee iterator should return false")
+	}
+	if its[1].Remove() {
+		t.Error("Remove should not be supported")
+	}
+}
+
+func TestTeeBuffering(t *testing.T) {
+	source := newSliceIterator(1, 2, 3, 4)
+	its := Tee[int](source, 3)
+
+	its[0].Next()
+	if source.index != 1 {
+		t.Errorf("Tee should pull lazily, source advanced to %d", source.index)
+	}
+	its[1].Next()
+	if source.index != 1 {
+		t.Errorf("Buffered element should not be pulled again, source advanced to %d", source.index)
+	}
+	if Count(its[2]) != 4 || Count(its[0]) != 3 || Count(its[1]) != 3 {
+		t.Error("Each cursor should yield the remaining elements independently")
+	}
+
+	if got := Tee[int](newSliceIterator(1), 0); len(got) != 0 {
+		t.Errorf("Expected no iterators for n = 0, got %d", len(got))
+	}
+}
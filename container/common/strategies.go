@@ -5,6 +5,8 @@ import (
 	"hash/fnv"
 	"reflect"
 	"strings"
+	"unicode"
+	"unicode/utf8"
 )
 
 // HashStrategy defines the interface for custom hash functions
@@ -84,6 +86,29 @@ func NewFunctionalHashStrategy[T any](hashFunc func(T) uint64, equalsFunc func(T
 	}
 }
 
+// FieldHashStrategy hashes and compares elements by a key derived from each element
+// Two elements are equal when their derived keys are equal, so structs can match on
+// identity fields while other fields differ
+type FieldHashStrategy[T any] struct {
+	keyFunc func(T) any
+}
+
+// Hash implements HashStrategy.Hash by hashing the derived key
+func (fhs *FieldHashStrategy[T]) Hash(element T) uint64 {
+	return Hash(fhs.keyFunc(element))
+}
+
+// Equals implements HashStrategy.Equals by comparing the derived keys
+func (fhs *FieldHashStrategy[T]) Equals(a, b T) bool {
+	return Equal(fhs.keyFunc(a), fhs.keyFunc(b))
+}
+
+// NewFieldHashStrategy creates a hash strategy that matches elements on keyFn(element)
+// keyFn must be deterministic; return a struct or array to match on several fields
+func NewFieldHashStrategy[T any](keyFn func(T) any) HashStrategy[T] {
+	return &FieldHashStrategy[T]{keyFunc: keyFn}
+}
+
 // DefaultComparatorStrategy provides a default comparator using natural ordering
 type DefaultComparatorStrategy[T comparable] struct{}
 
@@ -142,19 +167,37 @@ func NewStringLengthHashStrategy() HashStrategy[string] {
 // CaseInsensitiveStringHashStrategy provides case-insensitive hashing for strings
 type CaseInsensitiveStringHashStrategy struct{}
 
-// Hash computes hash for lowercase version of string
+// Hash computes hash for the case-folded version of string
+// Each rune is folded to the smallest rune of its Unicode case-folding orbit, so strings
+// that strings.EqualFold reports equal (e.g. "ſ" and "s") always hash alike
 func (cishs *CaseInsensitiveStringHashStrategy) Hash(element string) uint64 {
 	h := fnv.New64a()
-	h.Write([]byte(strings.ToLower(element)))
+	var buf [utf8.UTFMax]byte
+	for _, r := range element {
+		n := utf8.EncodeRune(buf[:], foldRune(r))
+		h.Write(buf[:n])
+	}
 	return h.Sum64()
 }
 
+// foldRune returns the smallest rune that is equivalent to r under simple case folding
+func foldRune(r rune) rune {
+	folded := r
+	for f := unicode.SimpleFold(r); f != r; f = unicode.SimpleFold(f) {
+		if f < folded {
+			folded = f
+		}
+	}
+	return folded
+}
+
 // Equals compares strings case-insensitively
 func (cishs *CaseInsensitiveStringHashStrategy) Equals(a, b string) bool {
 	return strings.EqualFold(a, b)
 }
 
 // NewCaseInsensitiveStringHashStrategy creates a new case-insensitive string hash strategy
+// Use it for keys such as HTTP header names or configuration keys
 func NewCaseInsensitiveStringHashStrategy() HashStrategy[string] {
 	return &CaseInsensitiveStringHashStrategy{}
 }
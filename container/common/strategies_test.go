@@ -172,6 +172,13 @@ func TestCaseInsensitiveStringHashStrategy(t *testing.T) {
 	if strategy.Equals("Hello", "World") {
 		t.Error("Different strings should not be equal")
 	}
+
+	// Strings equal under Unicode case folding must hash alike
+	for _, pair := range [][2]string{{"ſ", "S"}, {"\u212a", "k"}, {"Straße", "STRAßE"}, {"ΣΑΣ", "σας"}} {
+		if strategy.Equals(pair[0], pair[1]) && strategy.Hash(pair[0]) != strategy.Hash(pair[1]) {
+			t.Errorf("%q and %q are equal and should hash alike", pair[0], pair[1])
+		}
+	}
 }
 
 // Benchmark tests
@@ -233,3 +240,28 @@ func BenchmarkCaseInsensitiveStringHashStrategy(b *testing.B) {
 		strategy.Hash(testString)
 	}
 }
+
+func TestFieldHashStrategy(t *testing.T) {
+	type record struct {
+		Tenant string
+		ID     int
+		Cached []string
+	}
+	byID := NewFieldHashStrategy(func(r record) any {
+		return [2]any{r.Tenant, r.ID}
+	})
+
+	a := record{Tenant: "acme", ID: 1, Cached: []string{"x"}}
+	b := record{Tenant: "acme", ID: 1}
+	c := record{Tenant: "acme", ID: 2, Cached: []string{"x"}}
+
+	if !byID.Equals(a, b) || byID.Hash(a) != byID.Hash(b) {
+		t.Error("Records with the same identity fields should be equal and hash alike")
+	}
+	if byID.Equals(a, c) {
+		t.Error("Records with different identity fields should not be equal")
+	}
+	if byID.Hash(a) != byID.Hash(a) {
+		t.Error("Hash should be deterministic")
+	}
+}
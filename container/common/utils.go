@@ -462,6 +462,23 @@ func (e Entry[K, V]) String() string {
     return fmt.Sprintf("(%v, %v)", e.Key, e.Value)
 }
 
+// Equals reports whether both entries have deeply equal keys and values
+// Unlike ==, it works when K or V holds values that are not comparable, such as slices
+func (e Entry[K, V]) Equals(other Entry[K, V]) bool {
+	return Equal(e.Key, other.Key) && Equal(e.Value, other.Value)
+}
+
+// HashCode returns a stable hash of the key and value, consistent with Equals
+func (e Entry[K, V]) HashCode() uint64 {
+	return combineHashes(Hash(e.Key), Hash(e.Value))
+}
+
+// NewEntryHashStrategy creates a hash strategy that matches entries with Entry.Equals
+// Use it to store entries in hash-based collections when ComparableHashStrategy's == could panic
+func NewEntryHashStrategy[K, V any]() HashStrategy[Entry[K, V]] {
+	return NewFunctionalHashStrategy(Entry[K, V].HashCode, Entry[K, V].Equals)
+}
+
 // Pair is a compatibility struct mirroring Entry for older Go versions
 // Deprecated: prefer Entry/NewEntry directly.
 type Pair[K, V any] struct {
@@ -474,6 +491,32 @@ func (p Pair[K, V]) String() string {
     return fmt.Sprintf("(%v, %v)", p.Key, p.Value)
 }
 
+// ToEntry converts the Pair to the equivalent Entry
+func (p Pair[K, V]) ToEntry() Entry[K, V] {
+    return NewEntry(p.Key, p.Value)
+}
+
+// Equals reports whether both pairs have deeply equal keys and values
+func (p Pair[K, V]) Equals(other Pair[K, V]) bool {
+	return Equal(p.Key, other.Key) && Equal(p.Value, other.Value)
+}
+
+// HashCode returns a stable hash of the key and value, consistent with Equals
+// A Pair hashes the same as the Entry it converts to
+func (p Pair[K, V]) HashCode() uint64 {
+	return combineHashes(Hash(p.Key), Hash(p.Value))
+}
+
+// NewPairHashStrategy creates a hash strategy that matches pairs with Pair.Equals
+func NewPairHashStrategy[K, V any]() HashStrategy[Pair[K, V]] {
+	return NewFunctionalHashStrategy(Pair[K, V].HashCode, Pair[K, V].Equals)
+}
+
+// combineHashes mixes a key hash and a value hash so that swapping them changes the result
+func combineHashes(keyHash, valueHash uint64) uint64 {
+	return keyHash*31 + valueHash
+}
+
 // NewPair is a compatibility constructor that forwards to NewEntry.
 // Deprecated: use NewEntry instead.
 func NewPair[K, V any](key K, value V) Pair[K, V] {
@@ -486,3 +529,68 @@ func ZeroValue[T any]() T {
 	var zero T
 	return zero
 }
+
+// ContainerStats is a snapshot of a container's length and capacity
+type ContainerStats struct {
+	Len        int     // Number of elements, counting duplicates in multisets
+	Distinct   int     // Number of distinct elements; equal to Len when duplicates are not kept
+	Capacity   int     // Allocated slots (array cells or hash buckets), or 0 when not tracked
+	LoadFactor float64 // Len divided by Capacity, or 0 when Capacity is 0
+}
+
+// NewContainerStats creates a ContainerStats and derives its load factor
+func NewContainerStats(length, distinct, capacity int) ContainerStats {
+	stats := ContainerStats{Len: length, Distinct: distinct, Capacity: capacity}
+	if capacity > 0 {
+		stats.LoadFactor = float64(length) / float64(capacity)
+	}
+	return stats
+}
+
+// LimitedStringBuilder builds a bracketed, comma-separated listing of at most limit items
+// Items past the limit are not formatted; Finish summarises them as "… (+k more)"
+type LimitedStringBuilder struct {
+	builder strings.Builder
+	limit   int
+	count   int
+}
+
+// NewLimitedStringBuilder starts a listing with prefix, such as "[" or "{"
+// A negative limit is treated as 0
+func NewLimitedStringBuilder(prefix string, limit int) *LimitedStringBuilder {
+	if limit < 0 {
+		limit = 0
+	}
+	b := &LimitedStringBuilder{limit: limit}
+	b.builder.WriteString(prefix)
+	return b
+}
+
+// Full reports whether the limit has been reached, so callers can stop iterating
+func (b *LimitedStringBuilder) Full() bool {
+	return b.count >= b.limit
+}
+
+// Addf formats and appends one item unless the limit has been reached
+func (b *LimitedStringBuilder) Addf(format string, args ...interface{}) {
+	if b.Full() {
+		return
+	}
+	if b.count > 0 {
+		b.builder.WriteString(", ")
+	}
+	b.builder.WriteString(fmt.Sprintf(format, args...))
+	b.count++
+}
+
+// Finish closes the listing with suffix, noting how many of total items were left out
+func (b *LimitedStringBuilder) Finish(suffix string, total int) string {
+	if more := total - b.count; more > 0 {
+		if b.count > 0 {
+			b.builder.WriteString(", ")
+		}
+		b.builder.WriteString(fmt.Sprintf("… (+%d more)", more))
+	}
+	b.builder.WriteString(suffix)
+	return b.builder.String()
+}
@@ -373,3 +373,92 @@ func BenchmarkEqual(b *testing.B) {
 		Equal(42, 42)
 	}
 }
+
+func TestPairToEntry(t *testing.T) {
+	entry := NewPair("key", 42).ToEntry()
+	if entry != NewEntry("key", 42) {
+		t.Errorf("ToEntry should return the equivalent Entry, got %v", entry)
+	}
+}
+
+func TestEntryEqualsAndHashCode(t *testing.T) {
+	a := NewEntry[string, any]("edge", []int{1, 2})
+	b := NewEntry[string, any]("edge", []int{1, 2})
+	c := NewEntry[string, any]("edge", []int{2, 1})
+
+	if !a.Equals(b) || a.Equals(c) {
+		t.Error("Entry.Equals should compare keys and values deeply")
+	}
+	if a.HashCode() != b.HashCode() {
+		t.Error("Equal entries should have equal hash codes")
+	}
+	if a.HashCode() == c.HashCode() {
+		t.Error("Different values should usually produce different hash codes")
+	}
+	if NewEntry(1, 2).HashCode() == NewEntry(2, 1).HashCode() {
+		t.Error("Swapping key and value should change the hash code")
+	}
+
+	p := NewPair[string, any]("edge", []int{1, 2})
+	if !p.Equals(NewPair[string, any]("edge", []int{1, 2})) {
+		t.Error("Pair.Equals should compare keys and values deeply")
+	}
+	if p.HashCode() != a.HashCode() {
+		t.Error("A Pair should hash the same as the equivalent Entry")
+	}
+
+	strategy := NewEntryHashStrategy[string, any]()
+	if !strategy.Equals(a, b) || strategy.Hash(a) != strategy.Hash(b) {
+		t.Error("Entry hash strategy should agree with Equals and HashCode")
+	}
+	pairStrategy := NewPairHashStrategy[string, any]()
+	if !pairStrategy.Equals(p, p) || pairStrategy.Hash(p) != p.HashCode() {
+		t.Error("Pair hash strategy should agree with Equals and HashCode")
+	}
+}
+
+func TestLimitedStringBuilder(t *testing.T) {
+	build := func(limit, total int) string {
+		b := NewLimitedStringBuilder("[", limit)
+		for i := 0; i < total; i++ {
+			b.Addf("%d", i)
+		}
+		return b.Finish("]", total)
+	}
+
+	cases := []struct {
+		limit, total int
+		expected     string
+	}{
+		{3, 0, "[]"},
+		{3, 2, "[0, 1]"},
+		{3, 3, "[0, 1, 2]"},
+		{3, 5, "[0, 1, 2, … (+2 more)]"},
+		{0, 4, "[… (+4 more)]"},
+		{-1, 1, "[… (+1 more)]"},
+	}
+	for _, c := range cases {
+		if got := build(c.limit, c.total); got != c.expected {
+			t.Errorf("limit %d, total %d: expected %q, got %q", c.limit, c.total, c.expected, got)
+		}
+	}
+
+	b := NewLimitedStringBuilder("{", 1)
+	if b.Full() {
+		t.Error("A new builder with limit 1 should not be full")
+	}
+	b.Addf("%v=%v", "a", 1)
+	if !b.Full() {
+		t.Error("Builder should be full after reaching its limit")
+	}
+}
+
+func TestNewContainerStats(t *testing.T) {
+	stats := NewContainerStats(6, 3, 8)
+	if stats.Len != 6 || stats.Distinct != 3 || stats.Capacity != 8 || stats.LoadFactor != 0.75 {
+		t.Errorf("unexpected stats %+v", stats)
+	}
+	if stats := NewContainerStats(5, 5, 0); stats.LoadFactor != 0 {
+		t.Errorf("load factor without capacity should be 0, got %v", stats.LoadFactor)
+	}
+}
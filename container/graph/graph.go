@@ -29,6 +29,111 @@ func NewEndpointPair[N comparable](nodeU, nodeV N) EndpointPair[N] {
 	return EndpointPair[N]{NodeU: nodeU, NodeV: nodeV}
 }
 
+// Equals reports whether p and other connect the same nodes
+// When directed is false the orientation is ignored, so (u, v) equals (v, u)
+func (p EndpointPair[N]) Equals(other EndpointPair[N], directed bool) bool {
+	if p == other {
+		return true
+	}
+	return !directed && p.NodeU == other.NodeV && p.NodeV == other.NodeU
+}
+
+// Normalized returns the pair with its endpoints in a canonical order
+// Two undirected pairs over the same nodes normalize to the same value, so they
+// compare equal with == and collapse to one element in a set
+func (p EndpointPair[N]) Normalized() EndpointPair[N] {
+	if common.CompareGeneric(p.NodeU, p.NodeV) > 0 {
+		return EndpointPair[N]{NodeU: p.NodeV, NodeV: p.NodeU}
+	}
+	return p
+}
+
+// NetworkEdge is an edge together with the nodes it connects, used for bulk loading a network
+type NetworkEdge[N comparable, E comparable] struct {
+	Edge  E
+	NodeU N
+	NodeV N
+}
+
+// ValueEdge is a pair of nodes together with an edge value, used for bulk loading a value graph
+type ValueEdge[N comparable, V any] struct {
+	NodeU N
+	NodeV N
+	Value V
+}
+
+// capacityEnsurer is implemented by sets that can pre-size their storage, such as set.HashSet
+type capacityEnsurer interface {
+	EnsureCapacity(capacity int)
+}
+
+// ensureSetCapacity pre-sizes s for capacity elements when the set supports it
+func ensureSetCapacity[E comparable](s set.Set[E], capacity int) {
+	if c, ok := s.(capacityEnsurer); ok {
+		c.EnsureCapacity(capacity)
+	}
+}
+
+// growMap returns m, or a copy of m sized for extra more entries when extra exceeds
+// its current length, so repeated bulk loads copy each entry an amortized constant number of times
+func growMap[K comparable, V any](m map[K]V, extra int) map[K]V {
+	if extra <= len(m) {
+		return m
+	}
+	grown := make(map[K]V, len(m)+extra)
+	for k, v := range m {
+		grown[k] = v
+	}
+	return grown
+}
+
+// sameNodesAndEdges reports whether a and b have the same directedness, nodes and edges
+// Edge orientation is only significant for directed graphs
+func sameNodesAndEdges[N comparable](a, b Graph[N]) bool {
+	if a.IsDirected() != b.IsDirected() || a.Size() != b.Size() {
+		return false
+	}
+	nodesEqual := true
+	a.Nodes().ForEach(func(node N) {
+		if nodesEqual && !b.Contains(node) {
+			nodesEqual = false
+		}
+	})
+	if !nodesEqual {
+		return false
+	}
+
+	edges := a.Edges()
+	if edges.Size() != b.Edges().Size() {
+		return false
+	}
+	edgesEqual := true
+	edges.ForEach(func(edge EndpointPair[N]) {
+		if edgesEqual && !b.HasEdgeConnecting(edge.NodeU, edge.NodeV) {
+			edgesEqual = false
+		}
+	})
+	return edgesEqual
+}
+
+// GraphsEqual returns true if a and b have the same directedness, nodes and edges
+// Node and edge iteration order is ignored, so graphs built in different orders compare equal
+// Two nil graphs are equal
+func GraphsEqual[N comparable](a, b Graph[N]) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	return sameNodesAndEdges(a, b)
+}
+
+// ExactEqual is like GraphsEqual but also requires the same self-loop policy and node ElementOrder
+func ExactEqual[N comparable](a, b Graph[N]) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	return a.AllowsSelfLoops() == b.AllowsSelfLoops() && a.NodeOrder() == b.NodeOrder() && sameNodesAndEdges(a, b)
+}
+
 // Graph represents a graph data structure with nodes and edges
 // This is the basic graph interface similar to Guava's Graph
 type Graph[N comparable] interface {
@@ -38,6 +143,12 @@ type Graph[N comparable] interface {
 	// Edges returns all edges in this graph as endpoint pairs
 	Edges() set.Set[EndpointPair[N]]
 
+	// NodeCount returns the number of nodes without building the node set
+	NodeCount() int
+
+	// EdgeCount returns the number of edges without building the edge set
+	EdgeCount() int
+
 	// IsDirected returns true if this is a directed graph
 	IsDirected() bool
 
@@ -128,6 +239,12 @@ type Network[N comparable, E comparable] interface {
 	// Edges returns all edges in this network
 	Edges() set.Set[E]
 
+	// NodeCount returns the number of nodes without building the node set
+	NodeCount() int
+
+	// EdgeCount returns the number of edges without building the edge set
+	EdgeCount() int
+
 	// IsDirected returns true if this is a directed network
 	IsDirected() bool
 
@@ -149,8 +266,13 @@ type Network[N comparable, E comparable] interface {
 
 	// AddEdge adds an edge between two nodes
 	// Returns error if operation is not allowed (e.g., self-loops or parallel edges not allowed)
+	// Adding an edge that is already in the network is a no-op
 	AddEdge(edge E, nodeU, nodeV N) error
 
+	// TryAddEdge adds an edge between two nodes, reporting whether the network changed
+	// Returns false and a typed error if the edge violates the network's constraints
+	TryAddEdge(edge E, nodeU, nodeV N) (bool, error)
+
 	// RemoveNode removes a node and all its incident edges
 	// Returns true if the node was removed, false if it didn't exist
 	RemoveNode(node N) bool
@@ -215,3 +337,39 @@ type Network[N comparable, E comparable] interface {
 	// Common container operations
 	common.Container[N]
 }
+
+// InducedSubgraph returns a new graph holding the given nodes of g and every edge of g
+// whose endpoints are both among them
+// Nodes that are not in g are ignored; the result keeps g's directedness, self-loop
+// policy and node order
+func InducedSubgraph[N comparable](g Graph[N], nodes set.Set[N]) Graph[N] {
+	result := NewMutableGraph[N](g.IsDirected(), g.AllowsSelfLoops(), g.NodeOrder())
+	nodes.ForEach(func(node N) {
+		if g.Contains(node) {
+			result.AddNode(node)
+		}
+	})
+	g.Edges().ForEach(func(edge EndpointPair[N]) {
+		if result.Contains(edge.NodeU) && result.Contains(edge.NodeV) {
+			_ = result.PutEdge(edge.NodeU, edge.NodeV)
+		}
+	})
+	return result
+}
+
+// InducedValueSubgraph is like InducedSubgraph for a ValueGraph, copying edge values
+func InducedValueSubgraph[N comparable, V any](g ValueGraph[N, V], nodes set.Set[N]) ValueGraph[N, V] {
+	result := NewMutableValueGraph[N, V](g.IsDirected(), g.AllowsSelfLoops(), g.NodeOrder())
+	nodes.ForEach(func(node N) {
+		if g.Contains(node) {
+			result.AddNode(node)
+		}
+	})
+	g.Edges().ForEach(func(edge EndpointPair[N]) {
+		if result.Contains(edge.NodeU) && result.Contains(edge.NodeV) {
+			value, _ := g.EdgeValue(edge.NodeU, edge.NodeV)
+			result.PutEdgeValue(edge.NodeU, edge.NodeV, value)
+		}
+	})
+	return result
+}
@@ -1,7 +1,11 @@
 package graph
 
 import (
+	"errors"
 	"testing"
+
+	"github.com/chenjianyu/collections/container/common"
+	"github.com/chenjianyu/collections/container/set"
 )
 
 func TestMutableGraphBasicOperations(t *testing.T) {
@@ -260,3 +264,268 @@ func TestGraphString(t *testing.T) {
 		t.Error("Expected meaningful string representation")
 	}
 }
+
+func TestEndpointPairEquality(t *testing.T) {
+	ab := NewEndpointPair("A", "B")
+	ba := NewEndpointPair("B", "A")
+
+	if !ab.Equals(ba, false) {
+		t.Error("Undirected (A, B) should equal (B, A)")
+	}
+	if ab.Equals(ba, true) {
+		t.Error("Directed (A, B) should not equal (B, A)")
+	}
+	if !ab.Equals(ab, true) {
+		t.Error("Pair should equal itself")
+	}
+	if ab.Normalized() != ba.Normalized() {
+		t.Error("Normalized undirected pairs over the same nodes should be ==")
+	}
+
+	edges := set.New[EndpointPair[string]]()
+	edges.Add(ab.Normalized())
+	edges.Add(ba.Normalized())
+	if edges.Size() != 1 {
+		t.Errorf("Normalized pairs should collapse in a set, got %d", edges.Size())
+	}
+}
+
+func TestGraphEquals(t *testing.T) {
+	g1 := UndirectedGraph[int]()
+	g1.PutEdge(1, 2)
+	g1.PutEdge(2, 3)
+	g1.AddNode(4)
+
+	// Same structure, different insertion order and orientation
+	g2 := UndirectedGraph[int]()
+	g2.AddNode(4)
+	g2.PutEdge(3, 2)
+	g2.PutEdge(2, 1)
+
+	if !g1.(*MutableGraph[int]).Equals(g2) || !g2.(*MutableGraph[int]).Equals(g1) {
+		t.Error("Undirected graphs with the same nodes and edges should be equal")
+	}
+	if g1.Edges().Size() != 2 || !g1.Edges().Difference(g2.Edges()).IsEmpty() {
+		t.Errorf("Edge sets of equal undirected graphs should match, got %v and %v", g1.Edges(), g2.Edges())
+	}
+
+	g2.PutEdge(1, 4)
+	if g1.(*MutableGraph[int]).Equals(g2) {
+		t.Error("Graphs with different edges should not be equal")
+	}
+
+	d1 := DirectedGraph[int]()
+	d1.PutEdge(1, 2)
+	d2 := DirectedGraph[int]()
+	d2.PutEdge(2, 1)
+	if d1.(*MutableGraph[int]).Equals(d2) {
+		t.Error("Directed graphs with reversed edges should not be equal")
+	}
+
+	u := UndirectedGraph[int]()
+	u.PutEdge(1, 2)
+	if d1.(*MutableGraph[int]).Equals(u) {
+		t.Error("Directed and undirected graphs should not be equal")
+	}
+}
+
+func TestInducedSubgraph(t *testing.T) {
+	g := DirectedGraph[string]()
+	g.PutEdge("A", "B")
+	g.PutEdge("B", "C")
+	g.PutEdge("C", "A")
+	g.PutEdge("C", "D")
+	g.PutEdge("D", "E")
+
+	community := set.New[string]()
+	community.Add("A")
+	community.Add("B")
+	community.Add("C")
+	community.Add("Z") // not in the graph
+
+	sub := InducedSubgraph[string](g, community)
+	if !sub.IsDirected() {
+		t.Error("Subgraph should keep the graph's directedness")
+	}
+	if sub.Size() != 3 || sub.Contains("Z") || sub.Contains("D") {
+		t.Errorf("Subgraph should contain exactly A, B and C, got %v", sub.Nodes())
+	}
+	if sub.Edges().Size() != 3 {
+		t.Errorf("Subgraph should keep the 3 edges inside the community, got %d", sub.Edges().Size())
+	}
+	if !sub.HasEdgeConnecting("C", "A") || sub.HasEdgeConnecting("A", "C") {
+		t.Error("Subgraph should preserve edge direction")
+	}
+
+	// The subgraph is independent of the source graph
+	sub.RemoveNode("A")
+	if !g.Contains("A") || !g.HasEdgeConnecting("A", "B") {
+		t.Error("Changing the subgraph should not affect the source")
+	}
+
+	empty := InducedSubgraph[string](g, set.New[string]())
+	if !empty.IsEmpty() || empty.Edges().Size() != 0 {
+		t.Error("Subgraph of no nodes should be empty")
+	}
+}
+
+func TestAccessorsOnMissingNodeReturnError(t *testing.T) {
+	g := DirectedGraph[string]()
+	g.PutEdge("A", "B")
+	vg := DirectedValueGraph[string, int]()
+	vg.PutEdgeValue("A", "B", 1)
+	n := DirectedNetwork[string, string]()
+	n.AddEdge("e", "A", "B")
+
+	graphs := map[string]Graph[string]{
+		"graph":         g,
+		"value graph":   vg,
+		"network graph": n.AsGraph(),
+	}
+	for name, graph := range graphs {
+		// A node removed between lookups must not cause a panic
+		graph.RemoveNode("B")
+
+		if _, err := graph.Successors("B"); !errors.Is(err, common.ErrNodeNotFound) {
+			t.Errorf("%s: Successors should return ErrNodeNotFound, got %v", name, err)
+		}
+		if _, err := graph.Predecessors("B"); !errors.Is(err, common.ErrNodeNotFound) {
+			t.Errorf("%s: Predecessors should return ErrNodeNotFound, got %v", name, err)
+		}
+		if _, err := graph.AdjacentNodes("B"); !errors.Is(err, common.ErrNodeNotFound) {
+			t.Errorf("%s: AdjacentNodes should return ErrNodeNotFound, got %v", name, err)
+		}
+		if _, err := graph.IncidentEdges("B"); !errors.Is(err, common.ErrNodeNotFound) {
+			t.Errorf("%s: IncidentEdges should return ErrNodeNotFound, got %v", name, err)
+		}
+		if _, err := graph.Degree("B"); !errors.Is(err, common.ErrNodeNotFound) {
+			t.Errorf("%s: Degree should return ErrNodeNotFound, got %v", name, err)
+		}
+		if _, err := graph.InDegree("B"); !errors.Is(err, common.ErrNodeNotFound) {
+			t.Errorf("%s: InDegree should return ErrNodeNotFound, got %v", name, err)
+		}
+		if _, err := graph.OutDegree("B"); !errors.Is(err, common.ErrNodeNotFound) {
+			t.Errorf("%s: OutDegree should return ErrNodeNotFound, got %v", name, err)
+		}
+		if graph.HasEdgeConnecting("A", "B") {
+			t.Errorf("%s: HasEdgeConnecting should be false for a missing node", name)
+		}
+	}
+
+	if _, err := n.InEdges("B"); !errors.Is(err, common.ErrNodeNotFound) {
+		t.Errorf("InEdges should return ErrNodeNotFound, got %v", err)
+	}
+	if _, err := n.OutEdges("B"); !errors.Is(err, common.ErrNodeNotFound) {
+		t.Errorf("OutEdges should return ErrNodeNotFound, got %v", err)
+	}
+	if _, err := n.IncidentNodes("e"); !errors.Is(err, common.ErrEdgeNotFound) {
+		t.Errorf("IncidentNodes of a removed edge should return ErrEdgeNotFound, got %v", err)
+	}
+	if edges := n.EdgesConnecting("A", "B"); edges.Size() != 0 {
+		t.Errorf("EdgesConnecting should be empty for a missing node, got %v", edges)
+	}
+}
+
+func TestGraphsEqual(t *testing.T) {
+	a := NewMutableGraph[int](true, false, Insertion)
+	_ = a.PutEdge(1, 2)
+	_ = a.PutEdge(2, 3)
+	a.AddNode(4)
+
+	b := NewMutableGraph[int](true, false, Insertion)
+	b.AddNode(4)
+	_ = b.PutEdge(2, 3)
+	_ = b.PutEdge(1, 2)
+
+	if !GraphsEqual[int](a, b) || !ExactEqual[int](a, b) {
+		t.Error("graphs built in different orders should be equal")
+	}
+
+	c := NewMutableGraph[int](true, true, Natural)
+	_ = c.PutEdge(2, 3)
+	_ = c.PutEdge(1, 2)
+	c.AddNode(4)
+	if !GraphsEqual[int](a, c) {
+		t.Error("GraphsEqual should ignore node order and self-loop policy")
+	}
+	if ExactEqual[int](a, c) {
+		t.Error("ExactEqual should require the same node order and self-loop policy")
+	}
+
+	_ = b.PutEdge(3, 1)
+	if GraphsEqual[int](a, b) || ExactEqual[int](a, b) {
+		t.Error("graphs with different edges should not be equal")
+	}
+
+	reversed := NewMutableGraph[int](true, false, Insertion)
+	_ = reversed.PutEdge(2, 1)
+	_ = reversed.PutEdge(3, 2)
+	reversed.AddNode(4)
+	if GraphsEqual[int](a, reversed) {
+		t.Error("edge direction should matter for directed graphs")
+	}
+
+	if !GraphsEqual[int](nil, nil) || GraphsEqual[int](a, nil) || ExactEqual[int](nil, a) {
+		t.Error("nil graphs should only equal nil")
+	}
+}
+
+func TestGraphNodeAndEdgeCounts(t *testing.T) {
+	for _, directed := range []bool{true, false} {
+		g := NewMutableGraph[int](directed, true, Unordered)
+		vg := NewMutableValueGraph[int, int](directed, true, Unordered)
+		check := func(step string) {
+			t.Helper()
+			if g.NodeCount() != g.Nodes().Size() || g.EdgeCount() != g.Edges().Size() {
+				t.Errorf("directed=%v %s: MutableGraph counts (%d, %d) should match (%d, %d)",
+					directed, step, g.NodeCount(), g.EdgeCount(), g.Nodes().Size(), g.Edges().Size())
+			}
+			if vg.NodeCount() != vg.Nodes().Size() || vg.EdgeCount() != vg.Edges().Size() {
+				t.Errorf("directed=%v %s: MutableValueGraph counts (%d, %d) should match (%d, %d)",
+					directed, step, vg.NodeCount(), vg.EdgeCount(), vg.Nodes().Size(), vg.Edges().Size())
+			}
+			if vg.AsGraph().EdgeCount() != vg.EdgeCount() {
+				t.Errorf("directed=%v %s: AsGraph edge count should match", directed, step)
+			}
+		}
+
+		for _, e := range [][2]int{{1, 2}, {2, 1}, {2, 3}, {3, 3}, {1, 2}, {4, 1}} {
+			_ = g.PutEdge(e[0], e[1])
+			vg.PutEdgeValue(e[0], e[1], e[0]+e[1])
+		}
+		g.AddNode(9)
+		vg.AddNode(9)
+		check("after puts")
+
+		g.RemoveEdge(2, 3)
+		vg.RemoveEdge(2, 3)
+		g.RemoveEdge(5, 6)
+		check("after edge removal")
+
+		g.RemoveNode(1)
+		vg.RemoveNode(1)
+		check("after node removal")
+
+		g.Clear()
+		vg.Clear()
+		check("after clear")
+		if g.EdgeCount() != 0 || vg.NodeCount() != 0 {
+			t.Error("Clear should reset the counts")
+		}
+	}
+
+	n := NewMutableNetwork[string, string](false, false, true, Unordered, Unordered)
+	_ = n.AddEdge("e1", "a", "b")
+	_ = n.AddEdge("e2", "b", "a")
+	_ = n.AddEdge("e3", "b", "c")
+	if n.NodeCount() != 3 || n.EdgeCount() != 3 {
+		t.Errorf("network counts should be (3, 3), got (%d, %d)", n.NodeCount(), n.EdgeCount())
+	}
+	if n.AsGraph().EdgeCount() != 2 {
+		t.Errorf("graph view should count parallel edges once, got %d", n.AsGraph().EdgeCount())
+	}
+	n.RemoveEdge("e3")
+	if n.EdgeCount() != 2 {
+		t.Errorf("network edge count should be 2 after removal, got %d", n.EdgeCount())
+	}
+}
@@ -18,6 +18,8 @@ type MutableGraph[N comparable] struct {
 	adjacencyMap map[N]set.Set[N]
 	// For directed graphs, we also maintain predecessors
 	predecessorMap map[N]set.Set[N]
+	// edgeCount is the number of edges, maintained on every edge mutation
+	edgeCount int
 }
 
 // NewMutableGraph creates a new mutable graph
@@ -50,11 +52,8 @@ func (g *MutableGraph[N]) Edges() set.Set[EndpointPair[N]] {
 			if g.directed {
 				result.Add(NewEndpointPair(node, successor))
 			} else {
-				// For undirected graphs, only add each edge once
-				// Use a consistent ordering to avoid duplicates
-				if fmt.Sprintf("%v", node) <= fmt.Sprintf("%v", successor) {
-					result.Add(NewEndpointPair(node, successor))
-				}
+				// For undirected graphs, only add each edge once in canonical orientation
+				result.Add(NewEndpointPair(node, successor).Normalized())
 			}
 		})
 	}
@@ -62,6 +61,15 @@ func (g *MutableGraph[N]) Edges() set.Set[EndpointPair[N]] {
 	return result
 }
 
+// Equals returns true if other has the same directedness, nodes and edges
+// Node and edge ordering are ignored, as is edge orientation for undirected graphs
+func (g *MutableGraph[N]) Equals(other Graph[N]) bool {
+	if other == nil {
+		return false
+	}
+	return sameNodesAndEdges[N](g, other)
+}
+
 // IsDirected returns true if this is a directed graph
 func (g *MutableGraph[N]) IsDirected() bool {
 	return g.directed
@@ -108,6 +116,7 @@ func (g *MutableGraph[N]) PutEdge(nodeU, nodeV N) error {
 	}
 
 	// Add edge
+	g.edgeCount++
 	g.adjacencyMap[nodeU].Add(nodeV)
 	if g.directed {
 		g.predecessorMap[nodeV].Add(nodeU)
@@ -158,6 +167,7 @@ func (g *MutableGraph[N]) RemoveEdge(nodeU, nodeV N) bool {
 		return false
 	}
 
+	g.edgeCount--
 	g.adjacencyMap[nodeU].Remove(nodeV)
 	if g.directed {
 		g.predecessorMap[nodeV].Remove(nodeU)
@@ -291,6 +301,16 @@ func (g *MutableGraph[N]) HasEdgeConnecting(nodeU, nodeV N) bool {
 	return g.adjacencyMap[nodeU].Contains(nodeV)
 }
 
+// NodeCount returns the number of nodes in the graph
+func (g *MutableGraph[N]) NodeCount() int {
+	return g.nodes.Size()
+}
+
+// EdgeCount returns the number of edges in the graph, maintained incrementally
+func (g *MutableGraph[N]) EdgeCount() int {
+	return g.edgeCount
+}
+
 // Size returns the number of nodes in the graph
 func (g *MutableGraph[N]) Size() int {
 	return g.nodes.Size()
@@ -306,6 +326,7 @@ func (g *MutableGraph[N]) Clear() {
 	g.nodes.Clear()
 	g.adjacencyMap = make(map[N]set.Set[N])
 	g.predecessorMap = make(map[N]set.Set[N])
+	g.edgeCount = 0
 }
 
 // Contains returns true if the graph contains the given node
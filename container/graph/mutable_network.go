@@ -102,20 +102,41 @@ func (n *MutableNetwork[N, E]) AddNode(node N) bool {
 }
 
 // AddEdge adds an edge between two nodes
+// Adding an edge that is already in the network is a no-op, whatever nodes it connects;
+// use TryAddEdge to reject an edge reused for different nodes
 func (n *MutableNetwork[N, E]) AddEdge(edge E, nodeU, nodeV N) error {
-	// Check if edge already exists
 	if n.edges.Contains(edge) {
 		return nil // Edge already exists, no error
 	}
+	_, err := n.TryAddEdge(edge, nodeU, nodeV)
+	return err
+}
+
+// TryAddEdge adds an edge between two nodes, reporting whether the network changed
+// Returns false and a nil error if the edge already connects the same nodes
+// Returns false and an error wrapping ErrSelfLoopNotAllowed, ErrParallelEdgeNotAllowed or
+// ErrInvalidArgument (for an edge already connecting different nodes) on a violation;
+// the network is left unchanged in that case
+func (n *MutableNetwork[N, E]) TryAddEdge(edge E, nodeU, nodeV N) (bool, error) {
+	endpoints := NewEndpointPair(nodeU, nodeV)
+
+	// Check if edge already exists
+	if n.edges.Contains(edge) {
+		if existing := n.edgeToNodes[edge]; !existing.Equals(endpoints, n.directed) {
+			return false, common.InvalidArgumentError("edge",
+				fmt.Sprintf("%v already connects %v and %v", edge, existing.NodeU, existing.NodeV))
+		}
+		return false, nil
+	}
 
 	// Check self-loop constraint
 	if !n.allowSelfLoops && nodeU == nodeV {
-		return common.SelfLoopNotAllowedError(nodeU)
+		return false, common.SelfLoopNotAllowedError(nodeU)
 	}
 
 	// Check parallel edge constraint
 	if !n.allowParallelEdges && n.HasEdgeConnecting(nodeU, nodeV) {
-		return common.ParallelEdgeNotAllowedError(nodeU, nodeV)
+		return false, common.ParallelEdgeNotAllowedError(nodeU, nodeV)
 	}
 
 	// Add nodes if they don't exist
@@ -124,7 +145,7 @@ func (n *MutableNetwork[N, E]) AddEdge(edge E, nodeU, nodeV N) error {
 
 	// Add edge
 	n.edges.Add(edge)
-	n.edgeToNodes[edge] = NewEndpointPair(nodeU, nodeV)
+	n.edgeToNodes[edge] = endpoints
 	n.nodeToEdges[nodeU].Add(edge)
 	n.nodeToEdges[nodeV].Add(edge)
 
@@ -133,7 +154,52 @@ func (n *MutableNetwork[N, E]) AddEdge(edge E, nodeU, nodeV N) error {
 		n.inEdges[nodeV].Add(edge)
 	}
 
-	return nil
+	return true, nil
+}
+
+// AddNodes adds every node in nodes, pre-sizing the node storage once
+// Returns the number of nodes that were not already present
+func (n *MutableNetwork[N, E]) AddNodes(nodes []N) int {
+	n.reserveNodes(len(nodes))
+	added := 0
+	for _, node := range nodes {
+		if n.AddNode(node) {
+			added++
+		}
+	}
+	return added
+}
+
+// AddEdges adds every edge in edges as AddEdge would, pre-sizing the edge storage once
+// Edges are added in order; on the first violation the remaining edges are skipped and the
+// error is returned along with the number of edges added before it
+func (n *MutableNetwork[N, E]) AddEdges(edges []NetworkEdge[N, E]) (int, error) {
+	ensureSetCapacity(n.edges, n.edges.Size()+len(edges))
+	n.edgeToNodes = growMap(n.edgeToNodes, len(edges))
+	added := 0
+	for _, e := range edges {
+		if n.edges.Contains(e.Edge) {
+			continue
+		}
+		changed, err := n.TryAddEdge(e.Edge, e.NodeU, e.NodeV)
+		if err != nil {
+			return added, err
+		}
+		if changed {
+			added++
+		}
+	}
+	return added, nil
+}
+
+// reserveNodes pre-sizes the node set and per-node maps for extra more nodes
+func (n *MutableNetwork[N, E]) reserveNodes(extra int) {
+	ensureSetCapacity(n.nodes, n.nodes.Size()+extra)
+	n.nodeToEdges = growMap(n.nodeToEdges, extra)
+	if n.directed {
+		n.inEdges = growMap(n.inEdges, extra)
+		n.outEdges = growMap(n.outEdges, extra)
+	}
 }
 
 // RemoveNode removes a node and all its incident edges
@@ -391,11 +457,126 @@ func (n *MutableNetwork[N, E]) HasEdgeConnecting(nodeU, nodeV N) bool {
 	return !n.EdgesConnecting(nodeU, nodeV).IsEmpty()
 }
 
+// Equals returns true if other has the same directedness, nodes and edges, with each
+// edge connecting the same endpoints
+// Ordering is ignored, as is endpoint orientation for undirected networks
+func (n *MutableNetwork[N, E]) Equals(other Network[N, E]) bool {
+	if other == nil || n.directed != other.IsDirected() || n.Size() != other.Size() {
+		return false
+	}
+	equal := true
+	n.nodes.ForEach(func(node N) {
+		if equal && !other.Contains(node) {
+			equal = false
+		}
+	})
+	if !equal || n.edges.Size() != other.Edges().Size() {
+		return false
+	}
+	for edge, endpoints := range n.edgeToNodes {
+		otherEndpoints, err := other.IncidentNodes(edge)
+		if err != nil || !endpoints.Equals(otherEndpoints, n.directed) {
+			return false
+		}
+	}
+	return true
+}
+
+// ContractNodes merges absorb into keep: every edge incident to absorb is reattached
+// to keep and absorb is removed from the network
+// An edge between keep and absorb becomes a self-loop on keep, which is dropped unless
+// self-loops are allowed; an edge that would duplicate an existing connection is dropped
+// unless parallel edges are allowed
+// Returns the dropped edges in the order they were re-added, or an error if either node
+// is not in the network or keep equals absorb
+func (n *MutableNetwork[N, E]) ContractNodes(keep, absorb N) ([]E, error) {
+	if !n.nodes.Contains(keep) {
+		return nil, common.NodeNotFoundError(keep)
+	}
+	if !n.nodes.Contains(absorb) {
+		return nil, common.NodeNotFoundError(absorb)
+	}
+	if keep == absorb {
+		return nil, common.InvalidArgumentError("absorb", "cannot contract a node into itself")
+	}
+
+	edges := n.nodeToEdges[absorb].ToSlice()
+	endpoints := make([]EndpointPair[N], len(edges))
+	for i, edge := range edges {
+		pair := n.edgeToNodes[edge]
+		if pair.NodeU == absorb {
+			pair.NodeU = keep
+		}
+		if pair.NodeV == absorb {
+			pair.NodeV = keep
+		}
+		endpoints[i] = pair
+		n.RemoveEdge(edge)
+	}
+
+	n.RemoveNode(absorb)
+
+	var dropped []E
+	for i, edge := range edges {
+		// Edges violating the self-loop or parallel-edge policy are dropped
+		if err := n.AddEdge(edge, endpoints[i].NodeU, endpoints[i].NodeV); err != nil {
+			dropped = append(dropped, edge)
+		}
+	}
+
+	return dropped, nil
+}
+
+// RemoveEdgesIf removes every edge that satisfies pred, which also receives the edge's endpoints
+// Matching edges are collected before any is removed, so pred sees the network unchanged
+// Returns the number of edges removed
+func (n *MutableNetwork[N, E]) RemoveEdgesIf(pred func(edge E, endpoints EndpointPair[N]) bool) int {
+	var matched []E
+	n.edges.ForEach(func(edge E) {
+		if pred(edge, n.edgeToNodes[edge]) {
+			matched = append(matched, edge)
+		}
+	})
+
+	for _, edge := range matched {
+		n.RemoveEdge(edge)
+	}
+
+	return len(matched)
+}
+
+// FilterEdges returns a new graph holding all nodes of this network and an edge between
+// two nodes for every pair connected by at least one edge that satisfies pred
+// The result is a snapshot; later changes to either graph are not reflected in the other
+func (n *MutableNetwork[N, E]) FilterEdges(pred func(edge E, endpoints EndpointPair[N]) bool) Graph[N] {
+	result := NewMutableGraph[N](n.directed, n.allowSelfLoops, n.nodeOrder)
+	n.nodes.ForEach(func(node N) {
+		result.AddNode(node)
+	})
+	n.edges.ForEach(func(edge E) {
+		endpoints := n.edgeToNodes[edge]
+		if pred(edge, endpoints) {
+			_ = result.PutEdge(endpoints.NodeU, endpoints.NodeV)
+		}
+	})
+	return result
+}
+
 // AsGraph returns a view of this network as a basic graph
 func (n *MutableNetwork[N, E]) AsGraph() Graph[N] {
 	return &networkAsGraph[N, E]{n}
 }
 
+// NodeCount returns the number of nodes in the network
+func (n *MutableNetwork[N, E]) NodeCount() int {
+	return n.nodes.Size()
+}
+
+// EdgeCount returns the number of edges in the network
+func (n *MutableNetwork[N, E]) EdgeCount() int {
+	return n.edges.Size()
+}
+
 // Size returns the number of nodes in the network
 func (n *MutableNetwork[N, E]) Size() int {
 	return n.nodes.Size()
@@ -466,11 +647,27 @@ func (g *networkAsGraph[N, E]) Edges() set.Set[EndpointPair[N]] {
 	result := set.New[EndpointPair[N]]()
 	g.network.edges.ForEach(func(edge E) {
 		endpoints := g.network.edgeToNodes[edge]
+		if !g.network.directed {
+			endpoints = endpoints.Normalized()
+		}
 		result.Add(endpoints)
 	})
 	return result
 }
 
+func (g *networkAsGraph[N, E]) NodeCount() int {
+	return g.network.NodeCount()
+}
+
+// EdgeCount counts connected node pairs, so parallel edges count once; without parallel
+// edges this is the network's edge count, otherwise the pairs are collected
+func (g *networkAsGraph[N, E]) EdgeCount() int {
+	if !g.network.allowParallelEdges {
+		return g.network.EdgeCount()
+	}
+	return g.Edges().Size()
+}
+
 func (g *networkAsGraph[N, E]) IsDirected() bool {
 	return g.network.IsDirected()
 }
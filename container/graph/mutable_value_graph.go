@@ -49,12 +49,30 @@ func (g *MutableValueGraph[N, V]) Edges() set.Set[EndpointPair[N]] {
 	result := set.New[EndpointPair[N]]()
 
 	for edge := range g.edgeValues {
+		if !g.directed {
+			edge = edge.Normalized()
+		}
 		result.Add(edge)
 	}
 
 	return result
 }
 
+// Equals returns true if other has the same directedness, nodes, edges and edge values
+// Node and edge ordering are ignored, as is edge orientation for undirected graphs
+func (g *MutableValueGraph[N, V]) Equals(other ValueGraph[N, V]) bool {
+	if other == nil || !sameNodesAndEdges[N](g, other) {
+		return false
+	}
+	for edge, value := range g.edgeValues {
+		otherValue, _ := other.EdgeValue(edge.NodeU, edge.NodeV)
+		if !common.Equal(value, otherValue) {
+			return false
+		}
+	}
+	return true
+}
+
 // IsDirected returns true if this is a directed graph
 func (g *MutableValueGraph[N, V]) IsDirected() bool {
 	return g.directed
@@ -357,11 +375,153 @@ func (g *MutableValueGraph[N, V]) PutEdgeValue(nodeU, nodeV N, value V) (V, bool
 	return zeroValue, false
 }
 
+// PutEdges adds or updates every edge in edges as PutEdgeValue would, pre-sizing the edge
+// storage once
+// Self-loops are skipped when the graph does not allow them
+// Returns the number of edges that were not already present
+func (g *MutableValueGraph[N, V]) PutEdges(edges []ValueEdge[N, V]) int {
+	g.edgeValues = growMap(g.edgeValues, len(edges))
+	added := 0
+	for _, e := range edges {
+		if !g.allowSelfLoops && e.NodeU == e.NodeV {
+			continue
+		}
+		if _, existed := g.PutEdgeValue(e.NodeU, e.NodeV, e.Value); !existed {
+			added++
+		}
+	}
+	return added
+}
+
+// ContractNodes merges absorb into keep: every edge incident to absorb is redirected
+// to keep and absorb is removed from the graph
+// An edge between keep and absorb becomes a self-loop on keep, which is dropped unless
+// self-loops are allowed. When a redirected edge coincides with an existing edge, the
+// values are merged with combine(existing, redirected); if combine is nil the redirected
+// edge is dropped and the existing value is kept
+// Edges leaving absorb are redirected before edges entering it, each in the order of
+// absorb's adjacency, so combine sees the same argument order on every run
+// Returns the dropped edges with their endpoints before contraction, in the order they
+// were redirected, or an error if either node is not in the graph or keep equals absorb
+func (g *MutableValueGraph[N, V]) ContractNodes(keep, absorb N, combine func(V, V) V) ([]EndpointPair[N], error) {
+	if !g.nodes.Contains(keep) {
+		return nil, common.NodeNotFoundError(keep)
+	}
+	if !g.nodes.Contains(absorb) {
+		return nil, common.NodeNotFoundError(absorb)
+	}
+	if keep == absorb {
+		return nil, common.InvalidArgumentError("absorb", "cannot contract a node into itself")
+	}
+
+	// Collect absorb's edges before removing it
+	type valuedEdge struct {
+		original EndpointPair[N]
+		value    V
+	}
+	var redirected []valuedEdge
+	g.adjacencyMap[absorb].ForEach(func(successor N) {
+		value, _ := g.EdgeValue(absorb, successor)
+		redirected = append(redirected, valuedEdge{original: NewEndpointPair(absorb, successor), value: value})
+	})
+	if g.directed {
+		g.predecessorMap[absorb].ForEach(func(predecessor N) {
+			if predecessor == absorb {
+				return // the self-loop was collected as an outgoing edge
+			}
+			value, _ := g.EdgeValue(predecessor, absorb)
+			redirected = append(redirected, valuedEdge{original: NewEndpointPair(predecessor, absorb), value: value})
+		})
+	}
+
+	g.RemoveNode(absorb)
+
+	var dropped []EndpointPair[N]
+	for _, edge := range redirected {
+		nodeU, nodeV := edge.original.NodeU, edge.original.NodeV
+		if nodeU == absorb {
+			nodeU = keep
+		}
+		if nodeV == absorb {
+			nodeV = keep
+		}
+		if nodeU == nodeV && !g.allowSelfLoops {
+			dropped = append(dropped, edge.original)
+			continue
+		}
+		value := edge.value
+		if existing, exists := g.EdgeValue(nodeU, nodeV); exists {
+			if combine == nil {
+				dropped = append(dropped, edge.original)
+				continue
+			}
+			value = combine(existing, value)
+		}
+		g.PutEdgeValue(nodeU, nodeV, value)
+	}
+
+	return dropped, nil
+}
+
+// RemoveEdgesIf removes every edge whose endpoints and value satisfy pred
+// Matching edges are collected before any is removed, so pred sees the graph unchanged
+// Undirected edges are passed to pred in normalized orientation
+// Returns the number of edges removed
+func (g *MutableValueGraph[N, V]) RemoveEdgesIf(pred func(edge EndpointPair[N], value V) bool) int {
+	var matched []EndpointPair[N]
+	for edge, value := range g.edgeValues {
+		if g.matchesEdge(edge, value, pred) {
+			matched = append(matched, edge)
+		}
+	}
+
+	for _, edge := range matched {
+		g.RemoveEdge(edge.NodeU, edge.NodeV)
+	}
+
+	return len(matched)
+}
+
+// FilterEdges returns a new graph holding all nodes of this graph and only the edges
+// whose endpoints and value satisfy pred
+// The result is a snapshot; later changes to either graph are not reflected in the other
+func (g *MutableValueGraph[N, V]) FilterEdges(pred func(edge EndpointPair[N], value V) bool) Graph[N] {
+	result := NewMutableGraph[N](g.directed, g.allowSelfLoops, g.nodeOrder)
+	g.nodes.ForEach(func(node N) {
+		result.AddNode(node)
+	})
+	for edge, value := range g.edgeValues {
+		if g.matchesEdge(edge, value, pred) {
+			_ = result.PutEdge(edge.NodeU, edge.NodeV)
+		}
+	}
+	return result
+}
+
+// matchesEdge applies pred to a stored edge, normalizing it for undirected graphs
+func (g *MutableValueGraph[N, V]) matchesEdge(edge EndpointPair[N], value V, pred func(EndpointPair[N], V) bool) bool {
+	if !g.directed {
+		edge = edge.Normalized()
+	}
+	return pred(edge, value)
+}
+
 // AsGraph returns a view of this value graph as a basic graph
 func (g *MutableValueGraph[N, V]) AsGraph() Graph[N] {
 	return &valueGraphAsGraph[N, V]{g}
 }
 
+// NodeCount returns the number of nodes in the graph
+func (g *MutableValueGraph[N, V]) NodeCount() int {
+	return g.nodes.Size()
+}
+
+// EdgeCount returns the number of edges in the graph
+// Each edge, undirected or not, has exactly one entry in edgeValues
+func (g *MutableValueGraph[N, V]) EdgeCount() int {
+	return len(g.edgeValues)
+}
+
 // Size returns the number of nodes in the graph
 func (g *MutableValueGraph[N, V]) Size() int {
 	return g.nodes.Size()
@@ -430,6 +590,14 @@ func (g *valueGraphAsGraph[N, V]) Edges() set.Set[EndpointPair[N]] {
 	return g.valueGraph.Edges()
 }
 
+func (g *valueGraphAsGraph[N, V]) NodeCount() int {
+	return g.valueGraph.NodeCount()
+}
+
+func (g *valueGraphAsGraph[N, V]) EdgeCount() int {
+	return g.valueGraph.EdgeCount()
+}
+
 func (g *valueGraphAsGraph[N, V]) IsDirected() bool {
 	return g.valueGraph.IsDirected()
 }
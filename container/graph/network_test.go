@@ -1,7 +1,12 @@
 package graph
 
 import (
+	"errors"
+	"sort"
+	"strings"
 	"testing"
+
+	"github.com/chenjianyu/collections/container/common"
 )
 
 func TestMutableNetworkBasicOperations(t *testing.T) {
@@ -371,3 +376,233 @@ func TestNetworkAsGraphView(t *testing.T) {
 		t.Error("Expected error when calling PutEdge on network graph view")
 	}
 }
+
+func TestNetworkContractNodes(t *testing.T) {
+	n := NewMutableNetwork[string, string](false, false, false, Insertion, Insertion)
+	n.AddEdge("ab", "A", "B")
+	n.AddEdge("ac", "A", "C")
+	n.AddEdge("bc", "B", "C")
+	n.AddEdge("bd", "B", "D")
+
+	dropped, err := n.ContractNodes("A", "B")
+	if err != nil {
+		t.Fatalf("ContractNodes should not return error, got %v", err)
+	}
+	sort.Strings(dropped)
+	if len(dropped) != 2 || dropped[0] != "ab" || dropped[1] != "bc" {
+		t.Errorf("ContractNodes should report ab and bc as dropped, got %v", dropped)
+	}
+
+	if n.Contains("B") {
+		t.Error("Absorbed node B should be removed")
+	}
+	edges := n.Edges()
+	if edges.Contains("ab") {
+		t.Error("Edge ab should be dropped as a self-loop")
+	}
+	if edges.Contains("bc") {
+		t.Error("Edge bc should be dropped as a parallel edge")
+	}
+	if endpoints, err := n.IncidentNodes("bd"); err != nil || endpoints.NodeU != "A" || endpoints.NodeV != "D" {
+		t.Errorf("Edge bd should now connect A and D, got %v, %v", endpoints, err)
+	}
+	if degree, _ := n.Degree("A"); degree != 2 {
+		t.Errorf("Expected degree of A to be 2, got %d", degree)
+	}
+
+	// Multigraph keeps parallel edges and self-loops
+	m := NewMutableNetwork[string, string](true, true, true, Insertion, Insertion)
+	m.AddEdge("xy", "X", "Y")
+	m.AddEdge("zx", "Z", "X")
+	m.AddEdge("zy", "Z", "Y")
+	if dropped, err := m.ContractNodes("X", "Y"); err != nil || len(dropped) != 0 {
+		t.Fatalf("ContractNodes should drop nothing in a multigraph, got %v, %v", dropped, err)
+	}
+	if m.EdgesConnecting("Z", "X").Size() != 2 {
+		t.Error("Expected two parallel edges from Z to X")
+	}
+	if !m.HasEdgeConnecting("X", "X") {
+		t.Error("Expected self-loop on X")
+	}
+}
+
+func TestNetworkEquals(t *testing.T) {
+	n1 := NewMutableNetwork[string, string](false, false, false, Insertion, Insertion)
+	n1.AddEdge("e1", "A", "B")
+	n1.AddEdge("e2", "B", "C")
+
+	n2 := NewMutableNetwork[string, string](false, false, false, Insertion, Insertion)
+	n2.AddEdge("e2", "C", "B")
+	n2.AddEdge("e1", "B", "A")
+
+	if !n1.Equals(n2) || !n2.Equals(n1) {
+		t.Error("Undirected networks with the same edges and endpoints should be equal")
+	}
+
+	n3 := NewMutableNetwork[string, string](false, false, false, Insertion, Insertion)
+	n3.AddEdge("e1", "A", "C")
+	n3.AddEdge("e2", "B", "C")
+	if n1.Equals(n3) {
+		t.Error("Networks whose edges connect different nodes should not be equal")
+	}
+
+	d1 := NewMutableNetwork[string, string](true, false, false, Insertion, Insertion)
+	d1.AddEdge("e1", "A", "B")
+	d2 := NewMutableNetwork[string, string](true, false, false, Insertion, Insertion)
+	d2.AddEdge("e1", "B", "A")
+	if d1.Equals(d2) {
+		t.Error("Directed networks with reversed edges should not be equal")
+	}
+
+	// The graph view counts an undirected connection once regardless of orientation
+	multi := NewMutableNetwork[string, string](false, false, true, Insertion, Insertion)
+	multi.AddEdge("x", "A", "B")
+	multi.AddEdge("y", "B", "A")
+	if multi.AsGraph().Edges().Size() != 1 {
+		t.Errorf("Graph view should report one edge between A and B, got %d", multi.AsGraph().Edges().Size())
+	}
+}
+
+func TestNetworkRemoveEdgesIf(t *testing.T) {
+	n := NewMutableNetwork[string, string](true, false, true, Insertion, Insertion)
+	n.AddEdge("local1", "A", "B")
+	n.AddEdge("express", "A", "B")
+	n.AddEdge("local2", "B", "C")
+
+	removed := n.RemoveEdgesIf(func(edge string, endpoints EndpointPair[string]) bool {
+		return strings.HasPrefix(edge, "local")
+	})
+	if removed != 2 {
+		t.Errorf("RemoveEdgesIf should remove 2 edges, got %d", removed)
+	}
+	if n.Edges().Size() != 1 || !n.Edges().Contains("express") {
+		t.Errorf("Only the express edge should remain, got %v", n.Edges())
+	}
+	if n.HasEdgeConnecting("B", "C") || !n.HasEdgeConnecting("A", "B") {
+		t.Error("Connections should reflect the removed edges")
+	}
+
+	// Endpoints are passed to the predicate
+	removed = n.RemoveEdgesIf(func(edge string, endpoints EndpointPair[string]) bool {
+		return endpoints.NodeU == "A"
+	})
+	if removed != 1 || n.Edges().Size() != 0 {
+		t.Errorf("RemoveEdgesIf by endpoint should remove the remaining edge, got %d", removed)
+	}
+}
+
+func TestNetworkFilterEdges(t *testing.T) {
+	n := NewMutableNetwork[string, string](false, false, true, Insertion, Insertion)
+	n.AddEdge("road", "A", "B")
+	n.AddEdge("rail", "A", "B")
+	n.AddEdge("road2", "B", "C")
+
+	rail := n.FilterEdges(func(edge string, endpoints EndpointPair[string]) bool {
+		return edge == "rail"
+	})
+	if rail.Size() != 3 || rail.Edges().Size() != 1 {
+		t.Errorf("Filtered graph should have 3 nodes and 1 edge, got %v", rail)
+	}
+	if !rail.HasEdgeConnecting("B", "A") || rail.HasEdgeConnecting("B", "C") {
+		t.Error("Filtered graph should only connect A and B")
+	}
+}
+
+func TestNetworkTryAddEdge(t *testing.T) {
+	n := DirectedNetwork[string, string]()
+
+	rows := []struct {
+		edge, from, to string
+	}{
+		{"e1", "A", "B"},
+		{"e2", "B", "B"}, // self-loop
+		{"e3", "A", "B"}, // parallel
+		{"e1", "A", "B"}, // duplicate row
+		{"e1", "B", "C"}, // edge id reused for other nodes
+		{"e4", "B", "C"},
+	}
+
+	var added int
+	var errs []error
+	for _, row := range rows {
+		ok, err := n.TryAddEdge(row.edge, row.from, row.to)
+		if ok {
+			added++
+		}
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	if added != 2 || n.Edges().Size() != 2 {
+		t.Errorf("Only e1 and e4 should be added, got %d added and %v", added, n.Edges())
+	}
+	if len(errs) != 3 {
+		t.Fatalf("Expected 3 constraint violations, got %v", errs)
+	}
+	if !errors.Is(errs[0], common.ErrSelfLoopNotAllowed) {
+		t.Errorf("Self-loop should return ErrSelfLoopNotAllowed, got %v", errs[0])
+	}
+	if !errors.Is(errs[1], common.ErrParallelEdgeNotAllowed) {
+		t.Errorf("Parallel edge should return ErrParallelEdgeNotAllowed, got %v", errs[1])
+	}
+	if !errors.Is(errs[2], common.ErrInvalidArgument) {
+		t.Errorf("Reusing an edge for other nodes should return ErrInvalidArgument, got %v", errs[2])
+	}
+	if endpoints, _ := n.IncidentNodes("e1"); endpoints.NodeV != "B" {
+		t.Errorf("Rejected rows should leave e1 unchanged, got %v", endpoints)
+	}
+	if n.HasEdgeConnecting("B", "B") || n.EdgesConnecting("A", "B").Size() != 1 {
+		t.Error("Failed rows should not add edges")
+	}
+
+	// Undirected networks accept a duplicate in either orientation
+	u := UndirectedNetwork[string, string]()
+	u.TryAddEdge("e", "A", "B")
+	if ok, err := u.TryAddEdge("e", "B", "A"); ok || err != nil {
+		t.Errorf("Re-adding an undirected edge reversed should be a no-op, got (%v, %v)", ok, err)
+	}
+
+	// AddEdge keeps ignoring an existing edge, even one reused for other nodes
+	if err := n.AddEdge("e1", "B", "C"); err != nil {
+		t.Errorf("AddEdge should ignore an existing edge, got %v", err)
+	}
+	if endpoints, _ := n.IncidentNodes("e1"); endpoints.NodeU != "A" || endpoints.NodeV != "B" {
+		t.Errorf("AddEdge should leave an existing edge unchanged, got %v", endpoints)
+	}
+}
+
+func TestMutableNetworkBulkAdd(t *testing.T) {
+	n := NewMutableNetwork[int, string](true, false, false, Unordered, Unordered)
+	if added := n.AddNodes([]int{1, 2, 3, 2}); added != 3 {
+		t.Errorf("AddNodes should add 3 new nodes, got %d", added)
+	}
+	if added := n.AddNodes([]int{3, 4}); added != 1 {
+		t.Errorf("AddNodes should skip existing nodes, got %d", added)
+	}
+
+	added, err := n.AddEdges([]NetworkEdge[int, string]{
+		{Edge: "a", NodeU: 1, NodeV: 2},
+		{Edge: "b", NodeU: 2, NodeV: 3},
+		{Edge: "a", NodeU: 1, NodeV: 2},
+		{Edge: "c", NodeU: 3, NodeV: 5},
+	})
+	if err != nil || added != 3 {
+		t.Fatalf("AddEdges should add 3 edges, got %d, %v", added, err)
+	}
+	if n.EdgeCount() != 3 || n.NodeCount() != 5 {
+		t.Errorf("network should have 5 nodes and 3 edges, got %d and %d", n.NodeCount(), n.EdgeCount())
+	}
+
+	added, err = n.AddEdges([]NetworkEdge[int, string]{
+		{Edge: "d", NodeU: 4, NodeV: 1},
+		{Edge: "e", NodeU: 4, NodeV: 4},
+		{Edge: "f", NodeU: 1, NodeV: 4},
+	})
+	if !errors.Is(err, common.ErrSelfLoopNotAllowed) || added != 1 {
+		t.Errorf("AddEdges should stop at the self-loop after 1 edge, got %d, %v", added, err)
+	}
+	if n.HasEdgeConnecting(1, 4) {
+		t.Error("edges after the violation should not be added")
+	}
+}
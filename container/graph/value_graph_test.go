@@ -2,6 +2,8 @@ package graph
 
 import (
 	"testing"
+
+	"github.com/chenjianyu/collections/container/set"
 )
 
 func TestMutableValueGraphBasicOperations(t *testing.T) {
@@ -294,4 +296,220 @@ func TestValueGraphAsGraphView(t *testing.T) {
 	if !successors.Contains("B") {
 		t.Error("Expected B to be successor of A in graph view")
 	}
-}
\ No newline at end of file
+}
+func TestValueGraphContractNodes(t *testing.T) {
+	g := NewMutableValueGraph[string, int](false, false, Insertion)
+	g.PutEdgeValue("A", "B", 1)
+	g.PutEdgeValue("A", "C", 2)
+	g.PutEdgeValue("B", "C", 3)
+	g.PutEdgeValue("B", "D", 4)
+
+	sum := func(a, b int) int { return a + b }
+	dropped, err := g.ContractNodes("A", "B", sum)
+	if err != nil {
+		t.Fatalf("ContractNodes should not return error, got %v", err)
+	}
+	if len(dropped) != 1 || dropped[0] != NewEndpointPair("B", "A") {
+		t.Errorf("Expected the B-A self-loop to be reported as dropped, got %v", dropped)
+	}
+
+	if g.Contains("B") {
+		t.Error("Absorbed node B should be removed")
+	}
+	if g.Size() != 3 {
+		t.Errorf("Expected 3 nodes, got %d", g.Size())
+	}
+	// A-B became a self-loop and was dropped
+	if g.HasEdgeConnecting("A", "A") {
+		t.Error("Self-loop should be dropped when self-loops are not allowed")
+	}
+	// A-C and B-C became parallel and were summed
+	if value, _ := g.EdgeValue("A", "C"); value != 5 {
+		t.Errorf("Expected combined edge value 5, got %d", value)
+	}
+	if value, _ := g.EdgeValue("D", "A"); value != 4 {
+		t.Errorf("Expected redirected edge value 4, got %d", value)
+	}
+	if degree, _ := g.Degree("A"); degree != 2 {
+		t.Errorf("Expected degree of A to be 2, got %d", degree)
+	}
+	if degree, _ := g.Degree("C"); degree != 1 {
+		t.Errorf("Expected degree of C to be 1, got %d", degree)
+	}
+
+	if _, err := g.ContractNodes("A", "X", sum); err == nil {
+		t.Error("ContractNodes with a missing node should return error")
+	}
+	if _, err := g.ContractNodes("A", "A", sum); err == nil {
+		t.Error("ContractNodes of a node into itself should return error")
+	}
+
+	// Directed graph keeping self-loops, nil combine keeps the existing value
+	d := NewMutableValueGraph[string, int](true, true, Insertion)
+	d.PutEdgeValue("X", "Y", 1)
+	d.PutEdgeValue("Z", "X", 2)
+	d.PutEdgeValue("Z", "Y", 3)
+	dropped, err = d.ContractNodes("X", "Y", nil)
+	if err != nil {
+		t.Fatalf("ContractNodes should not return error, got %v", err)
+	}
+	if len(dropped) != 1 || dropped[0] != NewEndpointPair("Z", "Y") {
+		t.Errorf("Expected Z->Y to be reported as dropped, got %v", dropped)
+	}
+	if value, ok := d.EdgeValue("X", "X"); !ok || value != 1 {
+		t.Errorf("Expected self-loop X->X with value 1, got %d, %v", value, ok)
+	}
+	if value, _ := d.EdgeValue("Z", "X"); value != 2 {
+		t.Errorf("Expected existing edge value 2 to be kept, got %d", value)
+	}
+	if outDegree, _ := d.OutDegree("Z"); outDegree != 1 {
+		t.Errorf("Expected out-degree of Z to be 1, got %d", outDegree)
+	}
+
+	// Edges leaving the absorbed node are combined before edges entering it
+	concat := func(a, b string) string { return a + b }
+	for i := 0; i < 20; i++ {
+		c := NewMutableValueGraph[string, string](true, true, Insertion)
+		c.PutEdgeValue("K", "K", "k")
+		c.PutEdgeValue("A", "K", "out")
+		c.PutEdgeValue("K", "A", "in")
+		if _, err := c.ContractNodes("K", "A", concat); err != nil {
+			t.Fatalf("ContractNodes should not return error, got %v", err)
+		}
+		if value, _ := c.EdgeValue("K", "K"); value != "koutin" {
+			t.Fatalf("Expected self-loops combined as koutin, got %q", value)
+		}
+	}
+}
+
+func TestValueGraphEquals(t *testing.T) {
+	g1 := NewMutableValueGraph[string, int](false, false, Insertion)
+	g1.PutEdgeValue("A", "B", 1)
+	g1.PutEdgeValue("B", "C", 2)
+
+	g2 := NewMutableValueGraph[string, int](false, false, Natural)
+	g2.PutEdgeValue("C", "B", 2)
+	g2.PutEdgeValue("B", "A", 1)
+
+	if !g1.Equals(g2) || !g2.Equals(g1) {
+		t.Error("Undirected value graphs with the same edges and values should be equal")
+	}
+	if !g1.Edges().Difference(g2.Edges()).IsEmpty() {
+		t.Errorf("Edge sets should match regardless of insertion orientation, got %v and %v", g1.Edges(), g2.Edges())
+	}
+
+	g2.PutEdgeValue("A", "B", 5)
+	if g1.Equals(g2) {
+		t.Error("Value graphs with different edge values should not be equal")
+	}
+
+	d1 := NewMutableValueGraph[string, int](true, false, Insertion)
+	d1.PutEdgeValue("A", "B", 1)
+	d2 := NewMutableValueGraph[string, int](true, false, Insertion)
+	d2.PutEdgeValue("B", "A", 1)
+	if d1.Equals(d2) {
+		t.Error("Directed value graphs with reversed edges should not be equal")
+	}
+	if d1.Equals(nil) {
+		t.Error("Graph should not equal nil")
+	}
+}
+
+func TestValueGraphRemoveEdgesIf(t *testing.T) {
+	g := NewMutableValueGraph[string, int](false, false, Insertion)
+	g.PutEdgeValue("A", "B", 1)
+	g.PutEdgeValue("B", "C", 5)
+	g.PutEdgeValue("C", "A", 2)
+	g.PutEdgeValue("C", "D", 8)
+
+	removed := g.RemoveEdgesIf(func(edge EndpointPair[string], weight int) bool {
+		return weight < 3
+	})
+	if removed != 2 {
+		t.Errorf("RemoveEdgesIf should remove 2 low-weight edges, got %d", removed)
+	}
+	if g.HasEdgeConnecting("A", "B") || g.HasEdgeConnecting("A", "C") {
+		t.Error("Low-weight edges should be removed")
+	}
+	if !g.HasEdgeConnecting("B", "C") || !g.HasEdgeConnecting("D", "C") {
+		t.Error("Heavy edges should be kept")
+	}
+	if g.Size() != 4 {
+		t.Errorf("RemoveEdgesIf should keep all nodes, got %d", g.Size())
+	}
+
+	if removed := g.RemoveEdgesIf(func(EndpointPair[string], int) bool { return false }); removed != 0 {
+		t.Errorf("RemoveEdgesIf matching nothing should return 0, got %d", removed)
+	}
+}
+
+func TestValueGraphFilterEdges(t *testing.T) {
+	g := NewMutableValueGraph[string, int](true, false, Insertion)
+	g.PutEdgeValue("A", "B", 1)
+	g.PutEdgeValue("B", "C", 5)
+	g.PutEdgeValue("C", "A", 7)
+
+	heavy := g.FilterEdges(func(edge EndpointPair[string], weight int) bool {
+		return weight >= 5
+	})
+	if !heavy.IsDirected() || heavy.Size() != 3 || heavy.Edges().Size() != 2 {
+		t.Errorf("Filtered graph should be directed with 3 nodes and 2 edges, got %v", heavy)
+	}
+	if heavy.HasEdgeConnecting("A", "B") || !heavy.HasEdgeConnecting("C", "A") {
+		t.Error("Filtered graph should hold only the heavy edges")
+	}
+
+	// The result is independent of the source graph
+	heavy.RemoveEdge("B", "C")
+	if !g.HasEdgeConnecting("B", "C") {
+		t.Error("Changing the filtered graph should not affect the source")
+	}
+}
+
+func TestInducedValueSubgraph(t *testing.T) {
+	g := UndirectedValueGraph[string, int]()
+	g.PutEdgeValue("A", "B", 1)
+	g.PutEdgeValue("B", "C", 2)
+	g.PutEdgeValue("C", "D", 3)
+
+	nodes := set.New[string]()
+	nodes.Add("B")
+	nodes.Add("C")
+	nodes.Add("D")
+
+	sub := InducedValueSubgraph[string, int](g, nodes)
+	if sub.IsDirected() || sub.Size() != 3 || sub.Edges().Size() != 2 {
+		t.Errorf("Subgraph should be undirected with 3 nodes and 2 edges, got %v", sub)
+	}
+	if v, ok := sub.EdgeValue("C", "B"); !ok || v != 2 {
+		t.Errorf("Subgraph should keep the value 2 on B-C, got (%d, %v)", v, ok)
+	}
+	if v, ok := sub.EdgeValue("D", "C"); !ok || v != 3 {
+		t.Errorf("Subgraph should keep the value 3 on C-D, got (%d, %v)", v, ok)
+	}
+	if sub.HasEdgeConnecting("A", "B") {
+		t.Error("Edges leaving the node set should be dropped")
+	}
+}
+
+func TestMutableValueGraphPutEdges(t *testing.T) {
+	g := NewMutableValueGraph[string, int](false, false, Unordered)
+	added := g.PutEdges([]ValueEdge[string, int]{
+		{NodeU: "a", NodeV: "b", Value: 1},
+		{NodeU: "b", NodeV: "c", Value: 2},
+		{NodeU: "b", NodeV: "a", Value: 3},
+		{NodeU: "c", NodeV: "c", Value: 4},
+	})
+	if added != 2 {
+		t.Errorf("PutEdges should add 2 new edges, got %d", added)
+	}
+	if value, _ := g.EdgeValue("a", "b"); value != 3 {
+		t.Errorf("a later duplicate should update the value to 3, got %d", value)
+	}
+	if g.HasEdgeConnecting("c", "c") {
+		t.Error("self-loops should be skipped when not allowed")
+	}
+	if g.EdgeCount() != 2 || g.NodeCount() != 3 {
+		t.Errorf("graph should have 3 nodes and 2 edges, got %d and %d", g.NodeCount(), g.EdgeCount())
+	}
+}
@@ -11,6 +11,10 @@ import (
 // ArrayList is a List implementation based on dynamic arrays
 type ArrayList[E any] struct {
 	elements []E
+	// buf is the backing array reserved by AddFirst and front is the index in buf
+	// where elements starts, leaving front free slots for further AddFirst calls
+	buf   []E
+	front int
 }
 
 // New creates a new ArrayList
@@ -27,18 +31,85 @@ func WithCapacity[E any](capacity int) *ArrayList[E] {
 }
 
 // FromSlice creates a new ArrayList from a slice
+// The elements are copied, so later writes to the slice do not affect the list
 func FromSlice[E any](slice []E) *ArrayList[E] {
 	elements := make([]E, len(slice))
 	copy(elements, slice)
 	return &ArrayList[E]{elements: elements}
 }
 
+// FromIterator creates a new ArrayList holding the remaining elements of it in iteration order
+// A nil iterator yields an empty list
+func FromIterator[E any](it common.Iterator[E]) *ArrayList[E] {
+	list := New[E]()
+	if it == nil {
+		return list
+	}
+	for it.HasNext() {
+		if element, ok := it.Next(); ok {
+			list.elements = append(list.elements, element)
+		}
+	}
+	return list
+}
+
 // Add adds an element to the end of the list
 func (list *ArrayList[E]) Add(element E) bool {
 	list.elements = append(list.elements, element)
 	return true
 }
 
+// AddFirst inserts an element at the beginning of the list
+// Free slots are reserved in front of the elements, so repeated calls run in amortized O(1)
+func (list *ArrayList[E]) AddFirst(element E) {
+	if list.front == 0 || !list.sharesFront() {
+		list.growFront()
+	}
+	list.front--
+	list.elements = list.buf[list.front : list.front+len(list.elements)+1]
+	list.elements[0] = element
+}
+
+// RemoveFirst removes and returns the first element of the list in O(1)
+// Returns zero value and false if the list is empty
+func (list *ArrayList[E]) RemoveFirst() (E, bool) {
+	if len(list.elements) == 0 {
+		return common.ZeroValue[E](), false
+	}
+	tracked := list.sharesFront()
+	element := list.elements[0]
+	// Clear the element to avoid memory leak
+	list.elements[0] = common.ZeroValue[E]()
+	list.elements = list.elements[1:]
+	if tracked {
+		list.front++
+	}
+	return element, true
+}
+
+// Internal method: report whether elements still starts at buf[front]
+// Appends that reallocate the slice leave buf stale, in which case the front slots are gone
+func (list *ArrayList[E]) sharesFront() bool {
+	if list.buf == nil || cap(list.elements) == 0 || list.front >= len(list.buf) {
+		return false
+	}
+	return &list.elements[:1][0] == &list.buf[list.front]
+}
+
+// Internal method: move the elements into a new backing array with free slots at both ends
+func (list *ArrayList[E]) growFront() {
+	size := len(list.elements)
+	gap := size
+	if gap < 8 {
+		gap = 8
+	}
+	buf := make([]E, gap+size+gap)
+	copy(buf[gap:], list.elements)
+	list.buf = buf
+	list.front = gap
+	list.elements = buf[gap : gap+size]
+}
+
 // Insert inserts an element at the specified position
 func (list *ArrayList[E]) Insert(index int, element E) error {
 	if index < 0 || index > len(list.elements) {
@@ -91,6 +162,26 @@ func (list *ArrayList[E]) RemoveAt(index int) (E, bool) {
 	return element, true
 }
 
+// RemoveRange removes the elements in the range [fromIndex, toIndex)
+// The remaining elements are shifted once, so the cost is O(n) regardless of the range length
+func (list *ArrayList[E]) RemoveRange(fromIndex, toIndex int) error {
+	if fromIndex < 0 || toIndex > len(list.elements) || fromIndex > toIndex {
+		return common.InvalidRangeError(fromIndex, toIndex)
+	}
+	if fromIndex == toIndex {
+		return nil
+	}
+
+	n := copy(list.elements[fromIndex:], list.elements[toIndex:])
+	newSize := fromIndex + n
+	// Clear the vacated tail to avoid memory leak
+	for i := newSize; i < len(list.elements); i++ {
+		list.elements[i] = common.ZeroValue[E]()
+	}
+	list.elements = list.elements[:newSize]
+	return nil
+}
+
 // Remove removes the first occurrence of the specified element
 func (list *ArrayList[E]) Remove(element E) bool {
 	index := list.IndexOf(element)
@@ -131,6 +222,15 @@ func (list *ArrayList[E]) Size() int {
 	return len(list.elements)
 }
 
+// Stats returns the list's length and allocated capacity, including slots reserved by AddFirst
+func (list *ArrayList[E]) Stats() common.ContainerStats {
+	capacity := cap(list.elements)
+	if list.sharesFront() {
+		capacity += list.front
+	}
+	return common.NewContainerStats(len(list.elements), len(list.elements), capacity)
+}
+
 // IsEmpty checks if the list is empty
 func (list *ArrayList[E]) IsEmpty() bool {
 	return len(list.elements) == 0
@@ -145,12 +245,36 @@ func (list *ArrayList[E]) Clear() {
 	// Reset the slice to zero length but keep some capacity for reuse
 	if cap(list.elements) > 100 { // Only shrink if capacity is large
 		list.elements = make([]E, 0, 16) // Reset to smaller capacity
+		list.buf, list.front = nil, 0
 	} else {
 		list.elements = list.elements[:0]
 	}
 }
 
+// reset empties the list in place for reuse, keeping its backing array
+// Every slot of the array is zeroed, including any left free by AddFirst or removals,
+// so a pooled list holds no references to its former elements
+func (list *ArrayList[E]) reset() {
+	backing := list.elements[:cap(list.elements)]
+	if list.sharesFront() {
+		backing = list.buf[:cap(list.buf)]
+	}
+	for i := range backing {
+		backing[i] = common.ZeroValue[E]()
+	}
+	list.elements = backing[:0]
+	list.buf, list.front = nil, 0
+}
+
+// ClearAndShrink removes all elements and releases the backing array
+// Use it instead of Clear to return memory when reusing a list after a large load
+func (list *ArrayList[E]) ClearAndShrink() {
+	list.elements = make([]E, 0)
+	list.buf, list.front = nil, 0
+}
+
 // ToSlice returns a slice containing all elements in the list
+// The returned slice is a copy; modifying it does not affect the list
 func (list *ArrayList[E]) ToSlice() []E {
 	result := make([]E, len(list.elements))
 	copy(result, list.elements)
@@ -175,6 +299,13 @@ func (list *ArrayList[E]) ForEach(f func(E)) {
 	}
 }
 
+// ForEachIndexed executes the given operation on each element together with its index
+func (list *ArrayList[E]) ForEachIndexed(f func(int, E)) {
+	for i, element := range list.elements {
+		f(i, element)
+	}
+}
+
 // String returns the string representation of the list
 func (list *ArrayList[E]) String() string {
 	if len(list.elements) == 0 {
@@ -193,6 +324,19 @@ func (list *ArrayList[E]) String() string {
 	return builder.String()
 }
 
+// StringN returns a representation like String that shows at most n elements
+// The remaining elements are summarised as "… (+k more)"
+func (list *ArrayList[E]) StringN(n int) string {
+	b := common.NewLimitedStringBuilder("[", n)
+	for _, element := range list.elements {
+		if b.Full() {
+			break
+		}
+		b.Addf("%v", element)
+	}
+	return b.Finish("]", len(list.elements))
+}
+
 // Iterator returns an iterator for traversing the elements in the list
 func (list *ArrayList[E]) Iterator() common.Iterator[E] {
 	return &arrayListIterator[E]{list: list, cursor: 0, lastRet: -1}
@@ -235,3 +379,20 @@ func (it *arrayListIterator[E]) Remove() bool {
 	}
 	return removed
 }
+
+// MarshalBinary encodes the list elements in order using common.EncodeElements
+func (list *ArrayList[E]) MarshalBinary() ([]byte, error) {
+	return common.EncodeElements(list.elements)
+}
+
+// UnmarshalBinary replaces the list contents with the elements decoded from data
+// The list is left unchanged if data is malformed
+func (list *ArrayList[E]) UnmarshalBinary(data []byte) error {
+	elements, err := common.DecodeElements[E](data)
+	if err != nil {
+		return err
+	}
+	list.elements = elements
+	list.buf, list.front = nil, 0
+	return nil
+}
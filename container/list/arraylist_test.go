@@ -1,7 +1,12 @@
 package list
 
 import (
+	"errors"
+	"fmt"
+	"strings"
 	"testing"
+
+	"github.com/chenjianyu/collections/container/common"
 )
 
 func TestArrayList_Basic(t *testing.T) {
@@ -69,6 +74,23 @@ func TestArrayList_FromSlice(t *testing.T) {
 	}
 }
 
+func TestArrayList_FromSliceCopies(t *testing.T) {
+	slice := []int{1, 2, 3}
+	list := FromSlice(slice)
+
+	slice[0] = 100
+	if val, _ := list.Get(0); val != 1 {
+		t.Errorf("Writing to the source slice should not affect the list, got %d", val)
+	}
+
+	// Appending to the list must not write into the source slice's backing array
+	list.Add(4)
+	list.Set(1, 200)
+	if slice[1] != 2 {
+		t.Errorf("Modifying the list should not affect the source slice, got %d", slice[1])
+	}
+}
+
 func TestArrayList_Get(t *testing.T) {
 	list := New[int]()
 	list.Add(1)
@@ -217,6 +239,53 @@ func TestArrayList_RemoveAt(t *testing.T) {
 	}
 }
 
+func TestArrayList_RemoveRange(t *testing.T) {
+	list := FromSlice([]int{0, 1, 2, 3, 4, 5, 6, 7})
+
+	// Remove from the middle
+	if err := list.RemoveRange(2, 5); err != nil {
+		t.Errorf("RemoveRange(2, 5) should not return error, got %v", err)
+	}
+	expected := []int{0, 1, 5, 6, 7}
+	if list.Size() != len(expected) {
+		t.Errorf("List size should be %d, got %d", len(expected), list.Size())
+	}
+	for i, exp := range expected {
+		if val, _ := list.Get(i); val != exp {
+			t.Errorf("Get(%d) should return %d, got %d", i, exp, val)
+		}
+	}
+
+	// Trim the front
+	if err := list.RemoveRange(0, 2); err != nil {
+		t.Errorf("RemoveRange(0, 2) should not return error, got %v", err)
+	}
+	if val, _ := list.Get(0); val != 5 || list.Size() != 3 {
+		t.Errorf("After trimming front, list should be [5, 6, 7], got %v", list)
+	}
+
+	// Empty range is a no-op
+	if err := list.RemoveRange(1, 1); err != nil || list.Size() != 3 {
+		t.Errorf("Empty range should be a no-op, got size %d, err %v", list.Size(), err)
+	}
+
+	// Invalid ranges
+	for _, r := range [][2]int{{-1, 1}, {2, 1}, {0, 4}} {
+		err := list.RemoveRange(r[0], r[1])
+		if !errors.Is(err, common.ErrInvalidRange) {
+			t.Errorf("RemoveRange(%d, %d) should return ErrInvalidRange, got %v", r[0], r[1], err)
+		}
+	}
+	if list.Size() != 3 {
+		t.Errorf("Invalid ranges should not modify the list, got size %d", list.Size())
+	}
+
+	// Remove everything
+	if err := list.RemoveRange(0, list.Size()); err != nil || !list.IsEmpty() {
+		t.Errorf("Removing the full range should empty the list, got %v", list)
+	}
+}
+
 func TestArrayList_Remove(t *testing.T) {
 	list := New[int]()
 	list.Add(1)
@@ -339,6 +408,29 @@ func TestArrayList_Clear(t *testing.T) {
 	}
 }
 
+func TestArrayList_ClearAndShrink(t *testing.T) {
+	list := New[int]()
+	for i := 0; i < 1000; i++ {
+		list.Add(i)
+	}
+	list.AddFirst(-1)
+
+	list.ClearAndShrink()
+	if !list.IsEmpty() {
+		t.Error("List should be empty after ClearAndShrink")
+	}
+	if cap(list.elements) != 0 || list.buf != nil {
+		t.Errorf("ClearAndShrink should release the backing array, capacity is %d", cap(list.elements))
+	}
+
+	list.AddFirst(2)
+	list.AddFirst(1)
+	list.Add(3)
+	if list.String() != "[1, 2, 3]" {
+		t.Errorf("List should be usable after ClearAndShrink, got %s", list.String())
+	}
+}
+
 func TestArrayList_Contains(t *testing.T) {
 	list := New[int]()
 	list.Add(1)
@@ -370,6 +462,22 @@ func TestArrayList_ForEach(t *testing.T) {
 	}
 }
 
+func TestArrayList_ForEachIndexed(t *testing.T) {
+	list := New[string]()
+	list.Add("a")
+	list.Add("b")
+	list.Add("c")
+
+	var lines []string
+	list.ForEachIndexed(func(index int, val string) {
+		lines = append(lines, fmt.Sprintf("%d. %s", index+1, val))
+	})
+
+	if strings.Join(lines, " ") != "1. a 2. b 3. c" {
+		t.Errorf("ForEachIndexed should visit elements with their indices, got %v", lines)
+	}
+}
+
 func TestArrayList_ToSlice(t *testing.T) {
 	list := New[int]()
 	list.Add(1)
@@ -390,6 +498,21 @@ func TestArrayList_ToSlice(t *testing.T) {
 	}
 }
 
+func TestArrayList_ToSliceIsDefensive(t *testing.T) {
+	list := FromSlice([]int{1, 2, 3})
+
+	slice := list.ToSlice()
+	slice[0] = 100
+	if val, _ := list.Get(0); val != 1 {
+		t.Errorf("Writing to ToSlice result should not affect the list, got %d", val)
+	}
+
+	list.Set(1, 200)
+	if slice[1] != 2 {
+		t.Errorf("Modifying the list should not affect a previous ToSlice result, got %d", slice[1])
+	}
+}
+
 func TestArrayList_String(t *testing.T) {
 	list := New[int]()
 
@@ -408,3 +531,144 @@ func TestArrayList_String(t *testing.T) {
 		t.Errorf("List string should be '%s', got '%s'", expected, list.String())
 	}
 }
+
+func TestArrayList_AddFirstRemoveFirst(t *testing.T) {
+	list := FromSlice([]int{3, 4})
+
+	list.AddFirst(2)
+	list.AddFirst(1)
+	list.Add(5)
+	list.AddFirst(0)
+
+	expected := []int{0, 1, 2, 3, 4, 5}
+	if list.Size() != len(expected) {
+		t.Errorf("List size should be %d, got %d", len(expected), list.Size())
+	}
+	for i, exp := range expected {
+		if val, _ := list.Get(i); val != exp {
+			t.Errorf("Get(%d) should return %d, got %d", i, exp, val)
+		}
+	}
+
+	val, ok := list.RemoveFirst()
+	if !ok || val != 0 {
+		t.Errorf("RemoveFirst should return (0, true), got (%d, %v)", val, ok)
+	}
+	list.AddFirst(-1)
+	if val, _ := list.Get(0); val != -1 {
+		t.Errorf("Get(0) should return -1 after AddFirst, got %d", val)
+	}
+
+	list.Clear()
+	if _, ok := list.RemoveFirst(); ok {
+		t.Error("RemoveFirst on empty list should return false")
+	}
+
+	// Use the list as a recent-items buffer
+	const n = 100000
+	for i := 0; i < n; i++ {
+		list.AddFirst(i)
+		if list.Size() > 10 {
+			list.RemoveAt(list.Size() - 1)
+		}
+	}
+	for i := 0; i < 10; i++ {
+		if val, _ := list.Get(i); val != n-1-i {
+			t.Errorf("Get(%d) should return %d, got %d", i, n-1-i, val)
+		}
+	}
+
+	// Interleave front and back operations across reallocations
+	list = New[int]()
+	for i := 0; i < 1000; i++ {
+		list.AddFirst(-i)
+		list.Add(i)
+	}
+	for i := 0; i < 500; i++ {
+		list.RemoveFirst()
+	}
+	first, _ := list.Get(0)
+	last, _ := list.Get(list.Size() - 1)
+	if list.Size() != 1500 || first != -499 || last != 999 {
+		t.Errorf("Unexpected list state: size %d, first %d, last %d", list.Size(), first, last)
+	}
+}
+
+func TestArrayList_BinaryRoundTrip(t *testing.T) {
+	list := New[string]()
+	list.Add("b")
+	list.Add("c")
+	list.AddFirst("a")
+
+	var _ common.Serializable = list
+	data, err := list.MarshalBinary()
+	if err != nil {
+		t.Fatalf("MarshalBinary failed: %v", err)
+	}
+
+	var restored ArrayList[string]
+	if err := restored.UnmarshalBinary(data); err != nil {
+		t.Fatalf("UnmarshalBinary failed: %v", err)
+	}
+	if restored.String() != list.String() {
+		t.Errorf("Expected %v, got %v", list, &restored)
+	}
+	restored.AddFirst("z")
+	if v, _ := restored.Get(0); v != "z" || restored.Size() != 4 {
+		t.Errorf("Restored list should accept AddFirst, got %v", &restored)
+	}
+
+	if err := restored.UnmarshalBinary(data[:len(data)-1]); !errors.Is(err, common.ErrMalformedData) {
+		t.Errorf("Expected ErrMalformedData, got %v", err)
+	}
+	if restored.Size() != 4 {
+		t.Errorf("Failed UnmarshalBinary should leave the list unchanged, got %v", &restored)
+	}
+}
+
+func TestArrayList_StringN(t *testing.T) {
+	list := New[int]()
+	if got := list.StringN(3); got != "[]" {
+		t.Errorf("Expected [], got %q", got)
+	}
+	for i := 1; i <= 5; i++ {
+		list.Add(i)
+	}
+	if got := list.StringN(2); got != "[1, 2, … (+3 more)]" {
+		t.Errorf("Expected truncated listing, got %q", got)
+	}
+	if got := list.StringN(10); got != list.String() {
+		t.Errorf("StringN above the size should match String, got %q", got)
+	}
+}
+
+func TestArrayList_FromIterator(t *testing.T) {
+	source := NewLinkedList[string]()
+	source.Add("x")
+	source.Add("y")
+
+	list := FromIterator(source.Iterator())
+	if list.String() != "[x, y]" {
+		t.Errorf("Expected [x, y], got %v", list)
+	}
+	if FromIterator[int](nil).Size() != 0 {
+		t.Error("A nil iterator should yield an empty list")
+	}
+}
+
+func TestArrayList_Stats(t *testing.T) {
+	list := WithCapacity[int](16)
+	for i := 0; i < 4; i++ {
+		list.Add(i)
+	}
+	stats := list.Stats()
+	if stats.Len != 4 || stats.Distinct != 4 || stats.Capacity != 16 || stats.LoadFactor != 0.25 {
+		t.Errorf("unexpected stats %+v", stats)
+	}
+
+	var reporter common.StatsReporter = list
+	list.AddFirst(-1)
+	if stats := reporter.Stats(); stats.Len != 5 || stats.Capacity < 5 {
+		t.Errorf("capacity should cover the slots reserved by AddFirst, got %+v", stats)
+	}
+}
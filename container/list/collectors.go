@@ -0,0 +1,35 @@
+package list
+
+import (
+	"github.com/chenjianyu/collections/container/common"
+	maps "github.com/chenjianyu/collections/container/map"
+)
+
+// ToMap indexes the list into a map, keying each element by keyFn and storing valFn's result
+// The map keeps the list's order; when several elements share a key, the last one wins
+func ToMap[E any, K comparable, V any](l List[E], keyFn func(E) K, valFn func(E) V) maps.Map[K, V] {
+	result := maps.NewLinkedHashMapWithCapacity[K, V](l.Size())
+	l.ForEach(func(element E) {
+		result.Put(keyFn(element), valFn(element))
+	})
+	return result
+}
+
+// ToMapUnique is like ToMap but fails on the first element whose key is already present
+// Returns nil and an error wrapping common.ErrDuplicateKey in that case
+func ToMapUnique[E any, K comparable, V any](l List[E], keyFn func(E) K, valFn func(E) V) (maps.Map[K, V], error) {
+	result := maps.NewLinkedHashMapWithCapacity[K, V](l.Size())
+	it := l.Iterator()
+	for it.HasNext() {
+		element, ok := it.Next()
+		if !ok {
+			break
+		}
+		key := keyFn(element)
+		if result.ContainsKey(key) {
+			return nil, common.DuplicateKeyError(key)
+		}
+		result.Put(key, valFn(element))
+	}
+	return result, nil
+}
@@ -0,0 +1,51 @@
+package list
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/chenjianyu/collections/container/common"
+)
+
+type record struct {
+	ID   int
+	Name string
+}
+
+func TestToMap(t *testing.T) {
+	records := FromSlice([]record{{1, "ann"}, {2, "bob"}, {1, "ann v2"}})
+
+	byID := ToMap[record, int, string](records,
+		func(r record) int { return r.ID },
+		func(r record) string { return r.Name })
+	if byID.Size() != 2 {
+		t.Errorf("Expected 2 keys, got %d", byID.Size())
+	}
+	if name, _ := byID.Get(1); name != "ann v2" {
+		t.Errorf("Last element should win on duplicate keys, got %s", name)
+	}
+	if keys := byID.Keys(); keys[0] != 1 || keys[1] != 2 {
+		t.Errorf("Map should keep list order, got %v", keys)
+	}
+
+	linked := NewLinkedList[record]()
+	linked.Add(record{3, "cat"})
+	if name, _ := ToMap[record, int, string](linked, func(r record) int { return r.ID }, func(r record) string { return r.Name }).Get(3); name != "cat" {
+		t.Error("ToMap should accept any List implementation")
+	}
+}
+
+func TestToMapUnique(t *testing.T) {
+	id := func(r record) int { return r.ID }
+	self := func(r record) record { return r }
+
+	m, err := ToMapUnique[record, int, record](FromSlice([]record{{1, "ann"}, {2, "bob"}}), id, self)
+	if err != nil || m.Size() != 2 {
+		t.Errorf("Unique keys should index without error, got %v", err)
+	}
+
+	m, err = ToMapUnique[record, int, record](FromSlice([]record{{1, "ann"}, {2, "bob"}, {1, "dup"}}), id, self)
+	if !errors.Is(err, common.ErrDuplicateKey) || m != nil {
+		t.Errorf("Duplicate key should return ErrDuplicateKey, got %v", err)
+	}
+}
@@ -67,6 +67,15 @@ func (list *LinkedList[E]) ForEach(f func(E)) {
 	}
 }
 
+// ForEachIndexed executes the given operation on each element together with its index
+func (list *LinkedList[E]) ForEachIndexed(f func(int, E)) {
+	index := 0
+	for current := list.head; current != nil; current = current.next {
+		f(index, current.data)
+		index++
+	}
+}
+
 // String returns the string representation of the list
 func (list *LinkedList[E]) String() string {
 	if list.IsEmpty() {
@@ -89,6 +98,16 @@ func (list *LinkedList[E]) String() string {
 	return builder.String()
 }
 
+// StringN returns a representation like String that shows at most n elements
+// The remaining elements are summarised as "… (+k more)"
+func (list *LinkedList[E]) StringN(n int) string {
+	b := common.NewLimitedStringBuilder("[", n)
+	for current := list.head; current != nil && !b.Full(); current = current.next {
+		b.Addf("%v", current.data)
+	}
+	return b.Finish("]", list.size)
+}
+
 // Add adds an element to the end of the list
 func (list *LinkedList[E]) Add(element E) bool {
 	list.AddLast(element)
@@ -419,3 +438,22 @@ func (it *linkedListIterator[E]) Remove() bool {
 	it.lastReturned = nil
 	return true
 }
+
+// MarshalBinary encodes the list elements in order using common.EncodeElements
+func (list *LinkedList[E]) MarshalBinary() ([]byte, error) {
+	return common.EncodeElements(list.ToSlice())
+}
+
+// UnmarshalBinary replaces the list contents with the elements decoded from data
+// The list is left unchanged if data is malformed
+func (list *LinkedList[E]) UnmarshalBinary(data []byte) error {
+	elements, err := common.DecodeElements[E](data)
+	if err != nil {
+		return err
+	}
+	list.Clear()
+	for _, element := range elements {
+		list.Add(element)
+	}
+	return nil
+}
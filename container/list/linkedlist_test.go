@@ -1,7 +1,11 @@
 package list
 
 import (
+	"fmt"
+	"strings"
 	"testing"
+
+	"github.com/chenjianyu/collections/container/common"
 )
 
 func TestLinkedListBasic(t *testing.T) {
@@ -352,6 +356,22 @@ func TestLinkedListForEach(t *testing.T) {
 	}
 }
 
+func TestLinkedListForEachIndexed(t *testing.T) {
+	list := NewLinkedList[string]()
+	list.Add("a")
+	list.Add("b")
+	list.Add("c")
+
+	var lines []string
+	list.ForEachIndexed(func(index int, val string) {
+		lines = append(lines, fmt.Sprintf("%d. %s", index+1, val))
+	})
+
+	if strings.Join(lines, " ") != "1. a 2. b 3. c" {
+		t.Errorf("ForEachIndexed should visit elements with their indices, got %v", lines)
+	}
+}
+
 func TestLinkedListToSlice(t *testing.T) {
 	list := NewLinkedList[int]()
 	list.Add(1)
@@ -390,3 +410,38 @@ func TestLinkedListString(t *testing.T) {
 		t.Errorf("List string should be '%s', got '%s'", expected, list.String())
 	}
 }
+
+func TestLinkedListBinaryRoundTrip(t *testing.T) {
+	list := NewLinkedList[int]()
+	for _, v := range []int{3, -1, 4, 1, 5} {
+		list.Add(v)
+	}
+
+	var _ common.Serializable = list
+	data, err := list.MarshalBinary()
+	if err != nil {
+		t.Fatalf("MarshalBinary failed: %v", err)
+	}
+
+	restored := NewLinkedList[int]()
+	restored.Add(99)
+	if err := restored.UnmarshalBinary(data); err != nil {
+		t.Fatalf("UnmarshalBinary failed: %v", err)
+	}
+	if restored.String() != list.String() {
+		t.Errorf("Expected %v, got %v", list, restored)
+	}
+}
+
+func TestLinkedListStringN(t *testing.T) {
+	list := NewLinkedList[string]()
+	for _, s := range []string{"a", "b", "c"} {
+		list.Add(s)
+	}
+	if got := list.StringN(1); got != "[a, … (+2 more)]" {
+		t.Errorf("Expected truncated listing, got %q", got)
+	}
+	if got := list.StringN(3); got != list.String() {
+		t.Errorf("StringN at the size should match String, got %q", got)
+	}
+}
@@ -0,0 +1,34 @@
+package list
+
+import "sync"
+
+// Pool recycles ArrayList instances through a sync.Pool
+// Lists returned by Get are always empty; Put empties a list in place before pooling it,
+// keeping its backing array so large lists are reused without reallocating
+// The zero value is ready to use
+type Pool[E any] struct {
+	pool sync.Pool
+}
+
+// NewPool creates an empty Pool of ArrayLists
+func NewPool[E any]() *Pool[E] {
+	return &Pool[E]{}
+}
+
+// Get returns an empty ArrayList, reusing a pooled one when available
+func (p *Pool[E]) Get() *ArrayList[E] {
+	if v := p.pool.Get(); v != nil {
+		return v.(*ArrayList[E])
+	}
+	return New[E]()
+}
+
+// Put empties list, zeroing its backing array, and returns it to the pool; nil lists are ignored
+// The caller must not use list after Put
+func (p *Pool[E]) Put(list *ArrayList[E]) {
+	if list == nil {
+		return
+	}
+	list.reset()
+	p.pool.Put(list)
+}
@@ -0,0 +1,69 @@
+package list
+
+import "testing"
+
+func TestPoolGetReturnsEmptyList(t *testing.T) {
+	pool := NewPool[int]()
+
+	list := pool.Get()
+	if !list.IsEmpty() {
+		t.Errorf("Expected empty list from Get, got size %d", list.Size())
+	}
+	for i := 0; i < 10; i++ {
+		list.Add(i)
+	}
+	pool.Put(list)
+
+	// Whether or not the pool hands back the same instance, it must be empty
+	reused := pool.Get()
+	if !reused.IsEmpty() {
+		t.Errorf("Expected recycled list to be empty, got %v", reused)
+	}
+	reused.Add(42)
+	if v, _ := reused.Get(0); v != 42 || reused.Size() != 1 {
+		t.Errorf("Expected recycled list to hold [42], got %v", reused)
+	}
+}
+
+func TestPoolZeroValueAndNilPut(t *testing.T) {
+	var pool Pool[string]
+	pool.Put(nil)
+	if list := pool.Get(); list == nil || !list.IsEmpty() {
+		t.Error("Expected zero-value Pool to return an empty list")
+	}
+}
+
+func TestPoolPutKeepsBackingArrayAndDropsReferences(t *testing.T) {
+	pool := NewPool[*int]()
+	list := pool.Get()
+	for i := 0; i < 1000; i++ {
+		v := i
+		list.Add(&v)
+	}
+	list.AddFirst(new(int))
+	list.RemoveAt(list.Size() - 1)
+	list.RemoveFirst()
+	capacity := list.Stats().Capacity
+
+	pool.Put(list)
+	if !list.IsEmpty() || cap(list.elements) < capacity {
+		t.Errorf("Put should keep the backing array of %d slots, got %d", capacity, cap(list.elements))
+	}
+	for i, p := range list.elements[:cap(list.elements)] {
+		if p != nil {
+			t.Fatalf("Slot %d still references a former element", i)
+		}
+	}
+}
+
+func BenchmarkPool(b *testing.B) {
+	pool := NewPool[int]()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		list := pool.Get()
+		for j := 0; j < 32; j++ {
+			list.Add(j)
+		}
+		pool.Put(list)
+	}
+}
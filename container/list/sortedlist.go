@@ -0,0 +1,265 @@
+package list
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/chenjianyu/collections/container/common"
+)
+
+// SortedList is a List implementation that keeps its elements ordered by a comparator
+// Elements are inserted at their binary-search position, so lookups run in O(log n)
+// Elements comparing equal are kept in insertion order
+type SortedList[E any] struct {
+	elements   []E
+	comparator func(a, b E) int
+}
+
+// NewSortedList creates a new SortedList ordered by the given comparator
+// The comparator is required, since E has no natural order; a nil comparator panics
+// with an error wrapping ErrInvalidArgument
+func NewSortedList[E any](comparator func(a, b E) int) *SortedList[E] {
+	requireComparator(comparator)
+	return &SortedList[E]{
+		elements:   make([]E, 0),
+		comparator: comparator,
+	}
+}
+
+// SortedListFromSlice creates a new SortedList containing the elements of the slice
+// As with NewSortedList, a nil comparator panics
+func SortedListFromSlice[E any](slice []E, comparator func(a, b E) int) *SortedList[E] {
+	requireComparator(comparator)
+	elements := make([]E, len(slice))
+	copy(elements, slice)
+	sort.SliceStable(elements, func(i, j int) bool {
+		return comparator(elements[i], elements[j]) < 0
+	})
+	return &SortedList[E]{
+		elements:   elements,
+		comparator: comparator,
+	}
+}
+
+// Internal method: panic at construction rather than on the first comparison
+func requireComparator[E any](comparator func(a, b E) int) {
+	if comparator == nil {
+		panic(common.InvalidArgumentError("comparator", "SortedList requires a non-nil comparator"))
+	}
+}
+
+// Internal method: index of the first element not less than element
+func (list *SortedList[E]) lowerBound(element E) int {
+	return sort.Search(len(list.elements), func(i int) bool {
+		return list.comparator(list.elements[i], element) >= 0
+	})
+}
+
+// Internal method: index of the first element greater than element
+func (list *SortedList[E]) upperBound(element E) int {
+	return sort.Search(len(list.elements), func(i int) bool {
+		return list.comparator(list.elements[i], element) > 0
+	})
+}
+
+// Add inserts an element at its sorted position
+func (list *SortedList[E]) Add(element E) bool {
+	index := list.upperBound(element)
+	list.elements = append(list.elements, common.ZeroValue[E]())
+	copy(list.elements[index+1:], list.elements[index:])
+	list.elements[index] = element
+	return true
+}
+
+// Insert is not supported because SortedList determines element positions
+// Use Add instead
+func (list *SortedList[E]) Insert(index int, element E) error {
+	return common.InvalidOperationError("Insert", "SortedList determines element positions, use Add")
+}
+
+// Get retrieves the element at the specified index
+func (list *SortedList[E]) Get(index int) (E, error) {
+	if index < 0 || index >= len(list.elements) {
+		return common.ZeroValue[E](), common.IndexOutOfBoundsError(index, len(list.elements))
+	}
+	return list.elements[index], nil
+}
+
+// Set is not supported because replacing an element could break the ordering
+// Returns zero value and false
+func (list *SortedList[E]) Set(index int, element E) (E, bool) {
+	return common.ZeroValue[E](), false
+}
+
+// RemoveAt removes the element at the specified index
+func (list *SortedList[E]) RemoveAt(index int) (E, bool) {
+	if index < 0 || index >= len(list.elements) {
+		return common.ZeroValue[E](), false
+	}
+	element := list.elements[index]
+	copy(list.elements[index:], list.elements[index+1:])
+	// Clear the last element to avoid memory leak
+	list.elements[len(list.elements)-1] = common.ZeroValue[E]()
+	list.elements = list.elements[:len(list.elements)-1]
+	return element, true
+}
+
+// Remove removes the first occurrence of the specified element
+func (list *SortedList[E]) Remove(element E) bool {
+	index := list.IndexOf(element)
+	if index < 0 {
+		return false
+	}
+	_, removed := list.RemoveAt(index)
+	return removed
+}
+
+// Contains checks if the list contains an element comparing equal to the specified element
+func (list *SortedList[E]) Contains(element E) bool {
+	return list.IndexOf(element) >= 0
+}
+
+// IndexOf returns the index of the first element comparing equal to the specified element
+// Returns -1 if not found
+func (list *SortedList[E]) IndexOf(element E) int {
+	index := list.lowerBound(element)
+	if index < len(list.elements) && list.comparator(list.elements[index], element) == 0 {
+		return index
+	}
+	return -1
+}
+
+// LastIndexOf returns the index of the last element comparing equal to the specified element
+// Returns -1 if not found
+func (list *SortedList[E]) LastIndexOf(element E) int {
+	index := list.upperBound(element) - 1
+	if index >= 0 && list.comparator(list.elements[index], element) == 0 {
+		return index
+	}
+	return -1
+}
+
+// Floor returns the greatest element less than or equal to target
+// Among equal elements the last inserted one is returned; returns false if there is no such element
+func (list *SortedList[E]) Floor(target E) (E, bool) {
+	index := list.upperBound(target) - 1
+	if index < 0 {
+		return common.ZeroValue[E](), false
+	}
+	return list.elements[index], true
+}
+
+// Ceiling returns the least element greater than or equal to target
+// Among equal elements the first inserted one is returned; returns false if there is no such element
+func (list *SortedList[E]) Ceiling(target E) (E, bool) {
+	index := list.lowerBound(target)
+	if index >= len(list.elements) {
+		return common.ZeroValue[E](), false
+	}
+	return list.elements[index], true
+}
+
+// Size returns the number of elements in the list
+func (list *SortedList[E]) Size() int {
+	return len(list.elements)
+}
+
+// IsEmpty checks if the list is empty
+func (list *SortedList[E]) IsEmpty() bool {
+	return len(list.elements) == 0
+}
+
+// Clear empties the list
+func (list *SortedList[E]) Clear() {
+	for i := range list.elements {
+		list.elements[i] = common.ZeroValue[E]()
+	}
+	list.elements = list.elements[:0]
+}
+
+// ToSlice returns a slice containing all elements in sorted order
+func (list *SortedList[E]) ToSlice() []E {
+	result := make([]E, len(list.elements))
+	copy(result, list.elements)
+	return result
+}
+
+// SubList returns a new SortedList containing the elements in the range [fromIndex, toIndex)
+func (list *SortedList[E]) SubList(fromIndex, toIndex int) (List[E], error) {
+	if fromIndex < 0 || toIndex > len(list.elements) || fromIndex > toIndex {
+		return nil, common.InvalidRangeError(fromIndex, toIndex)
+	}
+
+	subElements := make([]E, toIndex-fromIndex)
+	copy(subElements, list.elements[fromIndex:toIndex])
+	return &SortedList[E]{elements: subElements, comparator: list.comparator}, nil
+}
+
+// ForEach executes the given operation on each element in sorted order
+func (list *SortedList[E]) ForEach(f func(E)) {
+	for _, element := range list.elements {
+		f(element)
+	}
+}
+
+// String returns the string representation of the list
+func (list *SortedList[E]) String() string {
+	if len(list.elements) == 0 {
+		return "[]"
+	}
+
+	var builder strings.Builder
+	builder.WriteString("[")
+	for i, element := range list.elements {
+		if i > 0 {
+			builder.WriteString(", ")
+		}
+		builder.WriteString(fmt.Sprintf("%v", element))
+	}
+	builder.WriteString("]")
+	return builder.String()
+}
+
+// Iterator returns an iterator for traversing the elements in sorted order
+func (list *SortedList[E]) Iterator() common.Iterator[E] {
+	return &sortedListIterator[E]{list: list, cursor: 0, lastRet: -1}
+}
+
+// sortedListIterator is the iterator implementation for SortedList
+type sortedListIterator[E any] struct {
+	list    *SortedList[E]
+	cursor  int // Index of the next element
+	lastRet int // Index of the last returned element, -1 if none
+}
+
+// HasNext checks if the iterator has a next element
+func (it *sortedListIterator[E]) HasNext() bool {
+	return it.cursor < len(it.list.elements)
+}
+
+// Next returns the next element in the iterator
+func (it *sortedListIterator[E]) Next() (E, bool) {
+	if !it.HasNext() {
+		return common.ZeroValue[E](), false
+	}
+
+	element := it.list.elements[it.cursor]
+	it.lastRet = it.cursor
+	it.cursor++
+	return element, true
+}
+
+// Remove removes the last element returned by the iterator
+func (it *sortedListIterator[E]) Remove() bool {
+	if it.lastRet < 0 {
+		return false
+	}
+
+	_, removed := it.list.RemoveAt(it.lastRet)
+	if removed {
+		it.cursor = it.lastRet
+		it.lastRet = -1
+	}
+	return removed
+}
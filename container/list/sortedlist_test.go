@@ -0,0 +1,179 @@
+package list
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/chenjianyu/collections/container/common"
+)
+
+func intComparator(a, b int) int {
+	if a < b {
+		return -1
+	} else if a > b {
+		return 1
+	}
+	return 0
+}
+
+func TestSortedList_Add(t *testing.T) {
+	list := NewSortedList(intComparator)
+	for _, v := range []int{5, 1, 4, 1, 3, 9} {
+		list.Add(v)
+	}
+
+	expected := []int{1, 1, 3, 4, 5, 9}
+	if list.Size() != len(expected) {
+		t.Errorf("List size should be %d, got %d", len(expected), list.Size())
+	}
+	for i, exp := range expected {
+		if val, _ := list.Get(i); val != exp {
+			t.Errorf("Get(%d) should return %d, got %d", i, exp, val)
+		}
+	}
+}
+
+func TestSortedList_FromSlice(t *testing.T) {
+	slice := []int{3, 1, 2}
+	list := SortedListFromSlice(slice, intComparator)
+
+	if list.String() != "[1, 2, 3]" {
+		t.Errorf("List should be [1, 2, 3], got %s", list.String())
+	}
+	if slice[0] != 3 {
+		t.Error("SortedListFromSlice should not modify the source slice")
+	}
+}
+
+func TestSortedList_NilComparator(t *testing.T) {
+	for name, construct := range map[string]func(){
+		"NewSortedList":       func() { NewSortedList[int](nil) },
+		"SortedListFromSlice": func() { SortedListFromSlice([]int{2, 1}, nil) },
+	} {
+		func() {
+			defer func() {
+				err, ok := recover().(error)
+				if !ok || !errors.Is(err, common.ErrInvalidArgument) {
+					t.Errorf("%s with a nil comparator should panic with ErrInvalidArgument, got %v", name, err)
+				}
+			}()
+			construct()
+		}()
+	}
+}
+
+func TestSortedList_Lookup(t *testing.T) {
+	list := SortedListFromSlice([]int{2, 4, 4, 4, 6, 8}, intComparator)
+
+	if !list.Contains(6) || list.Contains(5) {
+		t.Error("Contains should find 6 and not 5")
+	}
+	if idx := list.IndexOf(4); idx != 1 {
+		t.Errorf("IndexOf(4) should return 1, got %d", idx)
+	}
+	if idx := list.LastIndexOf(4); idx != 3 {
+		t.Errorf("LastIndexOf(4) should return 3, got %d", idx)
+	}
+	if idx := list.IndexOf(7); idx != -1 {
+		t.Errorf("IndexOf(7) should return -1, got %d", idx)
+	}
+	if idx := list.LastIndexOf(1); idx != -1 {
+		t.Errorf("LastIndexOf(1) should return -1, got %d", idx)
+	}
+}
+
+func TestSortedList_FloorCeiling(t *testing.T) {
+	list := SortedListFromSlice([]int{10, 20, 20, 40}, intComparator)
+
+	floors := map[int]int{10: 10, 15: 10, 20: 20, 39: 20, 100: 40}
+	for target, expected := range floors {
+		if v, ok := list.Floor(target); !ok || v != expected {
+			t.Errorf("Floor(%d) should return %d, got %d, %v", target, expected, v, ok)
+		}
+	}
+	ceilings := map[int]int{-5: 10, 10: 10, 11: 20, 21: 40, 40: 40}
+	for target, expected := range ceilings {
+		if v, ok := list.Ceiling(target); !ok || v != expected {
+			t.Errorf("Ceiling(%d) should return %d, got %d, %v", target, expected, v, ok)
+		}
+	}
+
+	if _, ok := list.Floor(9); ok {
+		t.Error("Floor below the first element should return false")
+	}
+	if _, ok := list.Ceiling(41); ok {
+		t.Error("Ceiling above the last element should return false")
+	}
+	if _, ok := NewSortedList(intComparator).Floor(0); ok {
+		t.Error("Floor on an empty list should return false")
+	}
+}
+
+func TestSortedList_Remove(t *testing.T) {
+	list := SortedListFromSlice([]int{1, 2, 2, 3}, intComparator)
+
+	if !list.Remove(2) || list.Size() != 3 {
+		t.Error("Remove(2) should remove one occurrence")
+	}
+	if list.Remove(10) {
+		t.Error("Remove of missing element should return false")
+	}
+	if val, ok := list.RemoveAt(0); !ok || val != 1 {
+		t.Errorf("RemoveAt(0) should return (1, true), got (%d, %v)", val, ok)
+	}
+	if list.String() != "[2, 3]" {
+		t.Errorf("List should be [2, 3], got %s", list.String())
+	}
+}
+
+func TestSortedList_UnsupportedOperations(t *testing.T) {
+	list := SortedListFromSlice([]int{1, 2, 3}, intComparator)
+
+	err := list.Insert(0, 10)
+	if !errors.Is(err, common.ErrInvalidOperation) {
+		t.Errorf("Insert should return ErrInvalidOperation, got %v", err)
+	}
+	if _, ok := list.Set(0, 10); ok {
+		t.Error("Set should return false")
+	}
+	if list.String() != "[1, 2, 3]" {
+		t.Errorf("Unsupported operations should not modify the list, got %s", list.String())
+	}
+}
+
+func TestSortedList_SubListAndIterator(t *testing.T) {
+	var l List[int] = SortedListFromSlice([]int{5, 3, 1, 4, 2}, intComparator)
+
+	sub, err := l.SubList(1, 4)
+	if err != nil {
+		t.Errorf("SubList should not return error, got %v", err)
+	}
+	if sub.String() != "[2, 3, 4]" {
+		t.Errorf("SubList should be [2, 3, 4], got %s", sub.String())
+	}
+	sub.Add(0)
+	if val, _ := sub.Get(0); val != 0 {
+		t.Errorf("SubList should stay sorted, got %s", sub.String())
+	}
+
+	it := l.Iterator()
+	prev := 0
+	for it.HasNext() {
+		val, _ := it.Next()
+		if val < prev {
+			t.Errorf("Iterator should yield sorted elements, got %d after %d", val, prev)
+		}
+		prev = val
+		if val%2 == 0 {
+			it.Remove()
+		}
+	}
+	if l.String() != "[1, 3, 5]" {
+		t.Errorf("List should be [1, 3, 5] after removing evens, got %s", l.String())
+	}
+
+	l.Clear()
+	if !l.IsEmpty() {
+		t.Error("List should be empty after Clear")
+	}
+}
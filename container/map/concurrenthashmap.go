@@ -14,7 +14,9 @@ type ConcurrentHashMap[K comparable, V any] struct {
 	segments     []*segment[K, V]
 	segmentMask  uint32
 	segmentCount int
-	hashStrategy common.HashStrategy[K]
+	// initialBuckets is the per-segment bucket count restored by ClearAndShrink
+	initialBuckets int
+	hashStrategy   common.HashStrategy[K]
 }
 
 // segment represents a segment of ConcurrentHashMap
@@ -40,6 +42,10 @@ const defaultBuckets = 16
 // Load factor threshold for concurrent hash map
 const concurrentLoadFactor = 0.75
 
+// swapContentsMutex serializes SwapContents calls so that two maps swapped from both
+// sides at once cannot acquire each other's segment locks in opposite orders
+var swapContentsMutex sync.Mutex
+
 // NewConcurrentHashMap creates a new ConcurrentHashMap with default hash strategy
 func NewConcurrentHashMap[K comparable, V any]() *ConcurrentHashMap[K, V] {
 	return NewConcurrentHashMapWithHashStrategy[K, V](common.NewComparableHashStrategy[K]())
@@ -75,10 +81,11 @@ func NewConcurrentHashMapWithCapacityAndHashStrategy[K comparable, V any](capaci
 	}
 
 	return &ConcurrentHashMap[K, V]{
-		segments:     segments,
-		segmentCount: segmentCount,
-		segmentMask:  uint32(segmentCount - 1),
-		hashStrategy: hashStrategy,
+		segments:       segments,
+		segmentCount:   segmentCount,
+		segmentMask:    uint32(segmentCount - 1),
+		initialBuckets: bucketsPerSegment,
+		hashStrategy:   hashStrategy,
 	}
 }
 
@@ -157,6 +164,60 @@ func (chm *ConcurrentHashMap[K, V]) Get(key K) (V, bool) {
 	return zero, false
 }
 
+// GetAll returns the values mapped to the given keys, omitting keys that are absent
+// Keys are grouped by segment so each segment is read-locked once
+func (chm *ConcurrentHashMap[K, V]) GetAll(keys []K) map[K]V {
+	result := make(map[K]V, len(keys))
+	chm.getBatch(keys, func(index int, value V, found bool) {
+		if found {
+			result[keys[index]] = value
+		}
+	})
+	return result
+}
+
+// GetAllOrDefault returns the values mapped to the given keys in key order,
+// using defaultValue for keys that are absent
+// Keys are grouped by segment so each segment is read-locked once
+func (chm *ConcurrentHashMap[K, V]) GetAllOrDefault(keys []K, defaultValue V) []V {
+	values := make([]V, len(keys))
+	chm.getBatch(keys, func(index int, value V, found bool) {
+		if found {
+			values[index] = value
+		} else {
+			values[index] = defaultValue
+		}
+	})
+	return values
+}
+
+// getBatch looks up keys one segment at a time, calling visit with each key's position
+// Each segment holds its read lock only while its own keys are looked up, so the batch
+// is consistent per segment but not across segments
+func (chm *ConcurrentHashMap[K, V]) getBatch(keys []K, visit func(index int, value V, found bool)) {
+	hashes := make([]uint32, len(keys))
+	bySegment := make(map[uint32][]int)
+	for i, key := range keys {
+		hashes[i] = chm.hash(key)
+		segmentIndex := hashes[i] & chm.segmentMask
+		bySegment[segmentIndex] = append(bySegment[segmentIndex], i)
+	}
+
+	var zero V
+	for segmentIndex, indices := range bySegment {
+		segment := chm.segments[segmentIndex]
+		segment.mutex.RLock()
+		for _, i := range indices {
+			if node := chm.findInSegment(segment, hashes[i], keys[i]); node != nil {
+				visit(i, node.value, true)
+			} else {
+				visit(i, zero, false)
+			}
+		}
+		segment.mutex.RUnlock()
+	}
+}
+
 // Remove removes the mapping for the specified key from this map if present
 func (chm *ConcurrentHashMap[K, V]) Remove(key K) (V, bool) {
 	hash := chm.hash(key)
@@ -225,6 +286,19 @@ func (chm *ConcurrentHashMap[K, V]) Size() int {
 	return totalSize
 }
 
+// Stats returns the map's size and total bucket count across segments
+// Each segment is read under its own lock, so the snapshot is not atomic across segments
+func (chm *ConcurrentHashMap[K, V]) Stats() common.ContainerStats {
+	size, buckets := 0, 0
+	for _, segment := range chm.segments {
+		segment.mutex.RLock()
+		size += segment.size
+		buckets += len(segment.buckets)
+		segment.mutex.RUnlock()
+	}
+	return common.NewContainerStats(size, size, buckets)
+}
+
 // IsEmpty returns true if this map contains no key-value mappings
 func (chm *ConcurrentHashMap[K, V]) IsEmpty() bool {
 	return chm.Size() == 0
@@ -242,6 +316,46 @@ func (chm *ConcurrentHashMap[K, V]) Clear() {
 	}
 }
 
+// ClearAndShrink removes all mappings and shrinks every segment back to its initial bucket count
+// Use it instead of Clear to release the buckets grown during a large load
+func (chm *ConcurrentHashMap[K, V]) ClearAndShrink() {
+	for _, segment := range chm.segments {
+		segment.mutex.Lock()
+		segment.buckets = make([]bucket[K, V], chm.initialBuckets)
+		segment.size = 0
+		segment.mutex.Unlock()
+	}
+}
+
+// SwapContents exchanges the entries of this map and other without copying them
+// Every segment of both maps is locked for the duration of the swap, so single-key
+// operations observe either the old or the new contents and never a mix of the two
+// Both maps must use equivalent hash strategies; swapping a map with itself or nil is a no-op
+func (chm *ConcurrentHashMap[K, V]) SwapContents(other *ConcurrentHashMap[K, V]) {
+	if other == nil || other == chm {
+		return
+	}
+
+	swapContentsMutex.Lock()
+	defer swapContentsMutex.Unlock()
+
+	for _, segment := range chm.segments {
+		segment.mutex.Lock()
+		defer segment.mutex.Unlock()
+	}
+	for _, segment := range other.segments {
+		segment.mutex.Lock()
+		defer segment.mutex.Unlock()
+	}
+
+	// Both maps always have defaultSegments segments, so entries stay in the segment their hash selects
+	for i, segment := range chm.segments {
+		peer := other.segments[i]
+		segment.buckets, peer.buckets = peer.buckets, segment.buckets
+		segment.size, peer.size = peer.size, segment.size
+	}
+}
+
 // Keys returns a collection view of the keys contained in this map
 func (chm *ConcurrentHashMap[K, V]) Keys() []K {
 	var keys []K
@@ -288,6 +402,10 @@ func (chm *ConcurrentHashMap[K, V]) Entries() []common.Entry[K, V] {
 }
 
 // ForEach performs the given action for each key-value pair in this map
+// Iteration is weakly consistent: each segment is walked under its read lock for the whole pass,
+// so a concurrent resize of that segment waits and every mapping present for the whole call is visited once;
+// updates to segments not yet reached or already left may or may not be seen.
+// The action must not modify this map, since the segment lock is held while it runs
 func (chm *ConcurrentHashMap[K, V]) ForEach(action func(K, V)) {
 	for _, segment := range chm.segments {
 		segment.mutex.RLock()
@@ -316,6 +434,25 @@ func (chm *ConcurrentHashMap[K, V]) String() string {
 	return builder.String()
 }
 
+// StringN returns a representation like String that shows at most n mappings
+// The remaining mappings are summarised as "… (+k more)"; segments are read one at a time,
+// so the count is weakly consistent under concurrent updates
+func (chm *ConcurrentHashMap[K, V]) StringN(n int) string {
+	b := common.NewLimitedStringBuilder("{", n)
+	total := 0
+	for _, segment := range chm.segments {
+		segment.mutex.RLock()
+		total += segment.size
+		for _, bkt := range segment.buckets {
+			for current := bkt.next; current != nil && !b.Full(); current = current.next {
+				b.Addf("%v=%v", current.key, current.value)
+			}
+		}
+		segment.mutex.RUnlock()
+	}
+	return b.Finish("}", total)
+}
+
 // Advanced operation methods
 
 // PutIfAbsent adds key-value pair only if key doesn't exist
@@ -407,6 +544,33 @@ func (chm *ConcurrentHashMap[K, V]) ReplaceIf(key K, oldValue, newValue V) bool
 	return false
 }
 
+// RemoveIfValue removes the mapping only if the key is currently mapped to expected
+// The check and the removal happen under the segment's write lock
+func (chm *ConcurrentHashMap[K, V]) RemoveIfValue(key K, expected V) bool {
+	hash := chm.hash(key)
+	segmentIndex := hash & chm.segmentMask
+	segment := chm.segments[segmentIndex]
+
+	segment.mutex.Lock()
+	defer segment.mutex.Unlock()
+
+	bucketIndex := hash % uint32(len(segment.buckets))
+	prev := &segment.buckets[bucketIndex]
+
+	for current := prev.next; current != nil; prev, current = current, current.next {
+		if chm.hashStrategy.Equals(current.key, key) {
+			if !chm.valueEquals(current.value, expected) {
+				return false
+			}
+			prev.next = current.next
+			segment.size--
+			return true
+		}
+	}
+
+	return false
+}
+
 // PutAll copies all elements from another Map
 func (chm *ConcurrentHashMap[K, V]) PutAll(other Map[K, V]) {
 	other.ForEach(func(key K, value V) {
@@ -496,8 +660,72 @@ func (chm *ConcurrentHashMap[K, V]) ComputeIfPresent(key K, remappingFunction fu
 	return zero, false
 }
 
+// WithKeyLocked runs f while holding the write lock of the segment that owns key
+// f receives accessors for that single key: get returns the current value, put stores a
+// value and remove deletes the mapping. All three act atomically with respect to other
+// operations on the map, so f can perform a multi-step read-modify-write on the key
+// The accessors must only be used inside f, and f must not call other methods of this
+// map, since the segment lock is not reentrant
+func (chm *ConcurrentHashMap[K, V]) WithKeyLocked(key K, f func(get func() (V, bool), put func(V), remove func())) {
+	hash := chm.hash(key)
+	segmentIndex := hash & chm.segmentMask
+	segment := chm.segments[segmentIndex]
+
+	segment.mutex.Lock()
+	defer segment.mutex.Unlock()
+
+	get := func() (V, bool) {
+		if node := chm.findInSegment(segment, hash, key); node != nil {
+			return node.value, true
+		}
+		var zero V
+		return zero, false
+	}
+	put := func(value V) {
+		if node := chm.findInSegment(segment, hash, key); node != nil {
+			node.value = value
+			return
+		}
+		bucketPtr := &segment.buckets[hash%uint32(len(segment.buckets))]
+		bucketPtr.next = &bucket[K, V]{
+			key:   key,
+			value: value,
+			next:  bucketPtr.next,
+		}
+		segment.size++
+
+		// Check if resize is needed
+		if float64(segment.size) > float64(len(segment.buckets))*concurrentLoadFactor {
+			chm.resizeSegment(segment)
+		}
+	}
+	remove := func() {
+		prev := &segment.buckets[hash%uint32(len(segment.buckets))]
+		for current := prev.next; current != nil; prev, current = current, current.next {
+			if chm.hashStrategy.Equals(current.key, key) {
+				prev.next = current.next
+				segment.size--
+				return
+			}
+		}
+	}
+
+	f(get, put, remove)
+}
+
 // Internal helper methods
 
+// findInSegment returns the node holding key in segment, or nil; the caller must hold the segment lock
+func (chm *ConcurrentHashMap[K, V]) findInSegment(segment *segment[K, V], hash uint32, key K) *bucket[K, V] {
+	bucketPtr := &segment.buckets[hash%uint32(len(segment.buckets))]
+	for current := bucketPtr.next; current != nil; current = current.next {
+		if chm.hashStrategy.Equals(current.key, key) {
+			return current
+		}
+	}
+	return nil
+}
+
 // hash computes hash value for key using the hash strategy
 func (chm *ConcurrentHashMap[K, V]) hash(key K) uint32 {
 	return uint32(chm.hashStrategy.Hash(key))
@@ -508,7 +736,8 @@ func (chm *ConcurrentHashMap[K, V]) valueEquals(a, b V) bool {
     return common.Equal(a, b)
 }
 
-// resizeSegment resizes the specified segment
+// resizeSegment resizes the specified segment; the caller must hold the segment's write lock,
+// which keeps readers from ever seeing the bucket slice swapped mid-walk
 func (chm *ConcurrentHashMap[K, V]) resizeSegment(segment *segment[K, V]) {
 	oldBuckets := segment.buckets
 	newSize := len(oldBuckets) * 2
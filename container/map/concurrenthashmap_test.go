@@ -2,9 +2,13 @@ package maps
 
 import (
 	"fmt"
+	"strings"
 	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
+
+	"github.com/chenjianyu/collections/container/common"
 )
 
 func TestNewConcurrentHashMap(t *testing.T) {
@@ -157,6 +161,37 @@ func TestConcurrentHashMapClear(t *testing.T) {
 	}
 }
 
+func TestConcurrentHashMapClearAndShrink(t *testing.T) {
+	chm := NewConcurrentHashMap[int, int]()
+	initial := len(chm.segments[0].buckets)
+	for i := 0; i < 10000; i++ {
+		chm.Put(i, i)
+	}
+	if len(chm.segments[0].buckets) <= initial {
+		t.Fatal("Segments should grow under load")
+	}
+
+	chm.Clear()
+	if !chm.IsEmpty() || len(chm.segments[0].buckets) <= initial {
+		t.Error("Clear should empty the map but keep grown buckets")
+	}
+
+	chm.ClearAndShrink()
+	if !chm.IsEmpty() {
+		t.Error("Map should be empty after ClearAndShrink")
+	}
+	for i, segment := range chm.segments {
+		if len(segment.buckets) != initial {
+			t.Fatalf("Segment %d should shrink to %d buckets, got %d", i, initial, len(segment.buckets))
+		}
+	}
+
+	chm.Put(1, 1)
+	if v, ok := chm.Get(1); !ok || v != 1 {
+		t.Error("Map should be usable after ClearAndShrink")
+	}
+}
+
 func TestConcurrentHashMapKeysAndValues(t *testing.T) {
 	chm := NewConcurrentHashMap[string, int]()
 	chm.Put("key1", 100)
@@ -593,3 +628,319 @@ func TestConcurrentHashMapStress(t *testing.T) {
 
 	t.Logf("Stress test completed. Final map size: %d", chm.Size())
 }
+
+func TestConcurrentHashMapRemoveIfValue(t *testing.T) {
+	chm := NewConcurrentHashMap[string, int]()
+	chm.Put("key1", 100)
+	chm.Put("key2", 200)
+
+	if chm.RemoveIfValue("key1", 999) {
+		t.Error("Should not have removed with incorrect expected value")
+	}
+	if !chm.RemoveIfValue("key1", 100) {
+		t.Error("Should have removed with correct expected value")
+	}
+	if chm.ContainsKey("key1") || chm.Size() != 1 {
+		t.Error("key1 should be removed and size should be 1")
+	}
+	if chm.RemoveIfValue("nonexistent", 0) {
+		t.Error("Should not have removed non-existing key")
+	}
+
+	// Only one of several concurrent compare-and-delete calls may win
+	chm.Put("contended", 1)
+	var wg sync.WaitGroup
+	var wins int32
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if chm.RemoveIfValue("contended", 1) {
+				atomic.AddInt32(&wins, 1)
+			}
+		}()
+	}
+	wg.Wait()
+	if wins != 1 {
+		t.Errorf("Exactly one RemoveIfValue should succeed, got %d", wins)
+	}
+}
+
+func TestConcurrentHashMapWithKeyLocked(t *testing.T) {
+	chm := NewConcurrentHashMap[string, int]()
+
+	// Insert through put when absent
+	chm.WithKeyLocked("counter", func(get func() (int, bool), put func(int), remove func()) {
+		if _, ok := get(); ok {
+			t.Error("counter should not exist yet")
+		}
+		put(1)
+		if v, ok := get(); !ok || v != 1 {
+			t.Errorf("get after put should return 1, got %d, %v", v, ok)
+		}
+	})
+	if v, _ := chm.Get("counter"); v != 1 || chm.Size() != 1 {
+		t.Errorf("counter should be 1 with size 1, got %d and %d", v, chm.Size())
+	}
+
+	// Multi-step read-modify-write from many goroutines must not lose updates
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			chm.WithKeyLocked("counter", func(get func() (int, bool), put func(int), remove func()) {
+				v, _ := get()
+				put(v + 1)
+			})
+		}()
+	}
+	wg.Wait()
+	if v, _ := chm.Get("counter"); v != 51 {
+		t.Errorf("counter should be 51, got %d", v)
+	}
+
+	// Conditional removal
+	chm.WithKeyLocked("counter", func(get func() (int, bool), put func(int), remove func()) {
+		if v, _ := get(); v > 50 {
+			remove()
+		}
+		if _, ok := get(); ok {
+			t.Error("get after remove should report absent")
+		}
+	})
+	if chm.ContainsKey("counter") || !chm.IsEmpty() {
+		t.Error("counter should be removed")
+	}
+}
+
+func TestConcurrentHashMapGetAll(t *testing.T) {
+	m := NewConcurrentHashMap[string, int]()
+	m.Put("a", 1)
+	m.Put("b", 2)
+	m.Put("c", 3)
+
+	found := m.GetAll([]string{"a", "c", "missing"})
+	if len(found) != 2 || found["a"] != 1 || found["c"] != 3 {
+		t.Errorf("GetAll should return only present keys, got %v", found)
+	}
+	if _, ok := found["missing"]; ok {
+		t.Error("GetAll should omit absent keys")
+	}
+
+	values := m.GetAllOrDefault([]string{"b", "missing", "a"}, -1)
+	if len(values) != 3 || values[0] != 2 || values[1] != -1 || values[2] != 1 {
+		t.Errorf("GetAllOrDefault should return [2 -1 1], got %v", values)
+	}
+	if len(m.GetAll(nil)) != 0 || len(m.GetAllOrDefault(nil, 0)) != 0 {
+		t.Error("Empty batches should return empty results")
+	}
+}
+
+func TestConcurrentHashMapGetAllAcrossSegments(t *testing.T) {
+	m := NewConcurrentHashMap[int, int]()
+	keys := make([]int, 0, 1000)
+	for i := 0; i < 1000; i++ {
+		m.Put(i, i*i)
+		keys = append(keys, i)
+	}
+	keys = append(keys, -1)
+
+	values := m.GetAllOrDefault(keys, -1)
+	for i, key := range keys {
+		expected := -1
+		if key >= 0 {
+			expected = key * key
+		}
+		if values[i] != expected {
+			t.Fatalf("GetAllOrDefault[%d] should be %d, got %d", i, expected, values[i])
+		}
+	}
+	if found := m.GetAll(keys); len(found) != 1000 {
+		t.Errorf("GetAll should find 1000 keys, got %d", len(found))
+	}
+}
+
+func TestConcurrentHashMapCaseInsensitiveKeys(t *testing.T) {
+	headers := NewConcurrentHashMapWithHashStrategy[string, string](common.NewCaseInsensitiveStringHashStrategy())
+	headers.Put("Content-Type", "text/plain")
+
+	if v, ok := headers.Get("content-type"); !ok || v != "text/plain" {
+		t.Errorf("Lookup should ignore case, got (%s, %v)", v, ok)
+	}
+	if _, existed := headers.Put("CONTENT-TYPE", "application/json"); !existed {
+		t.Error("Put with different case should replace the existing key")
+	}
+	if headers.Size() != 1 {
+		t.Errorf("Map should hold one header, got %d", headers.Size())
+	}
+}
+
+func TestConcurrentHashMapSwapContents(t *testing.T) {
+	live := NewConcurrentHashMap[string, int]()
+	live.Put("a", 1)
+	live.Put("b", 2)
+
+	staging := NewConcurrentHashMapWithCapacity[string, int](1024)
+	for i := 0; i < 500; i++ {
+		staging.Put(fmt.Sprintf("key%d", i), i)
+	}
+	staging.Put("a", 100)
+
+	live.SwapContents(staging)
+
+	if live.Size() != 501 || staging.Size() != 2 {
+		t.Errorf("Expected sizes 501 and 2 after swap, got %d and %d", live.Size(), staging.Size())
+	}
+	if v, _ := live.Get("a"); v != 100 {
+		t.Errorf("Expected live a=100, got %d", v)
+	}
+	if v, _ := live.Get("key499"); v != 499 {
+		t.Errorf("Expected live key499=499, got %d", v)
+	}
+	if v, _ := staging.Get("b"); v != 2 {
+		t.Errorf("Expected staging b=2, got %d", v)
+	}
+	if live.ContainsKey("b") {
+		t.Error("Old entries should have moved out of the live map")
+	}
+
+	// Both maps keep working normally after the swap
+	staging.Clear()
+	live.Put("c", 3)
+	if staging.Size() != 0 || live.Size() != 502 {
+		t.Error("Swapped maps should stay independent")
+	}
+
+	live.SwapContents(live)
+	live.SwapContents(nil)
+	if live.Size() != 502 {
+		t.Error("Swapping with itself or nil should be a no-op")
+	}
+}
+
+func TestConcurrentHashMapSwapContentsConcurrent(t *testing.T) {
+	a := NewConcurrentHashMap[int, int]()
+	b := NewConcurrentHashMap[int, int]()
+	for i := 0; i < 100; i++ {
+		a.Put(i, 1)
+		b.Put(i, 2)
+	}
+
+	var wg sync.WaitGroup
+	stop := make(chan struct{})
+	var badReads int64
+	for r := 0; r < 4; r++ {
+		wg.Add(1)
+		go func(r int) {
+			defer wg.Done()
+			for {
+				select {
+				case <-stop:
+					return
+				default:
+				}
+				if v, ok := a.Get(r); !ok || (v != 1 && v != 2) {
+					atomic.AddInt64(&badReads, 1)
+				}
+			}
+		}(r)
+	}
+
+	// Swapping from both sides at once must not deadlock
+	var swaps sync.WaitGroup
+	for s := 0; s < 2; s++ {
+		swaps.Add(1)
+		go func(s int) {
+			defer swaps.Done()
+			for i := 0; i < 200; i++ {
+				if s == 0 {
+					a.SwapContents(b)
+				} else {
+					b.SwapContents(a)
+				}
+			}
+		}(s)
+	}
+	swaps.Wait()
+	close(stop)
+	wg.Wait()
+
+	if badReads != 0 {
+		t.Errorf("Readers should always see a complete map, got %d bad reads", badReads)
+	}
+	if a.Size() != 100 || b.Size() != 100 {
+		t.Errorf("Expected both maps to keep 100 entries, got %d and %d", a.Size(), b.Size())
+	}
+}
+
+func TestConcurrentHashMapStringN(t *testing.T) {
+	chm := NewConcurrentHashMap[int, int]()
+	if got := chm.StringN(2); got != "{}" {
+		t.Errorf("Expected {}, got %q", got)
+	}
+	for i := 0; i < 50; i++ {
+		chm.Put(i, i*i)
+	}
+	got := chm.StringN(4)
+	if !strings.HasSuffix(got, ", … (+46 more)}") || strings.Count(got, "=") != 4 {
+		t.Errorf("Expected four mappings and a summary, got %q", got)
+	}
+}
+
+func TestConcurrentHashMapStats(t *testing.T) {
+	m := NewConcurrentHashMap[int, int]()
+	for i := 0; i < 100; i++ {
+		m.Put(i, i)
+	}
+	var reporter common.StatsReporter = m
+	stats := reporter.Stats()
+	buckets := 0
+	for _, seg := range m.segments {
+		buckets += len(seg.buckets)
+	}
+	if stats.Len != 100 || stats.Distinct != 100 || stats.Capacity != buckets {
+		t.Errorf("unexpected stats %+v, want %d buckets", stats, buckets)
+	}
+	if stats.LoadFactor <= 0 {
+		t.Errorf("load factor should be positive, got %v", stats.LoadFactor)
+	}
+}
+
+func TestConcurrentHashMapForEachDuringResize(t *testing.T) {
+	chm := NewConcurrentHashMapWithCapacity[int, int](1)
+	const stable = 500
+	for i := 0; i < stable; i++ {
+		chm.Put(-i-1, i)
+	}
+
+	var stop int32
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		// Keep growing every segment so resizes overlap the iterations below
+		for next := 0; atomic.LoadInt32(&stop) == 0 && next < 1<<18; next++ {
+			chm.Put(next, next)
+		}
+	}()
+
+	for round := 0; round < 100; round++ {
+		seen := make(map[int]int)
+		chm.ForEach(func(k, v int) {
+			seen[k]++
+		})
+		for k, n := range seen {
+			if n != 1 {
+				t.Fatalf("Expected key %d to be visited once, got %d", k, n)
+			}
+		}
+		for i := 0; i < stable; i++ {
+			if seen[-i-1] != 1 {
+				t.Fatalf("Round %d: expected stable key %d to be visited", round, -i-1)
+			}
+		}
+	}
+	atomic.StoreInt32(&stop, 1)
+	wg.Wait()
+}
@@ -0,0 +1,473 @@
+package maps
+
+import (
+	"fmt"
+	"math/rand"
+	"runtime"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"github.com/chenjianyu/collections/container/common"
+)
+
+const (
+	skipListMaxLevel    = 32
+	skipListProbability = 0.25
+)
+
+// ConcurrentSkipListMap is a thread-safe sorted map based on a lazy concurrent skip list
+// Get, ContainsKey and the navigation methods never take a lock, so readers do not block
+// writers. Writers lock only the few nodes around the key they change, so updates of
+// different parts of the map proceed in parallel instead of serializing on one lock
+// Iteration and navigation are weakly consistent: they reflect the map at some point
+// during the call and never fail because of concurrent modification
+type ConcurrentSkipListMap[K comparable, V any] struct {
+	size       int64 // Updated atomically; kept first for 64-bit alignment
+	head       *skipListMapNode[K, V]
+	comparator func(a, b K) int
+}
+
+// skipListMapNode is a node of the skip list
+// A node is logically removed once marked and logically present once fullyLinked
+type skipListMapNode[K comparable, V any] struct {
+	key         K
+	value       atomic.Value   // Holds *V
+	next        []atomic.Value // Holds *skipListMapNode[K, V], one per level
+	mutex       sync.Mutex
+	marked      int32
+	fullyLinked int32
+}
+
+// NewConcurrentSkipListMap creates a ConcurrentSkipListMap ordered by natural key order
+func NewConcurrentSkipListMap[K comparable, V any]() *ConcurrentSkipListMap[K, V] {
+	return NewConcurrentSkipListMapWithComparator[K, V](common.CompareNatural[K])
+}
+
+// NewConcurrentSkipListMapWithComparator creates a ConcurrentSkipListMap ordered by comparator
+// A nil comparator falls back to natural key order
+func NewConcurrentSkipListMapWithComparator[K comparable, V any](comparator func(a, b K) int) *ConcurrentSkipListMap[K, V] {
+	if comparator == nil {
+		comparator = common.CompareNatural[K]
+	}
+	head := newSkipListMapNode[K, V](common.ZeroValue[K](), common.ZeroValue[V](), skipListMaxLevel)
+	atomic.StoreInt32(&head.fullyLinked, 1)
+	return &ConcurrentSkipListMap[K, V]{
+		head:       head,
+		comparator: comparator,
+	}
+}
+
+// newSkipListMapNode creates an unlinked node with the given number of levels
+func newSkipListMapNode[K comparable, V any](key K, value V, levels int) *skipListMapNode[K, V] {
+	node := &skipListMapNode[K, V]{
+		key:  key,
+		next: make([]atomic.Value, levels),
+	}
+	node.value.Store(&value)
+	for i := range node.next {
+		node.next[i].Store((*skipListMapNode[K, V])(nil))
+	}
+	return node
+}
+
+// Put associates the specified value with the specified key in this map
+func (m *ConcurrentSkipListMap[K, V]) Put(key K, value V) (V, bool) {
+	var preds, succs [skipListMaxLevel]*skipListMapNode[K, V]
+	for {
+		if found := m.find(key, &preds, &succs); found != -1 {
+			node := succs[found]
+			if node.isMarked() {
+				// Being removed; retry once it is unlinked
+				runtime.Gosched()
+				continue
+			}
+			for !node.isFullyLinked() {
+				// Being inserted by another writer; wait until it is visible
+				runtime.Gosched()
+			}
+			node.mutex.Lock()
+			if node.isMarked() {
+				node.mutex.Unlock()
+				continue
+			}
+			old := node.value.Swap(&value).(*V)
+			node.mutex.Unlock()
+			return *old, true
+		}
+
+		levels := randomSkipListLevel()
+		locked, valid := m.lockPredecessors(&preds, &succs, levels, nil)
+		if !valid {
+			unlockNodes(locked)
+			continue
+		}
+
+		node := newSkipListMapNode(key, value, levels)
+		for level := 0; level < levels; level++ {
+			node.next[level].Store(succs[level])
+		}
+		for level := 0; level < levels; level++ {
+			preds[level].next[level].Store(node)
+		}
+		atomic.StoreInt32(&node.fullyLinked, 1)
+		unlockNodes(locked)
+		atomic.AddInt64(&m.size, 1)
+
+		var zero V
+		return zero, false
+	}
+}
+
+// Get returns the value mapped to the specified key without taking any lock
+func (m *ConcurrentSkipListMap[K, V]) Get(key K) (V, bool) {
+	if node := m.findNode(key); node != nil {
+		return node.load(), true
+	}
+	var zero V
+	return zero, false
+}
+
+// Remove removes the mapping for the specified key
+func (m *ConcurrentSkipListMap[K, V]) Remove(key K) (V, bool) {
+	var preds, succs [skipListMaxLevel]*skipListMapNode[K, V]
+	var victim *skipListMapNode[K, V]
+	var zero V
+	for {
+		found := m.find(key, &preds, &succs)
+		if victim == nil {
+			if found == -1 {
+				return zero, false
+			}
+			candidate := succs[found]
+			if !candidate.isFullyLinked() || candidate.isMarked() || len(candidate.next)-1 != found {
+				if candidate.isMarked() {
+					return zero, false
+				}
+				// Still being inserted; retry until it is fully linked
+				runtime.Gosched()
+				continue
+			}
+			candidate.mutex.Lock()
+			if candidate.isMarked() {
+				candidate.mutex.Unlock()
+				return zero, false
+			}
+			atomic.StoreInt32(&candidate.marked, 1)
+			victim = candidate
+		}
+
+		levels := len(victim.next)
+		locked, valid := m.lockPredecessors(&preds, &succs, levels, victim)
+		if !valid {
+			unlockNodes(locked)
+			continue
+		}
+
+		for level := levels - 1; level >= 0; level-- {
+			preds[level].next[level].Store(victim.nextAt(level))
+		}
+		victim.mutex.Unlock()
+		unlockNodes(locked)
+		atomic.AddInt64(&m.size, -1)
+		return victim.load(), true
+	}
+}
+
+// ContainsKey returns true if this map contains a mapping for the specified key
+func (m *ConcurrentSkipListMap[K, V]) ContainsKey(key K) bool {
+	return m.findNode(key) != nil
+}
+
+// ContainsValue returns true if this map maps one or more keys to the specified value
+func (m *ConcurrentSkipListMap[K, V]) ContainsValue(value V) bool {
+	for node := m.firstNode(); node != nil; node = m.successor(node) {
+		if common.Equal(node.load(), value) {
+			return true
+		}
+	}
+	return false
+}
+
+// Size returns the number of mappings in this map
+func (m *ConcurrentSkipListMap[K, V]) Size() int {
+	return int(atomic.LoadInt64(&m.size))
+}
+
+// IsEmpty returns true if this map contains no mappings
+func (m *ConcurrentSkipListMap[K, V]) IsEmpty() bool {
+	return m.Size() == 0
+}
+
+// Clear removes all mappings from this map one at a time
+// Mappings added concurrently with Clear may or may not be removed
+func (m *ConcurrentSkipListMap[K, V]) Clear() {
+	for node := m.firstNode(); node != nil; node = m.firstNode() {
+		m.Remove(node.key)
+	}
+}
+
+// Keys returns the keys in ascending order
+func (m *ConcurrentSkipListMap[K, V]) Keys() []K {
+	keys := make([]K, 0, m.Size())
+	m.ForEach(func(k K, _ V) {
+		keys = append(keys, k)
+	})
+	return keys
+}
+
+// Values returns the values in ascending key order
+func (m *ConcurrentSkipListMap[K, V]) Values() []V {
+	values := make([]V, 0, m.Size())
+	m.ForEach(func(_ K, v V) {
+		values = append(values, v)
+	})
+	return values
+}
+
+// Entries returns the mappings in ascending key order
+func (m *ConcurrentSkipListMap[K, V]) Entries() []common.Entry[K, V] {
+	entries := make([]common.Entry[K, V], 0, m.Size())
+	m.ForEach(func(k K, v V) {
+		entries = append(entries, common.NewEntry(k, v))
+	})
+	return entries
+}
+
+// ForEach executes the given operation for each mapping in ascending key order
+// f may modify the map; the traversal is weakly consistent
+func (m *ConcurrentSkipListMap[K, V]) ForEach(f func(K, V)) {
+	for node := m.firstNode(); node != nil; node = m.successor(node) {
+		f(node.key, node.load())
+	}
+}
+
+// PutAll copies all mappings from the specified map into this map
+func (m *ConcurrentSkipListMap[K, V]) PutAll(other Map[K, V]) {
+	other.ForEach(func(k K, v V) {
+		m.Put(k, v)
+	})
+}
+
+// String returns the string representation of this map in ascending key order
+func (m *ConcurrentSkipListMap[K, V]) String() string {
+	var builder strings.Builder
+	builder.WriteString("{")
+	first := true
+	m.ForEach(func(k K, v V) {
+		if !first {
+			builder.WriteString(", ")
+		}
+		builder.WriteString(fmt.Sprintf("%v=%v", k, v))
+		first = false
+	})
+	builder.WriteString("}")
+	return builder.String()
+}
+
+// FirstEntry returns the mapping with the least key
+// Returns false if the map is empty
+func (m *ConcurrentSkipListMap[K, V]) FirstEntry() (common.Entry[K, V], bool) {
+	return entryOf(m.firstNode())
+}
+
+// LastEntry returns the mapping with the greatest key
+// Returns false if the map is empty
+func (m *ConcurrentSkipListMap[K, V]) LastEntry() (common.Entry[K, V], bool) {
+	current := m.head
+	for level := skipListMaxLevel - 1; level >= 0; level-- {
+		for next := current.nextAt(level); next != nil; next = current.nextAt(level) {
+			current = next
+		}
+	}
+	if current == m.head {
+		return entryOf[K, V](nil)
+	}
+	if current.isLive() {
+		return entryOf(current)
+	}
+	return entryOf(m.lowerNode(current.key))
+}
+
+// CeilingEntry returns the mapping with the least key greater than or equal to key
+// Returns false if there is no such key
+func (m *ConcurrentSkipListMap[K, V]) CeilingEntry(key K) (common.Entry[K, V], bool) {
+	node := m.findGreaterOrEqual(key)
+	for node != nil && !node.isLive() {
+		node = node.nextAt(0)
+	}
+	return entryOf(node)
+}
+
+// FloorEntry returns the mapping with the greatest key less than or equal to key
+// Returns false if there is no such key
+func (m *ConcurrentSkipListMap[K, V]) FloorEntry(key K) (common.Entry[K, V], bool) {
+	if node := m.findNode(key); node != nil {
+		return entryOf(node)
+	}
+	return entryOf(m.lowerNode(key))
+}
+
+// ForEachInRange calls f for each mapping with from <= key < to in ascending key order
+// Iteration stops early when f returns false; the traversal is weakly consistent
+func (m *ConcurrentSkipListMap[K, V]) ForEachInRange(from, to K, f func(K, V) bool) {
+	for node := m.findGreaterOrEqual(from); node != nil && m.comparator(node.key, to) < 0; node = node.nextAt(0) {
+		if node.isLive() && !f(node.key, node.load()) {
+			return
+		}
+	}
+}
+
+// find fills preds and succs with the nodes around key at every level
+// Returns the highest level at which a node with key was found, or -1
+func (m *ConcurrentSkipListMap[K, V]) find(key K, preds, succs *[skipListMaxLevel]*skipListMapNode[K, V]) int {
+	found := -1
+	pred := m.head
+	for level := skipListMaxLevel - 1; level >= 0; level-- {
+		curr := pred.nextAt(level)
+		for curr != nil && m.comparator(curr.key, key) < 0 {
+			pred = curr
+			curr = pred.nextAt(level)
+		}
+		if found == -1 && curr != nil && m.comparator(curr.key, key) == 0 {
+			found = level
+		}
+		preds[level] = pred
+		succs[level] = curr
+	}
+	return found
+}
+
+// findNode returns the live node holding key, or nil
+func (m *ConcurrentSkipListMap[K, V]) findNode(key K) *skipListMapNode[K, V] {
+	node := m.findGreaterOrEqual(key)
+	if node != nil && m.comparator(node.key, key) == 0 && node.isLive() {
+		return node
+	}
+	return nil
+}
+
+// findGreaterOrEqual returns the first node at the bottom level whose key is not less than key
+func (m *ConcurrentSkipListMap[K, V]) findGreaterOrEqual(key K) *skipListMapNode[K, V] {
+	pred := m.head
+	var curr *skipListMapNode[K, V]
+	for level := skipListMaxLevel - 1; level >= 0; level-- {
+		curr = pred.nextAt(level)
+		for curr != nil && m.comparator(curr.key, key) < 0 {
+			pred = curr
+			curr = pred.nextAt(level)
+		}
+	}
+	return curr
+}
+
+// lowerNode returns the live node with the greatest key strictly less than key, or nil
+func (m *ConcurrentSkipListMap[K, V]) lowerNode(key K) *skipListMapNode[K, V] {
+	for {
+		pred := m.head
+		for level := skipListMaxLevel - 1; level >= 0; level-- {
+			curr := pred.nextAt(level)
+			for curr != nil && m.comparator(curr.key, key) < 0 {
+				pred = curr
+				curr = pred.nextAt(level)
+			}
+		}
+		if pred == m.head {
+			return nil
+		}
+		if pred.isLive() {
+			return pred
+		}
+		// pred is being inserted or removed; look further left
+		key = pred.key
+	}
+}
+
+// firstNode returns the live node with the least key, or nil
+func (m *ConcurrentSkipListMap[K, V]) firstNode() *skipListMapNode[K, V] {
+	return m.successor(m.head)
+}
+
+// successor returns the next live node after node at the bottom level, or nil
+func (m *ConcurrentSkipListMap[K, V]) successor(node *skipListMapNode[K, V]) *skipListMapNode[K, V] {
+	next := node.nextAt(0)
+	for next != nil && !next.isLive() {
+		next = next.nextAt(0)
+	}
+	return next
+}
+
+// lockPredecessors locks the distinct predecessors of the lowest levels levels and checks
+// that they still precede succs (or victim, when removing) and are not being removed
+// Returns the locked nodes, which the caller must unlock, and whether validation passed
+func (m *ConcurrentSkipListMap[K, V]) lockPredecessors(preds, succs *[skipListMaxLevel]*skipListMapNode[K, V], levels int, victim *skipListMapNode[K, V]) ([]*skipListMapNode[K, V], bool) {
+	locked := make([]*skipListMapNode[K, V], 0, levels)
+	var last *skipListMapNode[K, V]
+	for level := 0; level < levels; level++ {
+		pred := preds[level]
+		if pred != last {
+			pred.mutex.Lock()
+			locked = append(locked, pred)
+			last = pred
+		}
+		expected := succs[level]
+		if victim != nil {
+			expected = victim
+		} else if expected != nil && expected.isMarked() {
+			return locked, false
+		}
+		if pred.isMarked() || pred.nextAt(level) != expected {
+			return locked, false
+		}
+	}
+	return locked, true
+}
+
+// unlockNodes releases the locks taken by lockPredecessors
+func unlockNodes[K comparable, V any](nodes []*skipListMapNode[K, V]) {
+	for _, node := range nodes {
+		node.mutex.Unlock()
+	}
+}
+
+// randomSkipListLevel draws a node height with a geometric distribution
+func randomSkipListLevel() int {
+	level := 1
+	for level < skipListMaxLevel && rand.Float64() < skipListProbability {
+		level++
+	}
+	return level
+}
+
+// entryOf converts a node to an entry, reporting false for nil
+func entryOf[K comparable, V any](node *skipListMapNode[K, V]) (common.Entry[K, V], bool) {
+	if node == nil {
+		return common.Entry[K, V]{}, false
+	}
+	return common.NewEntry(node.key, node.load()), true
+}
+
+// nextAt returns the successor of the node at the given level
+func (n *skipListMapNode[K, V]) nextAt(level int) *skipListMapNode[K, V] {
+	return n.next[level].Load().(*skipListMapNode[K, V])
+}
+
+// load returns the node's current value
+func (n *skipListMapNode[K, V]) load() V {
+	return *n.value.Load().(*V)
+}
+
+// isMarked reports whether the node has been logically removed
+func (n *skipListMapNode[K, V]) isMarked() bool {
+	return atomic.LoadInt32(&n.marked) == 1
+}
+
+// isFullyLinked reports whether the node has been linked at every level
+func (n *skipListMapNode[K, V]) isFullyLinked() bool {
+	return atomic.LoadInt32(&n.fullyLinked) == 1
+}
+
+// isLive reports whether the node is logically present in the map
+func (n *skipListMapNode[K, V]) isLive() bool {
+	return n.isFullyLinked() && !n.isMarked()
+}
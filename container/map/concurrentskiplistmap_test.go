@@ -0,0 +1,217 @@
+package maps
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+)
+
+func TestConcurrentSkipListMapBasicOperations(t *testing.T) {
+	m := NewConcurrentSkipListMap[int, string]()
+	if !m.IsEmpty() {
+		t.Error("New map should be empty")
+	}
+
+	for _, k := range []int{5, 1, 9, 3, 7} {
+		if _, existed := m.Put(k, fmt.Sprintf("v%d", k)); existed {
+			t.Errorf("Key %d should be new", k)
+		}
+	}
+	if old, existed := m.Put(3, "three"); !existed || old != "v3" {
+		t.Errorf("Put should replace v3, got %q, %v", old, existed)
+	}
+	if m.Size() != 5 {
+		t.Errorf("Expected size 5, got %d", m.Size())
+	}
+	if v, ok := m.Get(3); !ok || v != "three" {
+		t.Errorf("Expected three, got %q, %v", v, ok)
+	}
+	if _, ok := m.Get(4); ok {
+		t.Error("Get of a missing key should return false")
+	}
+	if !m.ContainsKey(9) || m.ContainsKey(10) || !m.ContainsValue("v7") || m.ContainsValue("v3") {
+		t.Error("ContainsKey or ContainsValue returned a wrong result")
+	}
+	if fmt.Sprint(m.Keys()) != "[1 3 5 7 9]" {
+		t.Errorf("Keys should be sorted, got %v", m.Keys())
+	}
+	if m.String() != "{1=v1, 3=three, 5=v5, 7=v7, 9=v9}" {
+		t.Errorf("Unexpected String output %s", m.String())
+	}
+
+	if v, existed := m.Remove(5); !existed || v != "v5" {
+		t.Errorf("Remove should return v5, got %q, %v", v, existed)
+	}
+	if _, existed := m.Remove(5); existed {
+		t.Error("Second Remove should return false")
+	}
+	if fmt.Sprint(m.Values()) != "[v1 three v7 v9]" {
+		t.Errorf("Values should follow key order, got %v", m.Values())
+	}
+
+	m.Clear()
+	if !m.IsEmpty() || len(m.Entries()) != 0 {
+		t.Error("Clear should remove every mapping")
+	}
+}
+
+func TestConcurrentSkipListMapNavigation(t *testing.T) {
+	m := NewConcurrentSkipListMap[int, string]()
+	if _, ok := m.FirstEntry(); ok {
+		t.Error("FirstEntry of an empty map should return false")
+	}
+	if _, ok := m.LastEntry(); ok {
+		t.Error("LastEntry of an empty map should return false")
+	}
+
+	for _, k := range []int{10, 20, 30} {
+		m.Put(k, fmt.Sprint(k))
+	}
+	if e, _ := m.FirstEntry(); e.Key != 10 {
+		t.Errorf("FirstEntry should be 10, got %v", e)
+	}
+	if e, _ := m.LastEntry(); e.Key != 30 || e.Value != "30" {
+		t.Errorf("LastEntry should be 30, got %v", e)
+	}
+
+	ceilings := map[int]int{5: 10, 10: 10, 11: 20, 30: 30}
+	for key, expected := range ceilings {
+		if e, ok := m.CeilingEntry(key); !ok || e.Key != expected {
+			t.Errorf("CeilingEntry(%d) should be %d, got %v, %v", key, expected, e, ok)
+		}
+	}
+	floors := map[int]int{10: 10, 19: 10, 20: 20, 100: 30}
+	for key, expected := range floors {
+		if e, ok := m.FloorEntry(key); !ok || e.Key != expected {
+			t.Errorf("FloorEntry(%d) should be %d, got %v, %v", key, expected, e, ok)
+		}
+	}
+	if _, ok := m.CeilingEntry(31); ok {
+		t.Error("CeilingEntry above the last key should return false")
+	}
+	if _, ok := m.FloorEntry(9); ok {
+		t.Error("FloorEntry below the first key should return false")
+	}
+
+	desc := NewConcurrentSkipListMapWithComparator[string, int](func(a, b string) int {
+		if a > b {
+			return -1
+		} else if a < b {
+			return 1
+		}
+		return 0
+	})
+	desc.Put("a", 1)
+	desc.Put("c", 3)
+	desc.Put("b", 2)
+	if fmt.Sprint(desc.Keys()) != "[c b a]" {
+		t.Errorf("Keys should follow the comparator, got %v", desc.Keys())
+	}
+	if e, _ := desc.FirstEntry(); e.Key != "c" {
+		t.Errorf("FirstEntry should follow the comparator, got %v", e)
+	}
+}
+
+func TestConcurrentSkipListMapConcurrentWriters(t *testing.T) {
+	m := NewConcurrentSkipListMap[int, int]()
+	const writers = 8
+	const perWriter = 500
+
+	var wg sync.WaitGroup
+	for w := 0; w < writers; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			for i := 0; i < perWriter; i++ {
+				key := i*writers + w
+				m.Put(key, key)
+				if i%3 == 0 {
+					m.Remove(key)
+				}
+			}
+		}(w)
+	}
+	// Readers run alongside the writers
+	for r := 0; r < 4; r++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < perWriter; i++ {
+				if v, ok := m.Get(i); ok && v != i {
+					t.Errorf("Get(%d) returned %d", i, v)
+				}
+				m.CeilingEntry(i)
+				m.FloorEntry(i)
+			}
+		}()
+	}
+	wg.Wait()
+
+	expected := writers * (perWriter - (perWriter+2)/3)
+	if m.Size() != expected {
+		t.Errorf("Expected size %d, got %d", expected, m.Size())
+	}
+	keys := m.Keys()
+	if len(keys) != expected {
+		t.Errorf("Expected %d keys, got %d", expected, len(keys))
+	}
+	for i := 1; i < len(keys); i++ {
+		if keys[i-1] >= keys[i] {
+			t.Fatalf("Keys are not strictly ascending at %d: %d, %d", i, keys[i-1], keys[i])
+		}
+	}
+}
+
+func TestConcurrentSkipListMapConcurrentSameKeys(t *testing.T) {
+	m := NewConcurrentSkipListMap[int, int]()
+	var wg sync.WaitGroup
+	for w := 0; w < 8; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			for i := 0; i < 1000; i++ {
+				key := i % 16
+				if (i+w)%2 == 0 {
+					m.Put(key, w)
+				} else {
+					m.Remove(key)
+				}
+			}
+		}(w)
+	}
+	wg.Wait()
+
+	if m.Size() != len(m.Keys()) {
+		t.Errorf("Size %d disagrees with the %d reachable keys", m.Size(), len(m.Keys()))
+	}
+}
+
+func TestConcurrentSkipListMapForEachInRange(t *testing.T) {
+	m := NewConcurrentSkipListMap[int, int]()
+	for i := 0; i < 10; i++ {
+		m.Put(i*10, i)
+	}
+
+	var keys []int
+	m.ForEachInRange(15, 50, func(k, _ int) bool {
+		keys = append(keys, k)
+		return true
+	})
+	if fmt.Sprint(keys) != "[20 30 40]" {
+		t.Errorf("Expected [20 30 40], got %v", keys)
+	}
+
+	keys = nil
+	m.ForEachInRange(0, 100, func(k, _ int) bool {
+		keys = append(keys, k)
+		return len(keys) < 2
+	})
+	if fmt.Sprint(keys) != "[0 10]" {
+		t.Errorf("Returning false should stop the scan, got %v", keys)
+	}
+
+	m.ForEachInRange(50, 50, func(int, int) bool {
+		t.Error("An empty range should visit nothing")
+		return true
+	})
+}
@@ -62,6 +62,38 @@ func (m *CopyOnWriteMap[K, V]) Get(key K) (V, bool) {
 	return value, exists
 }
 
+// GetAll returns the values mapped to the given keys, omitting keys that are absent
+// All keys are looked up in the same snapshot
+func (m *CopyOnWriteMap[K, V]) GetAll(keys []K) map[K]V {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	result := make(map[K]V, len(keys))
+	for _, key := range keys {
+		if value, exists := m.data[key]; exists {
+			result[key] = value
+		}
+	}
+	return result
+}
+
+// GetAllOrDefault returns the values mapped to the given keys in key order,
+// using defaultValue for keys that are absent
+func (m *CopyOnWriteMap[K, V]) GetAllOrDefault(keys []K, defaultValue V) []V {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	values := make([]V, len(keys))
+	for i, key := range keys {
+		if value, exists := m.data[key]; exists {
+			values[i] = value
+		} else {
+			values[i] = defaultValue
+		}
+	}
+	return values
+}
+
 // Remove if exists, removes mapping relationship for the key from this map
 func (m *CopyOnWriteMap[K, V]) Remove(key K) (V, bool) {
 	m.mu.Lock()
@@ -84,6 +116,28 @@ func (m *CopyOnWriteMap[K, V]) Remove(key K) (V, bool) {
 	return oldValue, true
 }
 
+// RemoveIfValue removes the mapping for key only if it is currently mapped to expected
+// The check and the removal happen under the write lock; returns true if the mapping was removed
+func (m *CopyOnWriteMap[K, V]) RemoveIfValue(key K, expected V) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	current, exists := m.data[key]
+	if !exists || !common.Equal(current, expected) {
+		return false
+	}
+
+	// Copy-on-write: create a new map copy
+	newData := make(map[K]V, len(m.data)-1)
+	for k, v := range m.data {
+		if k != key {
+			newData[k] = v
+		}
+	}
+	m.data = newData
+	return true
+}
+
 // ContainsKey if this map contains mapping relationship for the specified key, returns true
 func (m *CopyOnWriteMap[K, V]) ContainsKey(key K) bool {
 	m.mu.RLock()
@@ -268,6 +322,8 @@ func (m *CopyOnWriteMap[K, V]) Replace(key K, value V) (V, bool) {
 }
 
 // ReplaceIf replaces the value for the key if it matches the old value
+// The compare and swap happen under the write lock and copy the backing map at most once,
+// only when the swap succeeds, so readers observe either the old or the new snapshot
 func (m *CopyOnWriteMap[K, V]) ReplaceIf(key K, oldValue V, newValue V) bool {
 	m.mu.Lock()
 	defer m.mu.Unlock()
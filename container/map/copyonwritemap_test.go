@@ -379,6 +379,78 @@ func TestCopyOnWriteMapReplaceIf(t *testing.T) {
 	}
 }
 
+func TestCopyOnWriteMapReplaceIfCopiesOnce(t *testing.T) {
+	source := make(map[int]int, 1000)
+	for i := 0; i < 1000; i++ {
+		source[i] = i
+	}
+	m := CopyOnWriteMapFromMap(source)
+
+	cloneAllocs := testing.AllocsPerRun(100, func() {
+		clone := make(map[int]int, len(source))
+		for k, v := range source {
+			clone[k] = v
+		}
+	})
+
+	// A failed compare only pays for the value comparison, never for a copy
+	missAllocs := testing.AllocsPerRun(100, func() {
+		m.ReplaceIf(0, -1, 1)
+	})
+	if missAllocs >= cloneAllocs {
+		t.Errorf("Failed ReplaceIf should not copy the map: %v allocs, one copy costs %v", missAllocs, cloneAllocs)
+	}
+
+	value := 0
+	hitAllocs := testing.AllocsPerRun(100, func() {
+		m.ReplaceIf(0, value, value+1)
+		value++
+	})
+	if hitAllocs > cloneAllocs+missAllocs {
+		t.Errorf("Successful ReplaceIf should copy the map once: %v allocs, one copy costs %v", hitAllocs, cloneAllocs)
+	}
+}
+
+func TestCopyOnWriteMapReplaceIfConcurrentCAS(t *testing.T) {
+	m := NewCopyOnWriteMap[string, int]()
+	m.Put("counter", 0)
+	m.Put("other", 0)
+
+	const goroutines = 8
+	const increments = 200
+	var wg sync.WaitGroup
+	for g := 0; g < goroutines; g++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < increments; i++ {
+				for {
+					current, _ := m.Get("counter")
+					if m.ReplaceIf("counter", current, current+1) {
+						break
+					}
+				}
+			}
+		}()
+	}
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < increments; i++ {
+			m.Replace("other", i)
+			m.Put("extra", i)
+		}
+	}()
+	wg.Wait()
+
+	if val, _ := m.Get("counter"); val != goroutines*increments {
+		t.Errorf("Expected counter %d, got %d", goroutines*increments, val)
+	}
+	if m.Size() != 3 {
+		t.Errorf("Expected size 3, got %d", m.Size())
+	}
+}
+
 // Concurrent tests
 func TestCopyOnWriteMapConcurrentReads(t *testing.T) {
 	m := NewCopyOnWriteMap[string, int]()
@@ -509,6 +581,27 @@ func BenchmarkCopyOnWriteMapPut(b *testing.B) {
 	}
 }
 
+func BenchmarkCopyOnWriteMapReplaceIf(b *testing.B) {
+	m := NewCopyOnWriteMapWithCapacity[int, int](1000)
+	for i := 0; i < 1000; i++ {
+		m.Put(i, i)
+	}
+
+	b.Run("Hit", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			current, _ := m.Get(0)
+			m.ReplaceIf(0, current, current+1)
+		}
+	})
+	b.Run("Miss", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			m.ReplaceIf(0, -1, i)
+		}
+	})
+}
+
 func BenchmarkCopyOnWriteMapMixed(b *testing.B) {
 	m := NewCopyOnWriteMap[string, int]()
 
@@ -621,3 +714,45 @@ func TestCopyOnWriteMapStress(t *testing.T) {
 
 	t.Logf("Stress test completed. Final map size: %d", m.Size())
 }
+
+func TestCopyOnWriteMapRemoveIfValue(t *testing.T) {
+	m := NewCopyOnWriteMap[string, int]()
+	m.Put("key1", 100)
+
+	snapshot := m.Snapshot()
+	if m.RemoveIfValue("key1", 200) {
+		t.Error("Should not have removed with incorrect expected value")
+	}
+	if !m.RemoveIfValue("key1", 100) {
+		t.Error("Should have removed with correct expected value")
+	}
+	if m.ContainsKey("key1") {
+		t.Error("key1 should be removed")
+	}
+	if _, exists := snapshot["key1"]; !exists {
+		t.Error("Earlier snapshot should be unaffected by RemoveIfValue")
+	}
+}
+
+func TestCopyOnWriteMapGetAll(t *testing.T) {
+	m := NewCopyOnWriteMap[string, int]()
+	m.Put("a", 1)
+	m.Put("b", 2)
+	m.Put("c", 3)
+
+	found := m.GetAll([]string{"a", "c", "missing"})
+	if len(found) != 2 || found["a"] != 1 || found["c"] != 3 {
+		t.Errorf("GetAll should return only present keys, got %v", found)
+	}
+	if _, ok := found["missing"]; ok {
+		t.Error("GetAll should omit absent keys")
+	}
+
+	values := m.GetAllOrDefault([]string{"b", "missing", "a"}, -1)
+	if len(values) != 3 || values[0] != 2 || values[1] != -1 || values[2] != 1 {
+		t.Errorf("GetAllOrDefault should return [2 -1 1], got %v", values)
+	}
+	if len(m.GetAll(nil)) != 0 || len(m.GetAllOrDefault(nil, 0)) != 0 {
+		t.Error("Empty batches should return empty results")
+	}
+}
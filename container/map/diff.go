@@ -0,0 +1,41 @@
+package maps
+
+import "github.com/chenjianyu/collections/container/common"
+
+// Diff compares two maps and reports how b differs from a
+// added holds the keys only in b, removed the keys only in a (with a's values), and changed the keys
+// present in both whose values differ according to eq (with b's values)
+// A nil eq falls back to common.Equal; a nil map is treated as empty
+func Diff[K comparable, V any](a, b Map[K, V], eq func(V, V) bool) (added, removed, changed map[K]V) {
+	if eq == nil {
+		eq = func(x, y V) bool {
+			return common.Equal(x, y)
+		}
+	}
+	added = make(map[K]V)
+	removed = make(map[K]V)
+	changed = make(map[K]V)
+
+	if a != nil {
+		a.ForEach(func(k K, va V) {
+			if b == nil {
+				removed[k] = va
+				return
+			}
+			vb, ok := b.Get(k)
+			if !ok {
+				removed[k] = va
+			} else if !eq(va, vb) {
+				changed[k] = vb
+			}
+		})
+	}
+	if b != nil {
+		b.ForEach(func(k K, vb V) {
+			if a == nil || !a.ContainsKey(k) {
+				added[k] = vb
+			}
+		})
+	}
+	return added, removed, changed
+}
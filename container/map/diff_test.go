@@ -0,0 +1,53 @@
+package maps
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestDiff(t *testing.T) {
+	before := NewTreeMap[string, string]()
+	before.Put("host", "localhost")
+	before.Put("port", "80")
+	before.Put("debug", "true")
+
+	after := NewLinkedHashMap[string, string]()
+	after.Put("host", "LOCALHOST")
+	after.Put("port", "8080")
+	after.Put("workers", "4")
+
+	added, removed, changed := Diff[string, string](before, after, nil)
+	if len(added) != 1 || added["workers"] != "4" {
+		t.Errorf("Expected workers to be added, got %v", added)
+	}
+	if len(removed) != 1 || removed["debug"] != "true" {
+		t.Errorf("Expected debug to be removed, got %v", removed)
+	}
+	if len(changed) != 2 || changed["host"] != "LOCALHOST" || changed["port"] != "8080" {
+		t.Errorf("Expected host and port to change to the new values, got %v", changed)
+	}
+
+	_, _, changed = Diff[string, string](before, after, strings.EqualFold)
+	if len(changed) != 1 || changed["port"] != "8080" {
+		t.Errorf("Custom eq should ignore case differences, got %v", changed)
+	}
+}
+
+func TestDiffEmptyAndNil(t *testing.T) {
+	m := NewTreeMap[int, int]()
+	m.Put(1, 1)
+
+	added, removed, changed := Diff[int, int](m, m, nil)
+	if len(added)+len(removed)+len(changed) != 0 {
+		t.Errorf("Diff of a map with itself should be empty, got %v %v %v", added, removed, changed)
+	}
+
+	added, removed, _ = Diff[int, int](nil, m, nil)
+	if len(added) != 1 || len(removed) != 0 {
+		t.Errorf("Nil a should report every key as added, got %v %v", added, removed)
+	}
+	added, removed, _ = Diff[int, int](m, nil, nil)
+	if len(added) != 0 || len(removed) != 1 {
+		t.Errorf("Nil b should report every key as removed, got %v %v", added, removed)
+	}
+}
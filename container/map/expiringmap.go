@@ -0,0 +1,281 @@
+package maps
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/chenjianyu/collections/container/common"
+)
+
+// ExpiringMap is a Map whose entries expire a fixed time after they were last put
+// Expired entries are removed lazily when they are accessed, or eagerly by Sweep
+// Read operations such as Size, Keys and ForEach skip expired entries without removing them
+type ExpiringMap[K comparable, V any] struct {
+	entries  map[K]*expiringEntry[V]
+	ttl      time.Duration
+	listener func(K, V)
+	now      func() time.Time
+	mutex    sync.RWMutex
+}
+
+// expiringEntry is a stored value with its expiration deadline
+type expiringEntry[V any] struct {
+	value     V
+	expiresAt time.Time // Zero means the entry never expires
+}
+
+// NewExpiringMap creates an ExpiringMap whose entries live for ttl after each Put
+// A non-positive ttl means entries never expire unless put with PutWithTTL
+func NewExpiringMap[K comparable, V any](ttl time.Duration) *ExpiringMap[K, V] {
+	if ttl < 0 {
+		ttl = 0
+	}
+	return &ExpiringMap[K, V]{
+		entries: make(map[K]*expiringEntry[V]),
+		ttl:     ttl,
+		now:     time.Now,
+	}
+}
+
+// TTL returns the default time to live applied by Put
+func (m *ExpiringMap[K, V]) TTL() time.Duration {
+	return m.ttl
+}
+
+// SetExpirationListener registers fn to be called with each entry that expires,
+// whether it is found expired on access or removed by Sweep; nil removes the listener
+// The listener runs after the map's lock is released, so it may call back into the map
+func (m *ExpiringMap[K, V]) SetExpirationListener(fn func(K, V)) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	m.listener = fn
+}
+
+// Put associates the specified value with the specified key using the default TTL
+func (m *ExpiringMap[K, V]) Put(key K, value V) (V, bool) {
+	return m.PutWithTTL(key, value, m.ttl)
+}
+
+// PutWithTTL associates the specified value with the specified key for the given duration
+// A non-positive ttl stores the entry without expiration
+// An expired previous value is reported to the listener and not returned
+func (m *ExpiringMap[K, V]) PutWithTTL(key K, value V, ttl time.Duration) (V, bool) {
+	m.mutex.Lock()
+	now := m.now()
+	oldValue, existed, expired := m.take(key, now)
+	entry := &expiringEntry[V]{value: value}
+	if ttl > 0 {
+		entry.expiresAt = now.Add(ttl)
+	}
+	m.entries[key] = entry
+	listener := m.listener
+	m.mutex.Unlock()
+
+	if expired {
+		notifyExpired(listener, key, oldValue)
+		var zero V
+		return zero, false
+	}
+	return oldValue, existed
+}
+
+// Get returns the value mapped to the specified key if it has not expired
+// An expired entry is removed and reported to the listener
+func (m *ExpiringMap[K, V]) Get(key K) (V, bool) {
+	m.mutex.RLock()
+	entry, exists := m.entries[key]
+	if exists && !entry.expired(m.now()) {
+		value := entry.value
+		m.mutex.RUnlock()
+		return value, true
+	}
+	m.mutex.RUnlock()
+
+	var zero V
+	if exists {
+		m.expireKey(key)
+	}
+	return zero, false
+}
+
+// Remove removes the mapping for the specified key
+// An entry that has already expired is reported to the listener and not returned
+func (m *ExpiringMap[K, V]) Remove(key K) (V, bool) {
+	m.mutex.Lock()
+	value, existed, expired := m.take(key, m.now())
+	listener := m.listener
+	m.mutex.Unlock()
+
+	if expired {
+		notifyExpired(listener, key, value)
+		var zero V
+		return zero, false
+	}
+	return value, existed
+}
+
+// Sweep removes every expired entry, reports each one to the listener and returns how many were removed
+func (m *ExpiringMap[K, V]) Sweep() int {
+	m.mutex.Lock()
+	now := m.now()
+	var expired []common.Entry[K, V]
+	for key, entry := range m.entries {
+		if entry.expired(now) {
+			expired = append(expired, common.NewEntry(key, entry.value))
+			delete(m.entries, key)
+		}
+	}
+	listener := m.listener
+	m.mutex.Unlock()
+
+	for _, entry := range expired {
+		notifyExpired(listener, entry.Key, entry.Value)
+	}
+	return len(expired)
+}
+
+// ContainsKey returns true if the key is mapped to a value that has not expired
+func (m *ExpiringMap[K, V]) ContainsKey(key K) bool {
+	_, ok := m.Get(key)
+	return ok
+}
+
+// ContainsValue returns true if some unexpired entry holds the specified value
+func (m *ExpiringMap[K, V]) ContainsValue(value V) bool {
+	found := false
+	m.ForEach(func(_ K, v V) {
+		if !found && common.Equal(v, value) {
+			found = true
+		}
+	})
+	return found
+}
+
+// Size returns the number of unexpired entries
+func (m *ExpiringMap[K, V]) Size() int {
+	size := 0
+	m.ForEach(func(K, V) {
+		size++
+	})
+	return size
+}
+
+// IsEmpty returns true if the map holds no unexpired entries
+func (m *ExpiringMap[K, V]) IsEmpty() bool {
+	return m.Size() == 0
+}
+
+// Clear removes all entries without reporting them to the listener
+func (m *ExpiringMap[K, V]) Clear() {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	m.entries = make(map[K]*expiringEntry[V])
+}
+
+// Keys returns the keys of the unexpired entries
+func (m *ExpiringMap[K, V]) Keys() []K {
+	keys := make([]K, 0)
+	m.ForEach(func(k K, _ V) {
+		keys = append(keys, k)
+	})
+	return keys
+}
+
+// Values returns the values of the unexpired entries
+func (m *ExpiringMap[K, V]) Values() []V {
+	values := make([]V, 0)
+	m.ForEach(func(_ K, v V) {
+		values = append(values, v)
+	})
+	return values
+}
+
+// Entries returns the unexpired entries
+func (m *ExpiringMap[K, V]) Entries() []common.Entry[K, V] {
+	entries := make([]common.Entry[K, V], 0)
+	m.ForEach(func(k K, v V) {
+		entries = append(entries, common.NewEntry(k, v))
+	})
+	return entries
+}
+
+// ForEach executes the given operation for each unexpired entry
+func (m *ExpiringMap[K, V]) ForEach(f func(K, V)) {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	now := m.now()
+	for key, entry := range m.entries {
+		if !entry.expired(now) {
+			f(key, entry.value)
+		}
+	}
+}
+
+// PutAll copies all mappings from the specified map using the default TTL
+func (m *ExpiringMap[K, V]) PutAll(other Map[K, V]) {
+	other.ForEach(func(k K, v V) {
+		m.Put(k, v)
+	})
+}
+
+// String returns the string representation of the unexpired entries
+func (m *ExpiringMap[K, V]) String() string {
+	entries := m.Entries()
+	if len(entries) == 0 {
+		return "{}"
+	}
+
+	var builder strings.Builder
+	builder.WriteString("{")
+	for i, entry := range entries {
+		if i > 0 {
+			builder.WriteString(", ")
+		}
+		builder.WriteString(fmt.Sprintf("%v=%v", entry.Key, entry.Value))
+	}
+	builder.WriteString("}")
+	return builder.String()
+}
+
+// take removes key and reports its value, whether it existed and whether it had expired
+// The caller must hold the write lock
+func (m *ExpiringMap[K, V]) take(key K, now time.Time) (V, bool, bool) {
+	entry, exists := m.entries[key]
+	if !exists {
+		var zero V
+		return zero, false, false
+	}
+	delete(m.entries, key)
+	return entry.value, true, entry.expired(now)
+}
+
+// expireKey removes key if it is still expired and reports it to the listener
+func (m *ExpiringMap[K, V]) expireKey(key K) {
+	m.mutex.Lock()
+	entry, exists := m.entries[key]
+	if !exists || !entry.expired(m.now()) {
+		// Another goroutine already expired or replaced the entry
+		m.mutex.Unlock()
+		return
+	}
+	delete(m.entries, key)
+	listener := m.listener
+	m.mutex.Unlock()
+
+	notifyExpired(listener, key, entry.value)
+}
+
+// expired reports whether the entry's deadline has passed at now
+func (e *expiringEntry[V]) expired(now time.Time) bool {
+	return !e.expiresAt.IsZero() && !now.Before(e.expiresAt)
+}
+
+// notifyExpired calls listener if one is registered
+func notifyExpired[K comparable, V any](listener func(K, V), key K, value V) {
+	if listener != nil {
+		listener(key, value)
+	}
+}
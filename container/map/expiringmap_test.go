@@ -0,0 +1,165 @@
+package maps
+
+import (
+	"sort"
+	"sync"
+	"testing"
+	"time"
+)
+
+// newTestExpiringMap returns an ExpiringMap driven by a manually advanced clock
+func newTestExpiringMap(ttl time.Duration) (*ExpiringMap[string, int], func(time.Duration)) {
+	current := time.Unix(1000, 0)
+	m := NewExpiringMap[string, int](ttl)
+	m.now = func() time.Time { return current }
+	return m, func(d time.Duration) { current = current.Add(d) }
+}
+
+func TestExpiringMapBasicOperations(t *testing.T) {
+	m, advance := newTestExpiringMap(time.Minute)
+
+	if _, existed := m.Put("a", 1); existed {
+		t.Error("First Put should not report a previous value")
+	}
+	m.PutWithTTL("b", 2, 3*time.Minute)
+	m.PutWithTTL("forever", 3, 0)
+
+	if v, ok := m.Get("a"); !ok || v != 1 {
+		t.Errorf("Expected a=1, got %d, %v", v, ok)
+	}
+	if m.Size() != 3 {
+		t.Errorf("Expected size 3, got %d", m.Size())
+	}
+
+	advance(2 * time.Minute)
+	if m.ContainsKey("a") {
+		t.Error("a should have expired")
+	}
+	keys := m.Keys()
+	sort.Strings(keys)
+	if len(keys) != 2 || keys[0] != "b" || keys[1] != "forever" {
+		t.Errorf("Expected keys [b forever], got %v", keys)
+	}
+
+	// Put refreshes the deadline
+	m.Put("b", 20)
+	advance(30 * time.Second)
+	if v, ok := m.Get("b"); !ok || v != 20 {
+		t.Errorf("Refreshed b should still be present, got %d, %v", v, ok)
+	}
+
+	advance(time.Hour)
+	if m.Size() != 1 || !m.ContainsValue(3) {
+		t.Errorf("Only the entry without TTL should remain, got %v", m)
+	}
+	if v, existed := m.Remove("forever"); !existed || v != 3 {
+		t.Errorf("Expected to remove forever=3, got %d, %v", v, existed)
+	}
+	if !m.IsEmpty() {
+		t.Error("Map should be empty")
+	}
+}
+
+func TestExpiringMapExpirationListener(t *testing.T) {
+	m, advance := newTestExpiringMap(time.Minute)
+	expired := make(map[string]int)
+	m.SetExpirationListener(func(k string, v int) {
+		expired[k] = v
+	})
+
+	m.Put("lazy", 1)
+	m.Put("swept", 2)
+	m.Put("replaced", 3)
+	m.Put("removed", 4)
+	m.PutWithTTL("alive", 5, time.Hour)
+
+	advance(time.Minute)
+
+	// Lazy expiration on Get
+	if _, ok := m.Get("lazy"); ok {
+		t.Error("lazy should have expired")
+	}
+	if v, ok := expired["lazy"]; !ok || v != 1 {
+		t.Error("Get should report the expired entry to the listener")
+	}
+	if _, ok := m.Get("lazy"); ok || len(expired) != 1 {
+		t.Error("An expired entry should be reported only once")
+	}
+
+	// Put and Remove over an expired entry report it instead of returning it
+	if _, existed := m.Put("replaced", 30); existed || expired["replaced"] != 3 {
+		t.Error("Put over an expired entry should report it to the listener")
+	}
+	if _, existed := m.Remove("removed"); existed || expired["removed"] != 4 {
+		t.Error("Remove of an expired entry should report it to the listener")
+	}
+
+	// Sweep reports the rest
+	if n := m.Sweep(); n != 1 {
+		t.Errorf("Expected Sweep to remove 1 entry, got %d", n)
+	}
+	if expired["swept"] != 2 {
+		t.Error("Sweep should report swept entries to the listener")
+	}
+	if _, ok := expired["alive"]; ok {
+		t.Error("Unexpired entries should not be reported")
+	}
+	if m.Size() != 2 {
+		t.Errorf("Expected alive and replaced to remain, got %v", m)
+	}
+
+	// A never-present key is not reported
+	m.Get("missing")
+	if _, ok := expired["missing"]; ok {
+		t.Error("Missing keys should not be reported")
+	}
+}
+
+func TestExpiringMapListenerMayReenter(t *testing.T) {
+	m, advance := newTestExpiringMap(time.Second)
+	m.SetExpirationListener(func(k string, v int) {
+		m.Put(k+"-archived", v)
+	})
+	m.Put("a", 1)
+	advance(time.Second)
+	m.Sweep()
+	if v, ok := m.Get("a-archived"); !ok || v != 1 {
+		t.Error("Listener should be able to write back into the map")
+	}
+}
+
+func TestExpiringMapConcurrentAccess(t *testing.T) {
+	m := NewExpiringMap[int, int](time.Millisecond)
+	var mu sync.Mutex
+	reported := 0
+	m.SetExpirationListener(func(int, int) {
+		mu.Lock()
+		reported++
+		mu.Unlock()
+	})
+
+	var wg sync.WaitGroup
+	for g := 0; g < 4; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			for i := 0; i < 200; i++ {
+				m.Put(g*1000+i, i)
+				m.Get(g*1000 + i/2)
+				if i%50 == 0 {
+					m.Sweep()
+				}
+			}
+		}(g)
+	}
+	wg.Wait()
+	time.Sleep(2 * time.Millisecond)
+	m.Sweep()
+
+	if !m.IsEmpty() {
+		t.Errorf("All entries should have expired, %d left", m.Size())
+	}
+	if reported != 800 {
+		t.Errorf("Each entry should be reported exactly once, got %d", reported)
+	}
+}
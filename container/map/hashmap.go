@@ -2,6 +2,7 @@ package maps
 
 import (
     "fmt"
+    "math"
     "strings"
     "sync"
     "github.com/chenjianyu/collections/container/common"
@@ -22,8 +23,11 @@ const minTreeifyCapacity = 64
 // Initial hash table size
 const initialCapacity = 16
 
-// Load factor, expand when element count exceeds capacity multiplied by load factor
-const loadFactor = 0.75
+// Default load factor, expand when element count exceeds capacity multiplied by load factor
+const defaultLoadFactor = 0.75
+
+// Largest accepted load factor, so capacity multiplied by load factor cannot overflow the threshold
+const maxLoadFactor = 64
 
 // LinkedHashMapNode is a linked list/red-black tree node
 type LinkedHashMapNode[K comparable, V any] struct {
@@ -49,6 +53,7 @@ type LinkedHashMap[K comparable, V any] struct {
     table     []*LinkedHashMapNode[K, V] // Hash bucket array
     size      int                        // Element count
     threshold int                        // Resize threshold
+    loadFactor float64                   // Ratio of size to capacity that triggers a resize
     mutex     sync.RWMutex               // Read-write lock for thread safety
     hashStrategy common.HashStrategy[K]  // Pluggable hash/equality strategy
 }
@@ -59,11 +64,27 @@ func NewLinkedHashMap[K comparable, V any]() *LinkedHashMap[K, V] {
     return &LinkedHashMap[K, V]{
         table:     make([]*LinkedHashMapNode[K, V], capacity),
         size:      0,
-        threshold: int(float64(capacity) * loadFactor),
+        threshold: int(float64(capacity) * defaultLoadFactor),
+        loadFactor: defaultLoadFactor,
         hashStrategy: common.NewComparableHashStrategy[K](),
     }
 }
 
+// FromIterator creates a new LinkedHashMap from the remaining entries of it
+// Later entries with the same key overwrite earlier ones; a nil iterator yields an empty map
+func FromIterator[K comparable, V any](it common.Iterator[common.Entry[K, V]]) *LinkedHashMap[K, V] {
+	m := NewLinkedHashMap[K, V]()
+	if it == nil {
+		return m
+	}
+	for it.HasNext() {
+		if entry, ok := it.Next(); ok {
+			m.Put(entry.Key, entry.Value)
+		}
+	}
+	return m
+}
+
 // NewLinkedHashMapWithCapacity creates a LinkedHashMap with specified initial capacity
 func NewLinkedHashMapWithCapacity[K comparable, V any](capacity int) *LinkedHashMap[K, V] {
 	if capacity < initialCapacity {
@@ -76,7 +97,8 @@ func NewLinkedHashMapWithCapacity[K comparable, V any](capacity int) *LinkedHash
     return &LinkedHashMap[K, V]{
         table:     make([]*LinkedHashMapNode[K, V], capacity),
         size:      0,
-        threshold: int(float64(capacity) * loadFactor),
+        threshold: int(float64(capacity) * defaultLoadFactor),
+        loadFactor: defaultLoadFactor,
         hashStrategy: common.NewComparableHashStrategy[K](),
     }
 }
@@ -86,7 +108,8 @@ func NewLinkedHashMapWithHashStrategy[K comparable, V any](strategy common.HashS
     return &LinkedHashMap[K, V]{
         table:        make([]*LinkedHashMapNode[K, V], capacity),
         size:         0,
-        threshold:    int(float64(capacity) * loadFactor),
+        threshold:    int(float64(capacity) * defaultLoadFactor),
+        loadFactor:   defaultLoadFactor,
         hashStrategy: strategy,
     }
 }
@@ -101,11 +124,48 @@ func NewLinkedHashMapWithCapacityAndHashStrategy[K comparable, V any](capacity i
     return &LinkedHashMap[K, V]{
         table:        make([]*LinkedHashMapNode[K, V], capacity),
         size:         0,
-        threshold:    int(float64(capacity) * loadFactor),
+        threshold:    int(float64(capacity) * defaultLoadFactor),
+        loadFactor:   defaultLoadFactor,
         hashStrategy: strategy,
     }
 }
 
+// NewLinkedHashMapWithLoadFactor creates a LinkedHashMap with specified initial capacity and load factor
+// A higher load factor saves memory at the cost of longer bucket chains; a lower one trades
+// memory for fewer collisions. A non-positive or non-finite load factor is treated as the
+// default 0.75, and one above 64 is clamped to 64
+func NewLinkedHashMapWithLoadFactor[K comparable, V any](capacity int, lf float64) *LinkedHashMap[K, V] {
+	if capacity < initialCapacity {
+		capacity = initialCapacity
+	} else {
+		capacity = tableSizeFor(capacity)
+	}
+	if !(lf > 0) || math.IsInf(lf, 1) {
+		lf = defaultLoadFactor
+	} else if lf > maxLoadFactor {
+		lf = maxLoadFactor
+	}
+
+	return &LinkedHashMap[K, V]{
+		table:        make([]*LinkedHashMapNode[K, V], capacity),
+		threshold:    int(float64(capacity) * lf),
+		loadFactor:   lf,
+		hashStrategy: common.NewComparableHashStrategy[K](),
+	}
+}
+
+// LoadFactor returns the load factor used to decide when the table grows
+func (m *LinkedHashMap[K, V]) LoadFactor() float64 {
+	return m.loadFactor
+}
+
+// Capacity returns the current number of buckets in the hash table
+func (m *LinkedHashMap[K, V]) Capacity() int {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+	return len(m.table)
+}
+
 // tableSizeFor returns the smallest power of 2 greater than or equal to cap
 func tableSizeFor(cap int) int {
 	n := cap - 1
@@ -196,76 +256,72 @@ func (m *LinkedHashMap[K, V]) Put(key K, value V) (V, bool) {
 
 // putTreeVal inserts or updates node in red-black tree
 func (m *LinkedHashMap[K, V]) putTreeVal(index int, key K, value V, hash uint64) (V, bool) {
-	var oldValue V
-	existed := false
-
 	root := m.table[index]
-	p := root
 
-	// Tree search
-	for p != nil {
-		cmp := 0
-		if p.hash > hash {
-			cmp = -1
-		} else if p.hash < hash {
-			cmp = 1
-        } else if m.hashStrategy.Equals(key, p.key) {
-            // Found same key, update value
-            oldValue = p.value
-            p.value = value
-            return oldValue, true
-        } else {
-            // Same hash but different key, use key comparison
-            cmp = common.CompareGeneric(key, p.key)
-        }
-
-		// Decide left or right based on comparison result
-		if cmp < 0 {
-			if p.left == nil {
-				// Insert as left child
-				p.left = &LinkedHashMapNode[K, V]{
-					key:        key,
-					value:      value,
-					hash:       hash,
-					isTreeNode: true,
-					parent:     p,
-					color:      red,
-				}
-				m.size++
-				m.balanceInsertion(root, p.left)
-				return oldValue, existed
-			}
-			p = p.left
-		} else {
-			if p.right == nil {
-				// Insert as right child
-				p.right = &LinkedHashMapNode[K, V]{
-					key:        key,
-					value:      value,
-					hash:       hash,
-					isTreeNode: true,
-					parent:     p,
-					color:      red,
-				}
-				m.size++
-				m.balanceInsertion(root, p.right)
-				return oldValue, existed
-			}
-			p = p.right
-		}
+	// Look the key up first; keys without a usable order may sit in either subtree
+	if p := m.findTreeNode(root, key, hash); p != nil {
+		oldValue := p.value
+		p.value = value
+		return oldValue, true
 	}
 
-	// If tree is empty, create root node
-	m.table[index] = &LinkedHashMapNode[K, V]{
+	node := &LinkedHashMapNode[K, V]{
 		key:        key,
 		value:      value,
 		hash:       hash,
 		isTreeNode: true,
-		color:      black, // Root node is black
 	}
 	m.size++
 
-	return oldValue, existed
+	// If tree is empty, create root node
+	if root == nil {
+		node.color = black // Root node is black
+		m.table[index] = node
+		return common.ZeroValue[V](), false
+	}
+
+	m.insertNodeSimple(&root, node)
+	m.table[index] = m.balanceInsertion(root, node)
+	return common.ZeroValue[V](), false
+}
+
+// compareTreeKeys orders two unequal keys that share a hash inside a tree bin
+// It returns 0 when K has no natural order consistent with the hash strategy,
+// in which case lookups must search both subtrees of a node with the same hash
+func (m *LinkedHashMap[K, V]) compareTreeKeys(a, b K) int {
+	if _, ok := m.hashStrategy.(*common.ComparableHashStrategy[K]); !ok {
+		return 0
+	}
+	switch any(a).(type) {
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64, float32, float64, string:
+		return common.CompareGeneric(a, b)
+	default:
+		return 0
+	}
+}
+
+// findTreeNode returns the node holding key in the tree rooted at p, or nil
+func (m *LinkedHashMap[K, V]) findTreeNode(p *LinkedHashMapNode[K, V], key K, hash uint64) *LinkedHashMapNode[K, V] {
+	for p != nil {
+		if hash < p.hash {
+			p = p.left
+		} else if hash > p.hash {
+			p = p.right
+		} else if m.hashStrategy.Equals(key, p.key) {
+			return p
+		} else if cmp := m.compareTreeKeys(key, p.key); cmp < 0 {
+			p = p.left
+		} else if cmp > 0 {
+			p = p.right
+		} else {
+			// No usable order: the key may be on either side
+			if q := m.findTreeNode(p.right, key, hash); q != nil {
+				return q
+			}
+			p = p.left
+		}
+	}
+	return nil
 }
 
 // balanceInsertion after insertion
@@ -455,11 +511,9 @@ func (m *LinkedHashMap[K, V]) buildTree(head *LinkedHashMapNode[K, V]) *LinkedHa
 					dir = -1
 				} else if h > ph {
 					dir = 1
-                } else if m.hashStrategy.Equals(k, pk) {
-                    dir = 0
-                } else {
-                    dir = common.CompareGeneric(k, pk)
-                }
+				} else {
+					dir = m.compareTreeKeys(k, pk)
+				}
 
 				if dir < 0 {
 					if cur.left == nil {
@@ -510,7 +564,7 @@ func (m *LinkedHashMap[K, V]) resize() {
 	// Create new table
 	newTab := make([]*LinkedHashMapNode[K, V], newCap)
 	m.table = newTab
-	m.threshold = int(float64(newCap) * loadFactor)
+	m.threshold = int(float64(newCap) * m.loadFactor)
 
 	// If old table is empty, directly return
 	if oldCap == 0 {
@@ -730,9 +784,9 @@ func (m *LinkedHashMap[K, V]) insertNodeSimple(root **LinkedHashMapNode[K, V], n
 			cmp = -1
 		} else if node.hash > p.hash {
 			cmp = 1
-        } else {
-            cmp = common.CompareGeneric(node.key, p.key)
-        }
+		} else {
+			cmp = m.compareTreeKeys(node.key, p.key)
+		}
 
 		if cmp < 0 {
 			if p.left == nil {
@@ -759,6 +813,43 @@ func (m *LinkedHashMap[K, V]) Get(key K) (V, bool) {
 	m.mutex.RLock()
 	defer m.mutex.RUnlock()
 
+	return m.get(key)
+}
+
+// GetAll returns the values mapped to the given keys, omitting keys that are absent
+// The lock is acquired once for the whole batch
+func (m *LinkedHashMap[K, V]) GetAll(keys []K) map[K]V {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	result := make(map[K]V, len(keys))
+	for _, key := range keys {
+		if value, exists := m.get(key); exists {
+			result[key] = value
+		}
+	}
+	return result
+}
+
+// GetAllOrDefault returns the values mapped to the given keys in key order,
+// using defaultValue for keys that are absent
+func (m *LinkedHashMap[K, V]) GetAllOrDefault(keys []K, defaultValue V) []V {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	values := make([]V, len(keys))
+	for i, key := range keys {
+		if value, exists := m.get(key); exists {
+			values[i] = value
+		} else {
+			values[i] = defaultValue
+		}
+	}
+	return values
+}
+
+// get looks up the value for key; the caller must hold the lock
+func (m *LinkedHashMap[K, V]) get(key K) (V, bool) {
 	hashValue := m.hash(key)
 	index := int(hashValue % uint64(len(m.table)))
 
@@ -786,32 +877,10 @@ func (m *LinkedHashMap[K, V]) Get(key K) (V, bool) {
 
 // getTreeVal search node in red-black tree
 func (m *LinkedHashMap[K, V]) getTreeVal(root *LinkedHashMapNode[K, V], key K, hash uint64) (V, bool) {
-	p := root
-
-	// Tree search
-	for p != nil {
-		cmp := 0
-		if p.hash > hash {
-			cmp = -1
-		} else if p.hash < hash {
-			cmp = 1
-        } else if m.hashStrategy.Equals(key, p.key) {
-            // Find same key, return value
-            return p.value, true
-        } else {
-            // Hash same but different key, use key comparison
-            cmp = common.CompareGeneric(key, p.key)
-        }
-
-		// Decide left or right based on comparison result
-		if cmp < 0 {
-			p = p.left
-		} else {
-			p = p.right
-		}
+	if p := m.findTreeNode(root, key, hash); p != nil {
+		return p.value, true
 	}
-
-    return common.ZeroValue[V](), false
+	return common.ZeroValue[V](), false
 }
 
 // Remove if exists, removes mapping relationship for the key
@@ -819,6 +888,25 @@ func (m *LinkedHashMap[K, V]) Remove(key K) (V, bool) {
 	m.mutex.Lock()
 	defer m.mutex.Unlock()
 
+	return m.remove(key)
+}
+
+// RemoveIfValue removes the mapping for key only if it is currently mapped to expected
+// Returns true if the mapping was removed
+func (m *LinkedHashMap[K, V]) RemoveIfValue(key K, expected V) bool {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	current, exists := m.get(key)
+	if !exists || !common.Equal(current, expected) {
+		return false
+	}
+	m.remove(key)
+	return true
+}
+
+// remove deletes the mapping for key; the caller must hold the write lock
+func (m *LinkedHashMap[K, V]) remove(key K) (V, bool) {
 	hashValue := m.hash(key)
 	index := int(hashValue % uint64(len(m.table)))
 
@@ -863,90 +951,67 @@ func (m *LinkedHashMap[K, V]) Remove(key K) (V, bool) {
 // removeTreeNode remove node from red-black tree
 func (m *LinkedHashMap[K, V]) removeTreeNode(index int, key K, hash uint64) (V, bool) {
 	root := m.table[index]
-	p := root
 
 	// Find node to delete
-	for p != nil {
-		cmp := 0
-		if p.hash > hash {
-			cmp = -1
-		} else if p.hash < hash {
-			cmp = 1
-        } else if m.hashStrategy.Equals(key, p.key) {
-            // Find node to delete
-            break
-        } else {
-            // Hash same but different key, use key comparison
-            cmp = common.CompareGeneric(key, p.key)
-        }
-
-		// Decide left or right based on comparison result
-		if cmp < 0 {
-			p = p.left
-		} else {
-			p = p.right
-		}
+	p := m.findTreeNode(root, key, hash)
+	if p == nil {
+		return common.ZeroValue[V](), false
 	}
 
-	// If node is not found, return zero value
-    if p == nil {
-        return common.ZeroValue[V](), false
-    }
-
 	oldValue := p.value
 
-	// Delete node
+	// If node has two children, move the successor into it and delete the successor instead
 	if p.left != nil && p.right != nil {
-		// If node has two children, find successor
 		s := p.right
 		for s.left != nil {
 			s = s.left
 		}
-
-		// Replace current node value with successor value
 		p.key = s.key
 		p.value = s.value
 		p.hash = s.hash
+		p = s
+	}
 
-		// Delete successor
-		if s.parent == p {
-			p.right = s.right
-			if s.right != nil {
-				s.right.parent = p
-			}
-		} else {
-			s.parent.left = s.right
-			if s.right != nil {
-				s.right.parent = s.parent
-			}
-		}
-	} else {
-		// If node has at most one child
-		replacement := p.left
-		if p.left == nil {
-			replacement = p.right
-		}
+	// p now has at most one child
+	replacement := p.left
+	if replacement == nil {
+		replacement = p.right
+	}
 
-		// Replace node with child node
+	if replacement != nil {
+		// Splice the child into p's place, then rebalance from it
+		replacement.parent = p.parent
 		if p.parent == nil {
-			// If it's a root node
-			m.table[index] = replacement
+			root = replacement
 		} else if p == p.parent.left {
 			p.parent.left = replacement
 		} else {
 			p.parent.right = replacement
 		}
+		p.left, p.right, p.parent = nil, nil, nil
 
-		if replacement != nil {
-			replacement.parent = p.parent
+		if p.color == black {
+			root = m.balanceDeletion(root, replacement)
+		}
+	} else if p.parent == nil {
+		// p was the only node
+		root = nil
+	} else {
+		// Rebalance while the leaf is still attached, then unlink it
+		if p.color == black {
+			root = m.balanceDeletion(root, p)
+		}
+		if p.parent != nil {
+			if p == p.parent.left {
+				p.parent.left = nil
+			} else if p == p.parent.right {
+				p.parent.right = nil
+			}
+			p.parent = nil
 		}
 	}
 
-	// Balance red-black tree
-	if p.color == black {
-		m.balanceDeletion(root, p)
-	}
-
+	m.table[index] = root
 	m.size--
 
 	// If tree is too small, convert to list
@@ -1245,6 +1310,23 @@ func (m *LinkedHashMap[K, V]) String() string {
 	return sb.String()
 }
 
+// StringN returns a representation like String that shows at most n mappings
+// The remaining mappings are summarised as "… (+k more)"
+func (m *LinkedHashMap[K, V]) StringN(n int) string {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	b := common.NewLimitedStringBuilder("{", n)
+	m.traverseAllWithEarlyExit(func(node *LinkedHashMapNode[K, V]) bool {
+		if b.Full() {
+			return true
+		}
+		b.Addf("%v=%v", node.key, node.value)
+		return false
+	})
+	return b.Finish("}", m.size)
+}
+
 // Entries returns the mapping relationships contained in this mapping
 func (m *LinkedHashMap[K, V]) Entries() []common.Entry[K, V] {
     m.mutex.RLock()
@@ -1257,3 +1339,47 @@ func (m *LinkedHashMap[K, V]) Entries() []common.Entry[K, V] {
 
     return entries
 }
+
+// MarshalBinary encodes the mappings using common.EncodeEntries
+func (m *LinkedHashMap[K, V]) MarshalBinary() ([]byte, error) {
+	return common.EncodeEntries(m.Entries())
+}
+
+// UnmarshalBinary replaces the map contents with the mappings decoded from data
+// The map keeps its hash strategy and load factor; a zero-value LinkedHashMap uses the defaults
+func (m *LinkedHashMap[K, V]) UnmarshalBinary(data []byte) error {
+	entries, err := common.DecodeEntries[K, V](data)
+	if err != nil {
+		return err
+	}
+
+	m.mutex.RLock()
+	loadFactor, strategy := m.loadFactor, m.hashStrategy
+	m.mutex.RUnlock()
+	if loadFactor == 0 {
+		loadFactor = defaultLoadFactor
+	}
+	if strategy == nil {
+		strategy = common.NewComparableHashStrategy[K]()
+	}
+
+	// Build the decoded table aside so readers never observe a partially loaded map
+	decoded := &LinkedHashMap[K, V]{
+		table:        make([]*LinkedHashMapNode[K, V], initialCapacity),
+		threshold:    int(float64(initialCapacity) * loadFactor),
+		loadFactor:   loadFactor,
+		hashStrategy: strategy,
+	}
+	for _, entry := range entries {
+		decoded.Put(entry.Key, entry.Value)
+	}
+
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	m.table = decoded.table
+	m.size = decoded.size
+	m.threshold = decoded.threshold
+	m.loadFactor = loadFactor
+	m.hashStrategy = strategy
+	return nil
+}
@@ -2,7 +2,11 @@ package maps
 
 import (
 	"fmt"
+	"math"
+	"strings"
 	"testing"
+
+	"github.com/chenjianyu/collections/container/common"
 )
 
 func TestLinkedHashMapBasicOperations(t *testing.T) {
@@ -334,3 +338,268 @@ func TestLinkedHashMapUpdateExistingKey(t *testing.T) {
 		t.Errorf("Size() = %v; want 1", size)
 	}
 }
+
+func TestLinkedHashMapRemoveIfValue(t *testing.T) {
+	m := NewLinkedHashMap[string, int]()
+	m.Put("one", 1)
+	m.Put("two", 2)
+
+	if m.RemoveIfValue("one", 2) {
+		t.Error("Should not have removed with incorrect expected value")
+	}
+	if !m.RemoveIfValue("one", 1) {
+		t.Error("Should have removed with correct expected value")
+	}
+	if m.ContainsKey("one") || m.Size() != 1 {
+		t.Error("one should be removed and size should be 1")
+	}
+	if m.RemoveIfValue("three", 3) {
+		t.Error("Should not have removed non-existing key")
+	}
+}
+
+func TestLinkedHashMapGetAll(t *testing.T) {
+	m := NewLinkedHashMap[string, int]()
+	m.Put("a", 1)
+	m.Put("b", 2)
+	m.Put("c", 3)
+
+	found := m.GetAll([]string{"a", "c", "missing"})
+	if len(found) != 2 || found["a"] != 1 || found["c"] != 3 {
+		t.Errorf("GetAll should return only present keys, got %v", found)
+	}
+	if _, ok := found["missing"]; ok {
+		t.Error("GetAll should omit absent keys")
+	}
+
+	values := m.GetAllOrDefault([]string{"b", "missing", "a"}, -1)
+	if len(values) != 3 || values[0] != 2 || values[1] != -1 || values[2] != 1 {
+		t.Errorf("GetAllOrDefault should return [2 -1 1], got %v", values)
+	}
+	if len(m.GetAll(nil)) != 0 || len(m.GetAllOrDefault(nil, 0)) != 0 {
+		t.Error("Empty batches should return empty results")
+	}
+}
+
+func TestLinkedHashMapWithLoadFactor(t *testing.T) {
+	compact := NewLinkedHashMapWithLoadFactor[int, int](16, 4.0)
+	standard := NewLinkedHashMapWithCapacity[int, int](16)
+	for i := 0; i < 40; i++ {
+		compact.Put(i, i)
+		standard.Put(i, i)
+	}
+
+	if compact.LoadFactor() != 4.0 || standard.LoadFactor() != 0.75 {
+		t.Errorf("Load factors should be 4.0 and 0.75, got %v and %v", compact.LoadFactor(), standard.LoadFactor())
+	}
+	if compact.Capacity() != 16 {
+		t.Errorf("A load factor of 4 should keep 40 entries in 16 buckets, got %d", compact.Capacity())
+	}
+	if standard.Capacity() <= compact.Capacity() {
+		t.Errorf("The default load factor should grow the table, got %d buckets", standard.Capacity())
+	}
+	for i := 0; i < 40; i++ {
+		if v, ok := compact.Get(i); !ok || v != i {
+			t.Fatalf("Compact map should still find key %d", i)
+		}
+	}
+
+	sparse := NewLinkedHashMapWithLoadFactor[int, int](16, 0.25)
+	for i := 0; i < 5; i++ {
+		sparse.Put(i, i)
+	}
+	if sparse.Capacity() != 32 {
+		t.Errorf("A load factor of 0.25 should grow 16 buckets past 4 entries, got %d", sparse.Capacity())
+	}
+
+	if NewLinkedHashMapWithLoadFactor[int, int](16, 0).LoadFactor() != 0.75 {
+		t.Error("A non-positive load factor should fall back to 0.75")
+	}
+	for _, lf := range []float64{math.NaN(), math.Inf(1), math.Inf(-1)} {
+		if got := NewLinkedHashMapWithLoadFactor[int, int](16, lf).LoadFactor(); got != 0.75 {
+			t.Errorf("A non-finite load factor %v should fall back to 0.75, got %v", lf, got)
+		}
+	}
+
+	huge := NewLinkedHashMapWithLoadFactor[int, int](16, 1e300)
+	if huge.LoadFactor() != maxLoadFactor {
+		t.Errorf("A huge load factor should be clamped to %v, got %v", maxLoadFactor, huge.LoadFactor())
+	}
+	for i := 0; i < 100; i++ {
+		huge.Put(i, i)
+	}
+	if huge.Capacity() != 16 || huge.Size() != 100 {
+		t.Errorf("A clamped load factor should keep 100 entries in 16 buckets, got %d buckets and %d entries", huge.Capacity(), huge.Size())
+	}
+}
+
+func TestLinkedHashMapCaseInsensitiveKeys(t *testing.T) {
+	config := NewLinkedHashMapWithHashStrategy[string, int](common.NewCaseInsensitiveStringHashStrategy())
+	config.Put("MaxConns", 10)
+	config.Put("maxconns", 20)
+
+	if config.Size() != 1 {
+		t.Errorf("Keys differing only in case should collapse, got size %d", config.Size())
+	}
+	if v, ok := config.Get("MAXCONNS"); !ok || v != 20 {
+		t.Errorf("Lookup should ignore case, got (%d, %v)", v, ok)
+	}
+	if _, ok := config.Remove("maxConns"); !ok || !config.IsEmpty() {
+		t.Error("Remove should ignore case")
+	}
+}
+
+type treeBinKey struct {
+	region string
+	id     int
+}
+
+func TestLinkedHashMapTreeBinStructKeys(t *testing.T) {
+	// Every key hashes to the same value, so the bucket treeifies and all
+	// ordering inside the tree falls back to the tiebreak path
+	collide := common.NewFunctionalHashStrategy(
+		func(treeBinKey) uint64 { return 7 },
+		func(a, b treeBinKey) bool { return a == b },
+	)
+	m := NewLinkedHashMapWithCapacityAndHashStrategy[treeBinKey, int](64, collide)
+
+	const n = 200
+	for i := 0; i < n; i++ {
+		m.Put(treeBinKey{region: fmt.Sprintf("r%d", i%3), id: i}, i)
+	}
+	if m.Size() != n {
+		t.Fatalf("Size() = %d; want %d", m.Size(), n)
+	}
+	for i := 0; i < n; i++ {
+		key := treeBinKey{region: fmt.Sprintf("r%d", i%3), id: i}
+		if val, found := m.Get(key); !found || val != i {
+			t.Errorf("Get(%v) = %v, %v; want %d, true", key, val, found, i)
+		}
+	}
+
+	// Overwrites must not create duplicates
+	for i := 0; i < n; i += 10 {
+		if _, existed := m.Put(treeBinKey{region: fmt.Sprintf("r%d", i%3), id: i}, -i); !existed {
+			t.Errorf("Put of existing key %d reported existed = false", i)
+		}
+	}
+	if m.Size() != n {
+		t.Errorf("After overwrite, Size() = %d; want %d", m.Size(), n)
+	}
+
+	for i := 0; i < n; i += 2 {
+		key := treeBinKey{region: fmt.Sprintf("r%d", i%3), id: i}
+		if _, found := m.Remove(key); !found {
+			t.Errorf("Remove(%v) found = false; want true", key)
+		}
+	}
+	for i := 0; i < n; i++ {
+		key := treeBinKey{region: fmt.Sprintf("r%d", i%3), id: i}
+		if _, found := m.Get(key); found != (i%2 == 1) {
+			t.Errorf("After removal, Get(%v) found = %v; want %v", key, found, i%2 == 1)
+		}
+	}
+}
+
+func TestLinkedHashMapTreeBinDefaultStructKeys(t *testing.T) {
+	m := NewLinkedHashMapWithCapacity[treeBinKey, int](64)
+	for i := 0; i < 5000; i++ {
+		m.Put(treeBinKey{region: "eu", id: i}, i)
+	}
+	for i := 0; i < 5000; i++ {
+		if val, found := m.Get(treeBinKey{region: "eu", id: i}); !found || val != i {
+			t.Fatalf("Get(%d) = %v, %v; want %d, true", i, val, found, i)
+		}
+	}
+}
+
+func TestLinkedHashMapBinaryRoundTrip(t *testing.T) {
+	m := NewLinkedHashMap[int, []string]()
+	for i := 0; i < 100; i++ {
+		m.Put(i, []string{fmt.Sprint(i), "x"})
+	}
+
+	var _ common.Serializable = m
+	data, err := m.MarshalBinary()
+	if err != nil {
+		t.Fatalf("MarshalBinary failed: %v", err)
+	}
+
+	var restored LinkedHashMap[int, []string]
+	if err := restored.UnmarshalBinary(data); err != nil {
+		t.Fatalf("UnmarshalBinary failed: %v", err)
+	}
+	if restored.Size() != 100 {
+		t.Fatalf("Expected 100 mappings, got %d", restored.Size())
+	}
+	for i := 0; i < 100; i++ {
+		if v, ok := restored.Get(i); !ok || len(v) != 2 || v[0] != fmt.Sprint(i) {
+			t.Errorf("Get(%d) = %v, %v after round trip", i, v, ok)
+		}
+	}
+
+	// Decoding into a populated map swaps the contents in one step
+	small := NewLinkedHashMap[int, []string]()
+	small.Put(-1, nil)
+	smallData, _ := small.MarshalBinary()
+	done := make(chan struct{})
+	sizes := make(chan int, 1)
+	go func() {
+		defer close(sizes)
+		for {
+			select {
+			case <-done:
+				return
+			default:
+			}
+			if size := restored.Size(); size != 1 && size != 100 {
+				sizes <- size
+				return
+			}
+		}
+	}()
+	for i := 0; i < 50; i++ {
+		payload := data
+		if i%2 == 0 {
+			payload = smallData
+		}
+		if err := restored.UnmarshalBinary(payload); err != nil {
+			t.Fatalf("UnmarshalBinary failed: %v", err)
+		}
+	}
+	close(done)
+	if size, seen := <-sizes; seen {
+		t.Errorf("A reader should only see the old or the new contents, saw %d mappings", size)
+	}
+	if restored.Size() != 100 {
+		t.Errorf("Expected the last payload's 100 mappings, got %d", restored.Size())
+	}
+}
+
+func TestLinkedHashMapStringN(t *testing.T) {
+	m := NewLinkedHashMap[int, int]()
+	for i := 0; i < 1000; i++ {
+		m.Put(i, i)
+	}
+	got := m.StringN(3)
+	if strings.Count(got, "=") != 3 || !strings.HasSuffix(got, ", … (+997 more)}") {
+		t.Errorf("Expected three mappings and a summary, got %q", got)
+	}
+}
+
+func TestLinkedHashMapFromIterator(t *testing.T) {
+	tm := NewTreeMap[string, int]()
+	tm.Put("a", 1)
+	tm.Put("b", 2)
+
+	m := FromIterator(tm.EntryIterator())
+	if m.Size() != 2 {
+		t.Fatalf("Expected 2 mappings, got %d", m.Size())
+	}
+	if v, ok := m.Get("b"); !ok || v != 2 {
+		t.Errorf("Get(b) = %v, %v; want 2, true", v, ok)
+	}
+	if FromIterator[string, int](nil).Size() != 0 {
+		t.Error("A nil iterator should yield an empty map")
+	}
+}
@@ -31,6 +31,19 @@ func NewImmutableMapFromMap[K comparable, V any](m map[K]V) *ImmutableMap[K, V]
 	}
 }
 
+// MapOfEntries creates a new ImmutableMap from the given entries
+// It accepts the result of Entries() from any Map or Multimap directly; later entries win on duplicate keys
+func MapOfEntries[K comparable, V any](entries ...common.Entry[K, V]) *ImmutableMap[K, V] {
+	m := make(map[K]V, len(entries))
+	for _, entry := range entries {
+		m[entry.Key] = entry.Value
+	}
+
+	return &ImmutableMap[K, V]{
+		entries: m,
+	}
+}
+
 // MapOf creates a new ImmutableMap from the given key-value pairs
 func MapOf[K comparable, V any](pairs ...Pair[K, V]) *ImmutableMap[K, V] {
 	entries := make(map[K]V, len(pairs))
@@ -80,6 +93,31 @@ func (im *ImmutableMap[K, V]) Get(key K) (V, bool) {
 	return value, exists
 }
 
+// GetAll returns the values mapped to the given keys, omitting keys that are absent
+func (im *ImmutableMap[K, V]) GetAll(keys []K) map[K]V {
+	result := make(map[K]V, len(keys))
+	for _, key := range keys {
+		if value, exists := im.entries[key]; exists {
+			result[key] = value
+		}
+	}
+	return result
+}
+
+// GetAllOrDefault returns the values mapped to the given keys in key order,
+// using defaultValue for keys that are absent
+func (im *ImmutableMap[K, V]) GetAllOrDefault(keys []K, defaultValue V) []V {
+	values := make([]V, len(keys))
+	for i, key := range keys {
+		if value, exists := im.entries[key]; exists {
+			values[i] = value
+		} else {
+			values[i] = defaultValue
+		}
+	}
+	return values
+}
+
 // Remove returns the zero value and false as ImmutableMap is immutable
 func (im *ImmutableMap[K, V]) Remove(key K) (V, bool) {
 	var zero V
@@ -87,6 +125,11 @@ func (im *ImmutableMap[K, V]) Remove(key K) (V, bool) {
 	return zero, false
 }
 
+// RemoveIfValue returns false as ImmutableMap is immutable
+func (im *ImmutableMap[K, V]) RemoveIfValue(key K, expected V) bool {
+	return false
+}
+
 // ContainsKey returns true if the map contains the specified key
 func (im *ImmutableMap[K, V]) ContainsKey(key K) bool {
 	_, exists := im.entries[key]
@@ -379,4 +379,53 @@ func TestImmutableMapImmutability(t *testing.T) {
 			t.Errorf("Original map should remain unchanged, expected %d for key %s, got %d (exists: %t)", expectedValue, key, value, exists)
 		}
 	}
-}
\ No newline at end of file
+}
+func TestImmutableMapRemoveIfValue(t *testing.T) {
+	m := NewImmutableMapFromMap(map[int]string{1: "one"})
+	if m.RemoveIfValue(1, "one") {
+		t.Error("RemoveIfValue should return false for ImmutableMap")
+	}
+	if !m.ContainsKey(1) {
+		t.Error("ImmutableMap should be unchanged")
+	}
+}
+
+func TestImmutableMapGetAll(t *testing.T) {
+	m := NewImmutableMapFromMap(map[string]int{"a": 1, "b": 2})
+
+	found := m.GetAll([]string{"a", "missing"})
+	if len(found) != 1 || found["a"] != 1 {
+		t.Errorf("GetAll should return only present keys, got %v", found)
+	}
+	values := m.GetAllOrDefault([]string{"missing", "b"}, 0)
+	if len(values) != 2 || values[0] != 0 || values[1] != 2 {
+		t.Errorf("GetAllOrDefault should return [0 2], got %v", values)
+	}
+}
+
+func TestMapOfEntries(t *testing.T) {
+	tree := NewTreeMap[string, int]()
+	tree.Put("a", 1)
+	tree.Put("b", 2)
+	linked := NewLinkedHashMap[string, int]()
+	linked.Put("b", 20)
+	linked.Put("c", 30)
+
+	// Entries from different map types share one type and can be combined directly
+	entries := append(tree.Entries(), linked.Entries()...)
+	entries = append(entries, NewPair("d", 4).ToEntry())
+
+	m := MapOfEntries(entries...)
+	if m.Size() != 4 {
+		t.Errorf("Expected 4 keys, got %d", m.Size())
+	}
+	if v, _ := m.Get("b"); v != 20 {
+		t.Errorf("Later entries should win on duplicate keys, got %d", v)
+	}
+	if v, _ := m.Get("d"); v != 4 {
+		t.Errorf("Converted pair should be present, got %d", v)
+	}
+	if !MapOfEntries[string, int]().IsEmpty() {
+		t.Error("MapOfEntries with no entries should be empty")
+	}
+}
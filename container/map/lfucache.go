@@ -0,0 +1,336 @@
+package maps
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/chenjianyu/collections/container/common"
+)
+
+// LFUCache is a bounded Map that evicts the least frequently used entry when full
+// Every Get and every Put of an existing key counts as one use of that key
+// Among entries with the same use count, the least recently used one is evicted first
+// All operations run in O(1) time
+type LFUCache[K comparable, V any] struct {
+	entries  map[K]*lfuEntry[K, V]
+	freqHead *lfuFreqNode[K, V] // Sentinel; real frequency nodes follow in ascending order
+	capacity int
+	mutex    sync.RWMutex
+}
+
+// lfuFreqNode groups all entries that share a use count
+// Entries are kept from least to most recently used
+type lfuFreqNode[K comparable, V any] struct {
+	freq int
+	head *lfuEntry[K, V]
+	tail *lfuEntry[K, V]
+	prev *lfuFreqNode[K, V]
+	next *lfuFreqNode[K, V]
+}
+
+// lfuEntry is a cached key-value pair linked into its frequency node
+type lfuEntry[K comparable, V any] struct {
+	key    K
+	value  V
+	parent *lfuFreqNode[K, V]
+	prev   *lfuEntry[K, V]
+	next   *lfuEntry[K, V]
+}
+
+// NewLFUCache creates an LFUCache holding at most capacity entries
+// A capacity less than 1 is treated as 1
+func NewLFUCache[K comparable, V any](capacity int) *LFUCache[K, V] {
+	if capacity < 1 {
+		capacity = 1
+	}
+	return &LFUCache[K, V]{
+		entries:  make(map[K]*lfuEntry[K, V], capacity),
+		freqHead: &lfuFreqNode[K, V]{},
+		capacity: capacity,
+	}
+}
+
+// Capacity returns the maximum number of entries the cache holds
+func (c *LFUCache[K, V]) Capacity() int {
+	return c.capacity
+}
+
+// Put associates the specified value with the specified key
+// Updating an existing key counts as a use; inserting into a full cache evicts the
+// least frequently used entry first
+func (c *LFUCache[K, V]) Put(key K, value V) (V, bool) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if entry, exists := c.entries[key]; exists {
+		oldValue := entry.value
+		entry.value = value
+		c.touch(entry)
+		return oldValue, true
+	}
+
+	if len(c.entries) >= c.capacity {
+		c.evict()
+	}
+
+	entry := &lfuEntry[K, V]{key: key, value: value}
+	first := c.freqHead.next
+	if first == nil || first.freq != 1 {
+		first = c.insertFreqAfter(c.freqHead, 1)
+	}
+	first.append(entry)
+	c.entries[key] = entry
+
+	var zero V
+	return zero, false
+}
+
+// Get returns the value mapped to the key and counts it as a use
+func (c *LFUCache[K, V]) Get(key K) (V, bool) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	entry, exists := c.entries[key]
+	if !exists {
+		var zero V
+		return zero, false
+	}
+	c.touch(entry)
+	return entry.value, true
+}
+
+// Peek returns the value mapped to the key without counting it as a use
+func (c *LFUCache[K, V]) Peek(key K) (V, bool) {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	if entry, exists := c.entries[key]; exists {
+		return entry.value, true
+	}
+	var zero V
+	return zero, false
+}
+
+// Frequency returns the number of uses recorded for the key, or 0 if it is not cached
+func (c *LFUCache[K, V]) Frequency(key K) int {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	if entry, exists := c.entries[key]; exists {
+		return entry.parent.freq
+	}
+	return 0
+}
+
+// Remove removes the mapping for the key if present
+func (c *LFUCache[K, V]) Remove(key K) (V, bool) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	entry, exists := c.entries[key]
+	if !exists {
+		var zero V
+		return zero, false
+	}
+	c.unlink(entry)
+	delete(c.entries, key)
+	return entry.value, true
+}
+
+// ContainsKey returns true if the cache holds the key; this does not count as a use
+func (c *LFUCache[K, V]) ContainsKey(key K) bool {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	_, exists := c.entries[key]
+	return exists
+}
+
+// ContainsValue returns true if one or more keys map to the specified value
+func (c *LFUCache[K, V]) ContainsValue(value V) bool {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	for _, entry := range c.entries {
+		if common.Equal(entry.value, value) {
+			return true
+		}
+	}
+	return false
+}
+
+// Size returns the number of cached entries
+func (c *LFUCache[K, V]) Size() int {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+	return len(c.entries)
+}
+
+// IsEmpty returns true if the cache holds no entries
+func (c *LFUCache[K, V]) IsEmpty() bool {
+	return c.Size() == 0
+}
+
+// Clear removes all entries from the cache
+func (c *LFUCache[K, V]) Clear() {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.entries = make(map[K]*lfuEntry[K, V], c.capacity)
+	c.freqHead.next = nil
+}
+
+// Keys returns the cached keys in eviction order (least frequently used first)
+func (c *LFUCache[K, V]) Keys() []K {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	keys := make([]K, 0, len(c.entries))
+	c.forEachEntry(func(entry *lfuEntry[K, V]) {
+		keys = append(keys, entry.key)
+	})
+	return keys
+}
+
+// Values returns the cached values in eviction order (least frequently used first)
+func (c *LFUCache[K, V]) Values() []V {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	values := make([]V, 0, len(c.entries))
+	c.forEachEntry(func(entry *lfuEntry[K, V]) {
+		values = append(values, entry.value)
+	})
+	return values
+}
+
+// Entries returns the cached entries in eviction order (least frequently used first)
+func (c *LFUCache[K, V]) Entries() []common.Entry[K, V] {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	entries := make([]common.Entry[K, V], 0, len(c.entries))
+	c.forEachEntry(func(entry *lfuEntry[K, V]) {
+		entries = append(entries, common.NewEntry(entry.key, entry.value))
+	})
+	return entries
+}
+
+// ForEach executes the given operation for each entry in eviction order
+// Visiting an entry does not count as a use
+func (c *LFUCache[K, V]) ForEach(f func(K, V)) {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	c.forEachEntry(func(entry *lfuEntry[K, V]) {
+		f(entry.key, entry.value)
+	})
+}
+
+// PutAll copies all mappings from the specified map into this cache
+func (c *LFUCache[K, V]) PutAll(other Map[K, V]) {
+	other.ForEach(func(k K, v V) {
+		c.Put(k, v)
+	})
+}
+
+// String returns the string representation of the cache in eviction order
+func (c *LFUCache[K, V]) String() string {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	if len(c.entries) == 0 {
+		return "{}"
+	}
+
+	var builder strings.Builder
+	builder.WriteString("{")
+	first := true
+	c.forEachEntry(func(entry *lfuEntry[K, V]) {
+		if !first {
+			builder.WriteString(", ")
+		}
+		builder.WriteString(fmt.Sprintf("%v=%v", entry.key, entry.value))
+		first = false
+	})
+	builder.WriteString("}")
+	return builder.String()
+}
+
+// touch records one use of entry by moving it to the next frequency node
+func (c *LFUCache[K, V]) touch(entry *lfuEntry[K, V]) {
+	current := entry.parent
+	next := current.next
+	if next == nil || next.freq != current.freq+1 {
+		next = c.insertFreqAfter(current, current.freq+1)
+	}
+	c.unlink(entry)
+	next.append(entry)
+}
+
+// evict removes the least recently used entry of the lowest frequency
+func (c *LFUCache[K, V]) evict() {
+	lowest := c.freqHead.next
+	if lowest == nil {
+		return
+	}
+	victim := lowest.head
+	c.unlink(victim)
+	delete(c.entries, victim.key)
+}
+
+// unlink detaches entry from its frequency node, dropping the node once it is empty
+func (c *LFUCache[K, V]) unlink(entry *lfuEntry[K, V]) {
+	node := entry.parent
+	if entry.prev != nil {
+		entry.prev.next = entry.next
+	} else {
+		node.head = entry.next
+	}
+	if entry.next != nil {
+		entry.next.prev = entry.prev
+	} else {
+		node.tail = entry.prev
+	}
+	entry.prev, entry.next, entry.parent = nil, nil, nil
+
+	if node.head == nil {
+		node.prev.next = node.next
+		if node.next != nil {
+			node.next.prev = node.prev
+		}
+	}
+}
+
+// insertFreqAfter creates an empty frequency node directly after node
+func (c *LFUCache[K, V]) insertFreqAfter(node *lfuFreqNode[K, V], freq int) *lfuFreqNode[K, V] {
+	created := &lfuFreqNode[K, V]{freq: freq, prev: node, next: node.next}
+	if node.next != nil {
+		node.next.prev = created
+	}
+	node.next = created
+	return created
+}
+
+// forEachEntry visits entries from the lowest frequency up, least recently used first
+func (c *LFUCache[K, V]) forEachEntry(fn func(*lfuEntry[K, V])) {
+	for node := c.freqHead.next; node != nil; node = node.next {
+		for entry := node.head; entry != nil; entry = entry.next {
+			fn(entry)
+		}
+	}
+}
+
+// append adds entry as the most recently used entry of this frequency
+func (n *lfuFreqNode[K, V]) append(entry *lfuEntry[K, V]) {
+	entry.parent = n
+	entry.prev = n.tail
+	entry.next = nil
+	if n.tail != nil {
+		n.tail.next = entry
+	} else {
+		n.head = entry
+	}
+	n.tail = entry
+}
@@ -0,0 +1,117 @@
+package maps
+
+import (
+	"testing"
+)
+
+func TestLFUCacheEvictsLeastFrequent(t *testing.T) {
+	cache := NewLFUCache[string, int](3)
+	cache.Put("a", 1)
+	cache.Put("b", 2)
+	cache.Put("c", 3)
+
+	// a is hot, b is warm, c is cold
+	cache.Get("a")
+	cache.Get("a")
+	cache.Get("b")
+
+	cache.Put("d", 4)
+	if cache.ContainsKey("c") {
+		t.Error("Least frequently used key c should be evicted")
+	}
+	if cache.Size() != 3 {
+		t.Errorf("Size should stay at capacity 3, got %d", cache.Size())
+	}
+
+	// d (freq 1) is now the coldest
+	cache.Put("e", 5)
+	if cache.ContainsKey("d") {
+		t.Error("Key d should be evicted next")
+	}
+	if !cache.ContainsKey("a") || !cache.ContainsKey("b") || !cache.ContainsKey("e") {
+		t.Errorf("Cache should hold a, b and e, got %v", cache)
+	}
+}
+
+func TestLFUCacheHotSetSurvivesScan(t *testing.T) {
+	cache := NewLFUCache[int, int](4)
+	for hot := 0; hot < 2; hot++ {
+		cache.Put(hot, hot)
+		cache.Get(hot)
+		cache.Get(hot)
+	}
+
+	// A long scan of one-off keys must not push out the hot set
+	for i := 100; i < 200; i++ {
+		cache.Put(i, i)
+	}
+	if !cache.ContainsKey(0) || !cache.ContainsKey(1) {
+		t.Errorf("Hot keys should survive a scan, got %v", cache.Keys())
+	}
+}
+
+func TestLFUCacheTieBreaksByRecency(t *testing.T) {
+	cache := NewLFUCache[string, int](2)
+	cache.Put("a", 1)
+	cache.Put("b", 2)
+	cache.Get("a")
+	cache.Get("b")
+
+	// a and b both have frequency 2, a was used longer ago
+	cache.Put("c", 3)
+	if cache.ContainsKey("a") || !cache.ContainsKey("b") {
+		t.Errorf("Least recently used key among equal frequencies should be evicted, got %v", cache.Keys())
+	}
+}
+
+func TestLFUCacheOperations(t *testing.T) {
+	cache := NewLFUCache[string, int](3)
+
+	if old, existed := cache.Put("a", 1); existed || old != 0 {
+		t.Error("Put of new key should report no previous value")
+	}
+	if old, existed := cache.Put("a", 10); !existed || old != 1 {
+		t.Errorf("Put of existing key should return old value 1, got %d", old)
+	}
+	if freq := cache.Frequency("a"); freq != 2 {
+		t.Errorf("Updating a key should count as a use, frequency should be 2, got %d", freq)
+	}
+
+	if v, ok := cache.Peek("a"); !ok || v != 10 {
+		t.Errorf("Peek should return 10, got %d", v)
+	}
+	if freq := cache.Frequency("a"); freq != 2 {
+		t.Errorf("Peek should not count as a use, got frequency %d", freq)
+	}
+
+	cache.Put("b", 2)
+	if keys := cache.Keys(); len(keys) != 2 || keys[0] != "b" || keys[1] != "a" {
+		t.Errorf("Keys should be in eviction order [b a], got %v", keys)
+	}
+	if !cache.ContainsValue(2) || cache.ContainsValue(3) {
+		t.Error("ContainsValue returned wrong result")
+	}
+
+	if v, ok := cache.Remove("b"); !ok || v != 2 {
+		t.Errorf("Remove should return 2, got %d", v)
+	}
+	if _, ok := cache.Get("b"); ok {
+		t.Error("Removed key should not be found")
+	}
+
+	cache.Clear()
+	if !cache.IsEmpty() || cache.String() != "{}" {
+		t.Error("Cache should be empty after Clear")
+	}
+	cache.Put("x", 1)
+	if cache.Frequency("x") != 1 {
+		t.Error("Cache should be usable after Clear")
+	}
+
+	var m Map[string, int] = NewLFUCache[string, int](0)
+	m.Put("only", 1)
+	m.Put("next", 2)
+	if m.Size() != 1 || !m.ContainsKey("next") {
+		t.Error("Non-positive capacity should be treated as 1")
+	}
+}
@@ -0,0 +1,215 @@
+package maps
+
+import (
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/chenjianyu/collections/container/common"
+)
+
+// LoadingCache is a read-through cache backed by a ConcurrentHashMap
+// Get computes missing values with the loader and stores them; concurrent Gets of the same
+// missing key share a single loader call, while loads of different keys run in parallel
+// The loader runs without holding any lock, so it never blocks readers of other keys
+type LoadingCache[K comparable, V any] struct {
+	// Counters reported by Stats, updated atomically; kept first for 64-bit alignment
+	hits          int64
+	misses        int64
+	loads         int64
+	loadFailures  int64
+	loadTimeNanos int64
+	evictions     int64
+
+	data     *ConcurrentHashMap[K, V]
+	loader   func(K) (V, error)
+	inflight map[K]*loadCall[V]
+	mutex    sync.Mutex // Guards inflight
+}
+
+// CacheStats is a snapshot of a LoadingCache's counters
+type CacheStats struct {
+	Hits          int64         // Gets answered from the cache
+	Misses        int64         // Gets that started or waited for a load
+	Loads         int64         // Loader invocations, successful or not
+	LoadFailures  int64         // Loader invocations that returned an error or panicked
+	TotalLoadTime time.Duration // Time spent in the loader across all invocations
+	Evictions     int64         // Cached values discarded by Invalidate or InvalidateAll
+}
+
+// HitRate returns the fraction of Gets answered from the cache, or 1 if there were no Gets
+func (s CacheStats) HitRate() float64 {
+	total := s.Hits + s.Misses
+	if total == 0 {
+		return 1
+	}
+	return float64(s.Hits) / float64(total)
+}
+
+// AverageLoadTime returns the mean time of a loader invocation, or 0 if there were none
+func (s CacheStats) AverageLoadTime() time.Duration {
+	if s.Loads == 0 {
+		return 0
+	}
+	return s.TotalLoadTime / time.Duration(s.Loads)
+}
+
+// loadCall is one in-progress loader invocation shared by every Get waiting on its key
+type loadCall[V any] struct {
+	done  chan struct{}
+	value V
+	err   error
+}
+
+// NewLoadingCache creates a LoadingCache that fills misses with loader
+// A nil loader makes Get on a missing key return an error wrapping common.ErrKeyNotFound
+func NewLoadingCache[K comparable, V any](loader func(K) (V, error)) *LoadingCache[K, V] {
+	if loader == nil {
+		loader = func(key K) (V, error) {
+			var zero V
+			return zero, common.KeyNotFoundError(key)
+		}
+	}
+	return &LoadingCache[K, V]{
+		data:     NewConcurrentHashMap[K, V](),
+		loader:   loader,
+		inflight: make(map[K]*loadCall[V]),
+	}
+}
+
+// Get returns the cached value for key, loading and caching it on a miss
+// If another goroutine is already loading key, Get waits for that load instead of starting its own
+// Loader errors are returned to every waiting caller and are not cached
+func (c *LoadingCache[K, V]) Get(key K) (V, error) {
+	if value, ok := c.data.Get(key); ok {
+		atomic.AddInt64(&c.hits, 1)
+		return value, nil
+	}
+
+	c.mutex.Lock()
+	if value, ok := c.data.Get(key); ok {
+		// A load finished between the first lookup and taking the lock
+		c.mutex.Unlock()
+		atomic.AddInt64(&c.hits, 1)
+		return value, nil
+	}
+	atomic.AddInt64(&c.misses, 1)
+	if call, ok := c.inflight[key]; ok {
+		c.mutex.Unlock()
+		<-call.done
+		return call.value, call.err
+	}
+	call := &loadCall[V]{done: make(chan struct{})}
+	c.inflight[key] = call
+	c.mutex.Unlock()
+
+	c.load(key, call)
+	return call.value, call.err
+}
+
+// load runs the loader for call, caches a successful result and releases any waiters
+// If the loader panics, waiters receive an error wrapping common.ErrInvalidOperation and the
+// panic is re-raised in the loading goroutine once the call has been cleaned up
+func (c *LoadingCache[K, V]) load(key K, call *loadCall[V]) {
+	start := time.Now()
+	finished := false
+	defer func() {
+		if finished {
+			return
+		}
+		r := recover()
+		var zero V
+		call.value, call.err = zero, common.InvalidOperationError("load", fmt.Sprintf("loader panicked: %v", r))
+		c.finishLoad(key, call, start)
+		panic(r)
+	}()
+
+	call.value, call.err = c.loader(key)
+	finished = true
+	c.finishLoad(key, call, start)
+}
+
+// finishLoad records stats for call, detaches it from inflight and wakes its waiters
+func (c *LoadingCache[K, V]) finishLoad(key K, call *loadCall[V], start time.Time) {
+	atomic.AddInt64(&c.loadTimeNanos, int64(time.Since(start)))
+	atomic.AddInt64(&c.loads, 1)
+	if call.err != nil {
+		atomic.AddInt64(&c.loadFailures, 1)
+	}
+
+	c.mutex.Lock()
+	// Put or Invalidate during the load detach the call, so a stale result is not stored
+	if c.inflight[key] == call {
+		delete(c.inflight, key)
+		if call.err == nil {
+			c.data.Put(key, call.value)
+		}
+	}
+	c.mutex.Unlock()
+	close(call.done)
+}
+
+// GetIfPresent returns the cached value for key without invoking the loader
+func (c *LoadingCache[K, V]) GetIfPresent(key K) (V, bool) {
+	return c.data.Get(key)
+}
+
+// Put stores value for key, replacing any cached value
+// A load of key in progress still returns its result to its callers but no longer caches it
+func (c *LoadingCache[K, V]) Put(key K, value V) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	delete(c.inflight, key)
+	c.data.Put(key, value)
+}
+
+// Invalidate discards the cached value for key so the next Get loads it again
+func (c *LoadingCache[K, V]) Invalidate(key K) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	delete(c.inflight, key)
+	if _, existed := c.data.Remove(key); existed {
+		atomic.AddInt64(&c.evictions, 1)
+	}
+}
+
+// InvalidateAll discards every cached value
+func (c *LoadingCache[K, V]) InvalidateAll() {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.inflight = make(map[K]*loadCall[V])
+	atomic.AddInt64(&c.evictions, int64(c.data.Size()))
+	c.data.Clear()
+}
+
+// Size returns the number of cached values
+func (c *LoadingCache[K, V]) Size() int {
+	return c.data.Size()
+}
+
+// Stats returns a snapshot of the cache's hit, miss, load and eviction counters
+// The counters are read individually, so a snapshot taken under load may be slightly inconsistent
+func (c *LoadingCache[K, V]) Stats() CacheStats {
+	return CacheStats{
+		Hits:          atomic.LoadInt64(&c.hits),
+		Misses:        atomic.LoadInt64(&c.misses),
+		Loads:         atomic.LoadInt64(&c.loads),
+		LoadFailures:  atomic.LoadInt64(&c.loadFailures),
+		TotalLoadTime: time.Duration(atomic.LoadInt64(&c.loadTimeNanos)),
+		Evictions:     atomic.LoadInt64(&c.evictions),
+	}
+}
+
+// ResetStats sets every counter reported by Stats back to zero
+func (c *LoadingCache[K, V]) ResetStats() {
+	atomic.StoreInt64(&c.hits, 0)
+	atomic.StoreInt64(&c.misses, 0)
+	atomic.StoreInt64(&c.loads, 0)
+	atomic.StoreInt64(&c.loadFailures, 0)
+	atomic.StoreInt64(&c.loadTimeNanos, 0)
+	atomic.StoreInt64(&c.evictions, 0)
+}
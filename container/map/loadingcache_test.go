@@ -0,0 +1,264 @@
+package maps
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/chenjianyu/collections/container/common"
+)
+
+func TestLoadingCacheGet(t *testing.T) {
+	var loads int32
+	cache := NewLoadingCache(func(key string) (int, error) {
+		atomic.AddInt32(&loads, 1)
+		return len(key), nil
+	})
+
+	if v, err := cache.Get("hello"); err != nil || v != 5 {
+		t.Errorf("Expected 5, got %d, %v", v, err)
+	}
+	if v, err := cache.Get("hello"); err != nil || v != 5 {
+		t.Errorf("Expected cached 5, got %d, %v", v, err)
+	}
+	if loads != 1 {
+		t.Errorf("Expected 1 load, got %d", loads)
+	}
+	if v, ok := cache.GetIfPresent("hello"); !ok || v != 5 {
+		t.Error("GetIfPresent should return the loaded value")
+	}
+	if _, ok := cache.GetIfPresent("missing"); ok {
+		t.Error("GetIfPresent should not invoke the loader")
+	}
+
+	cache.Put("hello", 42)
+	if v, _ := cache.Get("hello"); v != 42 {
+		t.Errorf("Put should replace the cached value, got %d", v)
+	}
+	cache.Invalidate("hello")
+	if v, _ := cache.Get("hello"); v != 5 || loads != 2 {
+		t.Errorf("Invalidate should force a reload, got %d after %d loads", v, loads)
+	}
+	cache.InvalidateAll()
+	if cache.Size() != 0 {
+		t.Errorf("InvalidateAll should empty the cache, got size %d", cache.Size())
+	}
+}
+
+func TestLoadingCacheErrorsAreNotCached(t *testing.T) {
+	fail := true
+	cache := NewLoadingCache(func(key int) (string, error) {
+		if fail {
+			return "", errors.New("backend unavailable")
+		}
+		return "ok", nil
+	})
+
+	if _, err := cache.Get(1); err == nil {
+		t.Error("Loader error should be returned")
+	}
+	if cache.Size() != 0 {
+		t.Error("Failed loads should not be cached")
+	}
+	fail = false
+	if v, err := cache.Get(1); err != nil || v != "ok" {
+		t.Errorf("Get should retry after a failed load, got %q, %v", v, err)
+	}
+
+	noLoader := NewLoadingCache[string, int](nil)
+	if _, err := noLoader.Get("x"); !errors.Is(err, common.ErrKeyNotFound) {
+		t.Errorf("Nil loader should report ErrKeyNotFound, got %v", err)
+	}
+}
+
+func TestLoadingCacheSingleFlight(t *testing.T) {
+	var loads int32
+	release := make(chan struct{})
+	cache := NewLoadingCache(func(key string) (int, error) {
+		atomic.AddInt32(&loads, 1)
+		<-release
+		return 7, nil
+	})
+
+	const callers = 20
+	var wg sync.WaitGroup
+	results := make([]int, callers)
+	for i := 0; i < callers; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			results[i], _ = cache.Get("shared")
+		}(i)
+	}
+	time.Sleep(20 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	if loads != 1 {
+		t.Errorf("Concurrent Gets of one key should share a single load, got %d loads", loads)
+	}
+	for i, v := range results {
+		if v != 7 {
+			t.Errorf("Caller %d expected 7, got %d", i, v)
+		}
+	}
+}
+
+func TestLoadingCacheLoadsDifferentKeysInParallel(t *testing.T) {
+	fastLoaded := make(chan struct{})
+	cache := NewLoadingCache(func(key string) (string, error) {
+		if key == "slow" {
+			// Blocks until another key has been loaded, which deadlocks if loads are serialized
+			select {
+			case <-fastLoaded:
+			case <-time.After(2 * time.Second):
+				return "", errors.New("loads of different keys were serialized")
+			}
+		}
+		return key, nil
+	})
+
+	done := make(chan error, 1)
+	go func() {
+		_, err := cache.Get("slow")
+		done <- err
+	}()
+	time.Sleep(10 * time.Millisecond)
+	if v, err := cache.Get("fast"); err != nil || v != "fast" {
+		t.Errorf("Expected fast, got %q, %v", v, err)
+	}
+	if v, ok := cache.GetIfPresent("fast"); !ok || v != "fast" {
+		t.Error("fast should be readable while slow is loading")
+	}
+	close(fastLoaded)
+	if err := <-done; err != nil {
+		t.Error(err)
+	}
+}
+
+func TestLoadingCachePutDuringLoad(t *testing.T) {
+	started := make(chan struct{})
+	release := make(chan struct{})
+	cache := NewLoadingCache(func(key string) (int, error) {
+		close(started)
+		<-release
+		return 1, nil
+	})
+
+	done := make(chan int, 1)
+	go func() {
+		v, _ := cache.Get("k")
+		done <- v
+	}()
+	<-started
+	cache.Put("k", 2)
+	close(release)
+
+	if v := <-done; v != 1 {
+		t.Errorf("The loading caller should receive the loaded value, got %d", v)
+	}
+	if v, _ := cache.Get("k"); v != 2 {
+		t.Errorf("A Put during the load should win over the stale loaded value, got %d", v)
+	}
+}
+
+func TestLoadingCacheStats(t *testing.T) {
+	cache := NewLoadingCache(func(key int) (int, error) {
+		time.Sleep(time.Millisecond)
+		if key < 0 {
+			return 0, errors.New("negative key")
+		}
+		return key * key, nil
+	})
+
+	if rate := cache.Stats().HitRate(); rate != 1 {
+		t.Errorf("HitRate without Gets should be 1, got %v", rate)
+	}
+
+	cache.Get(2)  // miss, load
+	cache.Get(2)  // hit
+	cache.Get(3)  // miss, load
+	cache.Get(-1) // miss, failed load
+	cache.Get(3)  // hit
+	cache.Put(4, 16)
+	cache.Get(4) // hit
+
+	stats := cache.Stats()
+	if stats.Hits != 3 || stats.Misses != 3 {
+		t.Errorf("Expected 3 hits and 3 misses, got %+v", stats)
+	}
+	if stats.Loads != 3 || stats.LoadFailures != 1 {
+		t.Errorf("Expected 3 loads with 1 failure, got %+v", stats)
+	}
+	if stats.TotalLoadTime < 3*time.Millisecond || stats.AverageLoadTime() < time.Millisecond {
+		t.Errorf("Load time should cover every loader call, got %v", stats.TotalLoadTime)
+	}
+	if stats.HitRate() != 0.5 {
+		t.Errorf("Expected hit rate 0.5, got %v", stats.HitRate())
+	}
+
+	cache.Invalidate(2)
+	cache.Invalidate(100)
+	cache.InvalidateAll()
+	if ev := cache.Stats().Evictions; ev != 3 {
+		t.Errorf("Expected 3 evictions (2, then 3 and 4), got %d", ev)
+	}
+
+	cache.ResetStats()
+	if stats := cache.Stats(); stats != (CacheStats{}) {
+		t.Errorf("ResetStats should zero every counter, got %+v", stats)
+	}
+}
+
+func TestLoadingCacheLoaderPanic(t *testing.T) {
+	var calls int32
+	release := make(chan struct{})
+	cache := NewLoadingCache(func(key string) (int, error) {
+		if atomic.AddInt32(&calls, 1) == 1 {
+			<-release
+			panic("boom")
+		}
+		return 9, nil
+	})
+
+	recovered := make(chan interface{}, 1)
+	go func() {
+		defer func() { recovered <- recover() }()
+		cache.Get("k")
+	}()
+	time.Sleep(20 * time.Millisecond)
+
+	waiterErr := make(chan error, 1)
+	go func() {
+		_, err := cache.Get("k")
+		waiterErr <- err
+	}()
+	time.Sleep(20 * time.Millisecond)
+	close(release)
+
+	select {
+	case r := <-recovered:
+		if r != "boom" {
+			t.Errorf("The loading caller should see the loader's panic, got %v", r)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("The loading caller did not return")
+	}
+	select {
+	case err := <-waiterErr:
+		if !errors.Is(err, common.ErrInvalidOperation) {
+			t.Errorf("A waiting caller should get ErrInvalidOperation, got %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("A waiting caller blocked after the loader panicked")
+	}
+
+	if v, err := cache.Get("k"); err != nil || v != 9 {
+		t.Errorf("A later Get should load again, got %d, %v", v, err)
+	}
+	if stats := cache.Stats(); stats.LoadFailures != 1 {
+		t.Errorf("The panicking load should count as a failure, got %d", stats.LoadFailures)
+	}
+}
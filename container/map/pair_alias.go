@@ -1,6 +1,10 @@
 package maps
 
+import "github.com/chenjianyu/collections/container/common"
+
 // Pair is a simple struct used by MapOf helpers; mirrors common.Entry
+// It stays a distinct type because generic type aliases need a newer Go version than
+// this module targets; use ToEntry to convert
 // Deprecated: prefer common.Entry/common.NewEntry in new code.
 type Pair[K, V any] struct {
     Key   K
@@ -10,4 +14,9 @@ type Pair[K, V any] struct {
 // NewPair constructs a Pair for use with MapOf and helpers.
 func NewPair[K, V any](key K, value V) Pair[K, V] {
     return Pair[K, V]{Key: key, Value: value}
-}
\ No newline at end of file
+}
+
+// ToEntry converts the Pair to the equivalent common.Entry
+func (p Pair[K, V]) ToEntry() common.Entry[K, V] {
+    return common.NewEntry(p.Key, p.Value)
+}
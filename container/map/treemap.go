@@ -1,6 +1,7 @@
 package maps
 
 import (
+    "container/heap"
     "fmt"
     "strings"
     "github.com/chenjianyu/collections/container/common"
@@ -18,6 +19,7 @@ type mapNode[K comparable, V any] struct {
 	key    K
 	value  V
 	color  color
+	size   int // number of nodes in this subtree, for rank and select
 	left   *mapNode[K, V]
 	right  *mapNode[K, V]
 	parent *mapNode[K, V]
@@ -66,6 +68,8 @@ func (m *TreeMap[K, V]) rotateLeft(h *mapNode[K, V]) *mapNode[K, V] {
 	h.parent = x
 	x.color = h.color
 	h.color = red
+	updateMapNodeSize(h)
+	updateMapNodeSize(x)
 	return x
 }
 
@@ -88,17 +92,34 @@ func (m *TreeMap[K, V]) rotateRight(h *mapNode[K, V]) *mapNode[K, V] {
 	h.parent = x
 	x.color = h.color
 	h.color = red
+	updateMapNodeSize(h)
+	updateMapNodeSize(x)
 	return x
 }
 
+// mapNodeSize returns the number of nodes in the subtree rooted at node
+func mapNodeSize[K comparable, V any](node *mapNode[K, V]) int {
+	if node == nil {
+		return 0
+	}
+	return node.size
+}
+
+// updateMapNodeSize recomputes the subtree size of node from its children
+func updateMapNodeSize[K comparable, V any](node *mapNode[K, V]) {
+	node.size = mapNodeSize(node.left) + mapNodeSize(node.right) + 1
+}
+
 // flipColors color flip
+// Inverts the colors of h and its children: splitting a temporary 4-node on insertion,
+// or merging h with its children into one on deletion (moveRedLeft/moveRedRight)
 func (m *TreeMap[K, V]) flipColors(h *mapNode[K, V]) {
-	h.color = red
+	h.color = !h.color
 	if h.left != nil {
-		h.left.color = black
+		h.left.color = !h.left.color
 	}
 	if h.right != nil {
-		h.right.color = black
+		h.right.color = !h.right.color
 	}
 }
 
@@ -113,6 +134,7 @@ func (m *TreeMap[K, V]) put(h *mapNode[K, V], key K, value V) (*mapNode[K, V], V
 			key:   key,
 			value: value,
 			color: red,
+			size:  1,
 		}, oldValue, existed
 	}
 
@@ -134,6 +156,7 @@ func (m *TreeMap[K, V]) put(h *mapNode[K, V], key K, value V) (*mapNode[K, V], V
 		existed = true
 		return h, oldValue, existed
 	}
+	updateMapNodeSize(h)
 
 	// Red-black tree balancing adjustment
 	if isRedMap(h.right) && !isRedMap(h.left) {
@@ -195,6 +218,7 @@ func (m *TreeMap[K, V]) moveRedRight(h *mapNode[K, V]) *mapNode[K, V] {
 
 // balance balance node
 func (m *TreeMap[K, V]) balance(h *mapNode[K, V]) *mapNode[K, V] {
+	updateMapNodeSize(h)
 	if isRedMap(h.right) {
 		h = m.rotateLeft(h)
 	}
@@ -231,82 +255,46 @@ func (m *TreeMap[K, V]) removeMin(h *mapNode[K, V], decreaseSize bool) *mapNode[
 }
 
 // remove remove node
-func (m *TreeMap[K, V]) remove(h *mapNode[K, V], key K) (*mapNode[K, V], V, bool) {
+// key must be present in the subtree rooted at h; the caller adjusts the size
+func (m *TreeMap[K, V]) remove(h *mapNode[K, V], key K) (*mapNode[K, V], V) {
 	var oldValue V
-	found := false
-
-	if h == nil {
-		return nil, oldValue, found
-	}
 
-	cmp := m.comparator(key, h.key)
-	if cmp < 0 {
-		if h.left != nil {
-			if !isRedMap(h.left) && !isRedMap(h.left.left) {
-				h = m.moveRedLeft(h)
-			}
-			// Key is in left subtree
-			h.left, oldValue, found = m.remove(h.left, key)
-			if h.left != nil {
-				h.left.parent = h
-			}
-		}
-	} else {
-		if isRedMap(h.left) {
-			h = m.rotateRight(h)
+	if m.comparator(key, h.key) < 0 {
+		if !isRedMap(h.left) && !isRedMap(h.left.left) {
+			h = m.moveRedLeft(h)
 		}
-		if cmp == 0 && h.right == nil {
-			// Key is in right subtree
-			oldValue = h.value
-			found = true
-			m.size--
-			return nil, oldValue, found
-		}
-		if h.right != nil {
-			if !isRedMap(h.right) && !isRedMap(h.right.left) {
-				h = m.moveRedRight(h)
-			}
-			cmp = m.comparator(key, h.key) // Recalculate comparison result
-			if cmp == 0 {
-				// Found node to delete
-				oldValue = h.value
-				found = true
-				m.size--
-
-				min := m.findMin(h.right)
-				h.key = min.key
-				h.value = min.value
-
-				// Delete minimum node from right subtree, but don't decrease size (since we just moved the node)
-				h.right = m.removeMin(h.right, false)
-				if h.right != nil {
-					h.right.parent = h
-				}
-			} else {
-				h.right, oldValue, found = m.remove(h.right, key)
-				if h.right != nil {
-					h.right.parent = h
-				}
-			}
+		// Key is in left subtree
+		h.left, oldValue = m.remove(h.left, key)
+		if h.left != nil {
+			h.left.parent = h
 		}
+		return m.balance(h), oldValue
 	}
 
-	if h == nil {
-		return nil, oldValue, found
+	if isRedMap(h.left) {
+		h = m.rotateRight(h)
 	}
-
-	if h.left == nil && h.right == nil {
-		// No right subtree, directly return left subtree
-		return nil, oldValue, found
+	// The rotation may have moved h, so compare again
+	if m.comparator(key, h.key) == 0 && h.right == nil {
+		return nil, h.value
 	}
-
-	if h.left == nil {
-		// No left subtree, directly return right subtree
-		return h.right, oldValue, found
+	if !isRedMap(h.right) && !isRedMap(h.right.left) {
+		h = m.moveRedRight(h)
 	}
-
-	// Has two subtrees, replace current node with minimum node from right subtree
-	return m.balance(h), oldValue, found
+	if m.comparator(key, h.key) == 0 {
+		// Found node to delete: replace it with the minimum of its right subtree
+		oldValue = h.value
+		min := m.findMin(h.right)
+		h.key = min.key
+		h.value = min.value
+		h.right = m.removeMin(h.right, false)
+	} else {
+		h.right, oldValue = m.remove(h.right, key)
+	}
+	if h.right != nil {
+		h.right.parent = h
+	}
+	return m.balance(h), oldValue
 }
 
 // removeMinWithoutSizeChange remove minimum node but don't change size
@@ -336,7 +324,15 @@ func (m *TreeMap[K, V]) Remove(key K) (V, bool) {
 		return oldValue, found
 	}
 
-	m.root, oldValue, found = m.remove(m.root, key)
+	if m.find(m.root, key) == nil {
+		return oldValue, found
+	}
+	if !isRedMap(m.root.left) && !isRedMap(m.root.right) {
+		m.root.color = red
+	}
+	m.root, oldValue = m.remove(m.root, key)
+	found = true
+	m.size--
 	if m.root != nil {
 		m.root.color = black
 		m.root.parent = nil
@@ -345,6 +341,56 @@ func (m *TreeMap[K, V]) Remove(key K) (V, bool) {
 	return oldValue, found
 }
 
+// RemoveIfValue removes the mapping for key only if it is currently mapped to expected
+// Returns true if the mapping was removed
+func (m *TreeMap[K, V]) RemoveIfValue(key K, expected V) bool {
+	node := m.find(m.root, key)
+	if node == nil || !common.Equal(node.value, expected) {
+		return false
+	}
+	m.Remove(key)
+	return true
+}
+
+// KeyRank returns the number of keys in this map that are strictly less than key
+// key need not be present; runs in O(log n) using per-node subtree sizes
+func (m *TreeMap[K, V]) KeyRank(key K) int {
+	rank := 0
+	node := m.root
+	for node != nil {
+		if m.comparator(key, node.key) <= 0 {
+			node = node.left
+		} else {
+			rank += mapNodeSize(node.left) + 1
+			node = node.right
+		}
+	}
+	return rank
+}
+
+// SelectKey returns the mapping with the k-th smallest key, counting from 0,
+// so SelectKey(KeyRank(key)) finds key for any key in the map
+// Returns false if k is out of range; runs in O(log n)
+func (m *TreeMap[K, V]) SelectKey(k int) (K, V, bool) {
+	node := m.root
+	if k >= 0 && k < mapNodeSize(node) {
+		for node != nil {
+			left := mapNodeSize(node.left)
+			if k < left {
+				node = node.left
+			} else if k == left {
+				return node.key, node.value, true
+			} else {
+				k -= left + 1
+				node = node.right
+			}
+		}
+	}
+	var key K
+	var value V
+	return key, value, false
+}
+
 // find find node
 func (m *TreeMap[K, V]) find(h *mapNode[K, V], key K) *mapNode[K, V] {
 	for h != nil {
@@ -369,6 +415,31 @@ func (m *TreeMap[K, V]) Get(key K) (V, bool) {
 	return *new(V), false
 }
 
+// GetAll returns the values mapped to the given keys, omitting keys that are absent
+func (m *TreeMap[K, V]) GetAll(keys []K) map[K]V {
+	result := make(map[K]V, len(keys))
+	for _, key := range keys {
+		if node := m.find(m.root, key); node != nil {
+			result[key] = node.value
+		}
+	}
+	return result
+}
+
+// GetAllOrDefault returns the values mapped to the given keys in key order,
+// using defaultValue for keys that are absent
+func (m *TreeMap[K, V]) GetAllOrDefault(keys []K, defaultValue V) []V {
+	values := make([]V, len(keys))
+	for i, key := range keys {
+		if node := m.find(m.root, key); node != nil {
+			values[i] = node.value
+		} else {
+			values[i] = defaultValue
+		}
+	}
+	return values
+}
+
 // ContainsKey if this map contains mapping relationship for the specified key, returns true
 func (m *TreeMap[K, V]) ContainsKey(key K) bool {
 	_, found := m.Get(key)
@@ -458,6 +529,51 @@ func (m *TreeMap[K, V]) ForEach(f func(K, V)) {
 	m.inOrderTraversalMap(m.root, f)
 }
 
+// EntryIterator returns an iterator over the entries of this map in key order
+// Entries are produced lazily from an explicit stack, so memory is bounded by the tree height
+// The map must not be modified while the iterator is in use
+func (m *TreeMap[K, V]) EntryIterator() common.Iterator[common.Entry[K, V]] {
+	it := &treeMapEntryIterator[K, V]{}
+	it.pushLeft(m.root)
+	return it
+}
+
+// treeMapEntryIterator implements an in-order Iterator over TreeMap entries
+type treeMapEntryIterator[K comparable, V any] struct {
+	stack []*mapNode[K, V]
+}
+
+// pushLeft pushes node and its chain of left children onto the stack
+func (it *treeMapEntryIterator[K, V]) pushLeft(node *mapNode[K, V]) {
+	for node != nil {
+		it.stack = append(it.stack, node)
+		node = node.left
+	}
+}
+
+// HasNext returns true if there are more entries to iterate
+func (it *treeMapEntryIterator[K, V]) HasNext() bool {
+	return len(it.stack) > 0
+}
+
+// Next returns the next entry in key order
+func (it *treeMapEntryIterator[K, V]) Next() (common.Entry[K, V], bool) {
+	if !it.HasNext() {
+		var zero common.Entry[K, V]
+		return zero, false
+	}
+	node := it.stack[len(it.stack)-1]
+	it.stack[len(it.stack)-1] = nil
+	it.stack = it.stack[:len(it.stack)-1]
+	it.pushLeft(node.right)
+	return common.NewEntry(node.key, node.value), true
+}
+
+// Remove removes the current entry (not supported)
+func (it *treeMapEntryIterator[K, V]) Remove() bool {
+	return false // Not supported
+}
+
 // ContainsValue if this map maps one or more keys to the specified value, returns true
 func (m *TreeMap[K, V]) ContainsValue(value V) bool {
     found := false
@@ -489,9 +605,343 @@ func (m *TreeMap[K, V]) String() string {
 	return builder.String()
 }
 
+// StringN returns a representation like String that shows at most n mappings in key order
+// The remaining mappings are summarised as "… (+k more)"
+func (m *TreeMap[K, V]) StringN(n int) string {
+	b := common.NewLimitedStringBuilder("{", n)
+	for it := m.EntryIterator(); it.HasNext() && !b.Full(); {
+		entry, _ := it.Next()
+		b.Addf("%v=%v", entry.Key, entry.Value)
+	}
+	return b.Finish("}", m.size)
+}
+
 // PutAll copies all mapping relationships from the specified map to this map
 func (m *TreeMap[K, V]) PutAll(other Map[K, V]) {
 	other.ForEach(func(k K, v V) {
 		m.Put(k, v)
 	})
 }
+
+// CeilingValue returns the value of the least key greater than or equal to key
+// Returns zero value and false if there is no such key
+func (m *TreeMap[K, V]) CeilingValue(key K) (V, bool) {
+	if node := m.ceilingNode(key); node != nil {
+		return node.value, true
+	}
+	var zero V
+	return zero, false
+}
+
+// FloorValue returns the value of the greatest key less than or equal to key
+// Returns zero value and false if there is no such key
+func (m *TreeMap[K, V]) FloorValue(key K) (V, bool) {
+	if node := m.floorNode(key); node != nil {
+		return node.value, true
+	}
+	var zero V
+	return zero, false
+}
+
+// ceilingNode returns the node with the least key greater than or equal to key, or nil
+func (m *TreeMap[K, V]) ceilingNode(key K) *mapNode[K, V] {
+	var ceiling *mapNode[K, V]
+	h := m.root
+	for h != nil {
+		cmp := m.comparator(key, h.key)
+		if cmp < 0 {
+			ceiling = h
+			h = h.left
+		} else if cmp > 0 {
+			h = h.right
+		} else {
+			return h
+		}
+	}
+	return ceiling
+}
+
+// floorNode returns the node with the greatest key less than or equal to key, or nil
+func (m *TreeMap[K, V]) floorNode(key K) *mapNode[K, V] {
+	var floor *mapNode[K, V]
+	h := m.root
+	for h != nil {
+		cmp := m.comparator(key, h.key)
+		if cmp > 0 {
+			floor = h
+			h = h.right
+		} else if cmp < 0 {
+			h = h.left
+		} else {
+			return h
+		}
+	}
+	return floor
+}
+
+// MergeWith returns a new TreeMap holding the entries of this map and other, ordered by this map's comparator
+// For keys present in both maps the value is resolve(key, thisValue, otherValue); a nil resolve lets other win
+// Neither this map nor other is modified
+func (m *TreeMap[K, V]) MergeWith(other Map[K, V], resolve func(k K, a, b V) V) *TreeMap[K, V] {
+	result := NewTreeMapWithComparator[K, V](m.comparator)
+	m.ForEach(func(k K, v V) {
+		result.Put(k, v)
+	})
+	if other == nil {
+		return result
+	}
+	other.ForEach(func(k K, v V) {
+		if resolve != nil {
+			if existing, ok := m.Get(k); ok {
+				v = resolve(k, existing, v)
+			}
+		}
+		result.Put(k, v)
+	})
+	return result
+}
+
+// Cursor is a position in a TreeMap that steps to neighbouring keys in order
+// Each step follows parent links and costs amortized O(1), O(log n) in the worst case
+// A cursor is invalidated by any modification of the map other than SetValue
+type Cursor[K comparable, V any] struct {
+	node *mapNode[K, V]
+}
+
+// CursorAt returns a cursor positioned at key, or at the least key greater than key if
+// key is absent; the cursor is not valid if no such key exists
+func (m *TreeMap[K, V]) CursorAt(key K) *Cursor[K, V] {
+	return &Cursor[K, V]{node: m.ceilingNode(key)}
+}
+
+// Valid returns true if the cursor is positioned at an entry
+func (c *Cursor[K, V]) Valid() bool {
+	return c.node != nil
+}
+
+// Key returns the key at the cursor, or the zero value if the cursor is not valid
+func (c *Cursor[K, V]) Key() K {
+	if c.node == nil {
+		var zero K
+		return zero
+	}
+	return c.node.key
+}
+
+// Value returns the value at the cursor, or the zero value if the cursor is not valid
+func (c *Cursor[K, V]) Value() V {
+	if c.node == nil {
+		var zero V
+		return zero
+	}
+	return c.node.value
+}
+
+// SetValue replaces the value at the cursor
+// Returns false if the cursor is not valid
+func (c *Cursor[K, V]) SetValue(value V) bool {
+	if c.node == nil {
+		return false
+	}
+	c.node.value = value
+	return true
+}
+
+// Next moves the cursor to the next greater key
+// Returns false and invalidates the cursor if there is no greater key
+func (c *Cursor[K, V]) Next() bool {
+	if c.node == nil {
+		return false
+	}
+	if c.node.right != nil {
+		node := c.node.right
+		for node.left != nil {
+			node = node.left
+		}
+		c.node = node
+		return true
+	}
+	node := c.node
+	for node.parent != nil && node == node.parent.right {
+		node = node.parent
+	}
+	c.node = node.parent
+	return c.node != nil
+}
+
+// Prev moves the cursor to the next smaller key
+// Returns false and invalidates the cursor if there is no smaller key
+func (c *Cursor[K, V]) Prev() bool {
+	if c.node == nil {
+		return false
+	}
+	if c.node.left != nil {
+		node := c.node.left
+		for node.right != nil {
+			node = node.right
+		}
+		c.node = node
+		return true
+	}
+	node := c.node
+	for node.parent != nil && node == node.parent.left {
+		node = node.parent
+	}
+	c.node = node.parent
+	return c.node != nil
+}
+
+// MarshalBinary encodes the mappings in key order using common.EncodeEntries
+func (m *TreeMap[K, V]) MarshalBinary() ([]byte, error) {
+	return common.EncodeEntries(m.Entries())
+}
+
+// UnmarshalBinary replaces the map contents with the mappings decoded from data
+// The map keeps its comparator; a zero-value TreeMap uses natural key ordering
+func (m *TreeMap[K, V]) UnmarshalBinary(data []byte) error {
+	entries, err := common.DecodeEntries[K, V](data)
+	if err != nil {
+		return err
+	}
+	if m.comparator == nil {
+		m.comparator = common.CompareNatural[K]
+	}
+	m.Clear()
+	for _, entry := range entries {
+		m.Put(entry.Key, entry.Value)
+	}
+	return nil
+}
+
+// UnionSorted returns a new TreeMap holding the union of the given tree maps
+// When a key occurs in several maps, the value from the last of them wins, as with repeated PutAll
+// The maps are combined with a single k-way merge over their in-order iterators and the
+// result tree is built directly from the merged run, avoiding one tree insertion per key
+// All inputs are expected to share the comparator of the first non-nil map
+func UnionSorted[K comparable, V any](ms ...*TreeMap[K, V]) *TreeMap[K, V] {
+	var result *TreeMap[K, V]
+	h := &mergeHeap[K, V]{}
+	total := 0
+	for _, m := range ms {
+		if m == nil {
+			continue
+		}
+		if result == nil {
+			result = NewTreeMapWithComparator[K, V](m.comparator)
+			h.comparator = m.comparator
+		}
+		total += m.size
+		it := m.EntryIterator()
+		if entry, ok := it.Next(); ok {
+			h.cursors = append(h.cursors, mergeCursor[K, V]{entry: entry, it: it, source: len(h.cursors)})
+		}
+	}
+	if result == nil {
+		return NewTreeMap[K, V]()
+	}
+	heap.Init(h)
+
+	// Equal keys pop in source order, so the last source overwrites earlier values
+	merged := make([]common.Entry[K, V], 0, total)
+	for h.Len() > 0 {
+		c := &h.cursors[0]
+		if n := len(merged); n > 0 && h.comparator(merged[n-1].Key, c.entry.Key) == 0 {
+			merged[n-1].Value = c.entry.Value
+		} else {
+			merged = append(merged, c.entry)
+		}
+		if entry, ok := c.it.Next(); ok {
+			c.entry = entry
+			heap.Fix(h, 0)
+		} else {
+			heap.Pop(h)
+		}
+	}
+
+	result.root = buildTreeMapNodes(merged, treeMapBlackHeight(len(merged)), nil)
+	result.size = len(merged)
+	return result
+}
+
+// mergeCursor is the head of one input of a k-way merge
+type mergeCursor[K comparable, V any] struct {
+	entry  common.Entry[K, V]
+	it     common.Iterator[common.Entry[K, V]]
+	source int
+}
+
+// mergeHeap is a container/heap of merge cursors ordered by key, then by source
+// The queue package cannot be used here because it depends on this package
+type mergeHeap[K comparable, V any] struct {
+	cursors    []mergeCursor[K, V]
+	comparator func(a, b K) int
+}
+
+func (h *mergeHeap[K, V]) Len() int { return len(h.cursors) }
+
+func (h *mergeHeap[K, V]) Less(i, j int) bool {
+	if c := h.comparator(h.cursors[i].entry.Key, h.cursors[j].entry.Key); c != 0 {
+		return c < 0
+	}
+	return h.cursors[i].source < h.cursors[j].source
+}
+
+func (h *mergeHeap[K, V]) Swap(i, j int) { h.cursors[i], h.cursors[j] = h.cursors[j], h.cursors[i] }
+
+func (h *mergeHeap[K, V]) Push(x interface{}) { h.cursors = append(h.cursors, x.(mergeCursor[K, V])) }
+
+func (h *mergeHeap[K, V]) Pop() interface{} {
+	last := h.cursors[len(h.cursors)-1]
+	h.cursors = h.cursors[:len(h.cursors)-1]
+	return last
+}
+
+// buildTreeMapNodes builds a left-leaning red-black tree from entries sorted by key
+// The tree is laid out as a 2-3 tree with every leaf at black height height; a 3-node
+// is a black node with a red left child, the same shape put maintains
+func buildTreeMapNodes[K comparable, V any](entries []common.Entry[K, V], height int, parent *mapNode[K, V]) *mapNode[K, V] {
+	n := len(entries)
+	if n == 0 {
+		return nil
+	}
+	// Each child subtree of black height height-1 holds at most 3^(height-1)-1 entries
+	childMax := pow3Saturating(height-1) - 1
+	if n-1 <= 2*childMax {
+		mid := (n - 1) / 2
+		node := &mapNode[K, V]{key: entries[mid].Key, value: entries[mid].Value, color: black, size: n, parent: parent}
+		node.left = buildTreeMapNodes(entries[:mid], height-1, node)
+		node.right = buildTreeMapNodes(entries[mid+1:], height-1, node)
+		return node
+	}
+	first := (n - 2) / 3
+	second := first + 1 + (n-2-first)/2
+	node := &mapNode[K, V]{key: entries[second].Key, value: entries[second].Value, color: black, size: n, parent: parent}
+	left := &mapNode[K, V]{key: entries[first].Key, value: entries[first].Value, color: red, size: second, parent: node}
+	left.left = buildTreeMapNodes(entries[:first], height-1, left)
+	left.right = buildTreeMapNodes(entries[first+1:second], height-1, left)
+	node.left = left
+	node.right = buildTreeMapNodes(entries[second+1:], height-1, node)
+	return node
+}
+
+// treeMapBlackHeight returns the largest black height whose smallest 2-3 tree fits in size entries
+func treeMapBlackHeight(size int) int {
+	height := 0
+	for (1<<(height+1))-1 <= size {
+		height++
+	}
+	return height
+}
+
+// pow3Saturating returns 3^exp, saturating at the maximum int
+func pow3Saturating(exp int) int {
+	const maxInt = int(^uint(0) >> 1)
+	result := 1
+	for i := 0; i < exp; i++ {
+		if result > maxInt/3 {
+			return maxInt
+		}
+		result *= 3
+	}
+	return result
+}
@@ -2,7 +2,12 @@ package maps
 
 import (
 	"fmt"
+	"math/rand"
+	"reflect"
+	"sort"
 	"testing"
+
+	"github.com/chenjianyu/collections/container/common"
 )
 
 func TestTreeMapNew(t *testing.T) {
@@ -473,3 +478,427 @@ func BenchmarkTreeMapRemove(b *testing.B) {
 		tm.Remove(i)
 	}
 }
+
+func TestTreeMapRemoveIfValue(t *testing.T) {
+	m := NewTreeMap[int, string]()
+	m.Put(1, "one")
+	m.Put(2, "two")
+	m.Put(3, "three")
+
+	if m.RemoveIfValue(2, "TWO") {
+		t.Error("Should not have removed with incorrect expected value")
+	}
+	if !m.RemoveIfValue(2, "two") {
+		t.Error("Should have removed with correct expected value")
+	}
+	keys := m.Keys()
+	if len(keys) != 2 || keys[0] != 1 || keys[1] != 3 {
+		t.Errorf("Keys should be [1 3], got %v", keys)
+	}
+}
+
+func TestTreeMapCursor(t *testing.T) {
+	m := NewTreeMap[int, string]()
+	for i := 10; i <= 50; i += 10 {
+		m.Put(i, fmt.Sprintf("v%d", i))
+	}
+
+	// Scan outward from a found key in both directions
+	c := m.CursorAt(30)
+	if !c.Valid() || c.Key() != 30 || c.Value() != "v30" {
+		t.Fatalf("Cursor should start at 30, got %d", c.Key())
+	}
+	if !c.Prev() || c.Key() != 20 || !c.Prev() || c.Key() != 10 {
+		t.Error("Prev should step to 20 then 10")
+	}
+	if c.Prev() || c.Valid() {
+		t.Error("Prev past the smallest key should invalidate the cursor")
+	}
+
+	// An absent key positions the cursor at its ceiling
+	c = m.CursorAt(35)
+	if c.Key() != 40 || !c.Next() || c.Key() != 50 || c.Next() {
+		t.Error("Cursor at 35 should start at 40 and end after 50")
+	}
+	if m.CursorAt(60).Valid() {
+		t.Error("Cursor beyond the largest key should not be valid")
+	}
+	if NewTreeMap[int, int]().CursorAt(1).Valid() {
+		t.Error("Cursor on an empty map should not be valid")
+	}
+
+	c = m.CursorAt(20)
+	if !c.SetValue("twenty") {
+		t.Error("SetValue on a valid cursor should succeed")
+	}
+	if v, _ := m.Get(20); v != "twenty" {
+		t.Errorf("SetValue should update the map, got %s", v)
+	}
+}
+
+func TestTreeMapCursorAfterRemovals(t *testing.T) {
+	m := NewTreeMap[int, int]()
+	for i := 0; i < 500; i++ {
+		m.Put((i*7919)%500, i)
+	}
+	for i := 0; i < 500; i += 3 {
+		m.Remove(i)
+	}
+
+	keys := m.Keys()
+	var forward []int
+	for c := m.CursorAt(keys[0]); c.Valid(); c.Next() {
+		forward = append(forward, c.Key())
+	}
+	var backward []int
+	for c := m.CursorAt(keys[len(keys)-1]); c.Valid(); c.Prev() {
+		backward = append(backward, c.Key())
+	}
+
+	if len(forward) != len(keys) || len(backward) != len(keys) {
+		t.Fatalf("Cursor should visit %d keys, got %d forward and %d backward", len(keys), len(forward), len(backward))
+	}
+	for i, key := range keys {
+		if forward[i] != key || backward[len(keys)-1-i] != key {
+			t.Fatalf("Cursor order differs from Keys at index %d", i)
+		}
+	}
+}
+
+func TestTreeMapEntryIterator(t *testing.T) {
+	m := NewTreeMap[int, string]()
+	for _, k := range []int{5, 3, 8, 1, 4, 7, 9, 2, 6} {
+		m.Put(k, fmt.Sprintf("v%d", k))
+	}
+
+	it := m.EntryIterator()
+	expected := 1
+	for it.HasNext() {
+		entry, ok := it.Next()
+		if !ok || entry.Key != expected || entry.Value != fmt.Sprintf("v%d", expected) {
+			t.Fatalf("Expected entry %d=v%d, got %v", expected, expected, entry)
+		}
+		expected++
+	}
+	if expected != 10 {
+		t.Errorf("Iterator should visit 9 entries, visited %d", expected-1)
+	}
+	if _, ok := it.Next(); ok {
+		t.Error("Next on an exhausted iterator should return false")
+	}
+	if it.Remove() {
+		t.Error("Remove is not supported")
+	}
+
+	if NewTreeMap[int, int]().EntryIterator().HasNext() {
+		t.Error("Iterator over an empty map should have no entries")
+	}
+}
+
+func TestTreeMapGetAll(t *testing.T) {
+	m := NewTreeMap[string, int]()
+	m.Put("a", 1)
+	m.Put("b", 2)
+	m.Put("c", 3)
+
+	found := m.GetAll([]string{"a", "c", "missing"})
+	if len(found) != 2 || found["a"] != 1 || found["c"] != 3 {
+		t.Errorf("GetAll should return only present keys, got %v", found)
+	}
+	if _, ok := found["missing"]; ok {
+		t.Error("GetAll should omit absent keys")
+	}
+
+	values := m.GetAllOrDefault([]string{"b", "missing", "a"}, -1)
+	if len(values) != 3 || values[0] != 2 || values[1] != -1 || values[2] != 1 {
+		t.Errorf("GetAllOrDefault should return [2 -1 1], got %v", values)
+	}
+	if len(m.GetAll(nil)) != 0 || len(m.GetAllOrDefault(nil, 0)) != 0 {
+		t.Error("Empty batches should return empty results")
+	}
+}
+
+func TestTreeMapMergeWith(t *testing.T) {
+	base := NewTreeMap[string, int]()
+	base.Put("timeout", 30)
+	base.Put("retries", 3)
+	base.Put("port", 80)
+
+	override := NewLinkedHashMap[string, int]()
+	override.Put("timeout", 60)
+	override.Put("workers", 8)
+
+	var conflicts []string
+	merged := base.MergeWith(override, func(k string, a, b int) int {
+		conflicts = append(conflicts, k)
+		if a > b {
+			return a
+		}
+		return b
+	})
+
+	expectedKeys := []string{"port", "retries", "timeout", "workers"}
+	keys := merged.Keys()
+	if fmt.Sprint(keys) != fmt.Sprint(expectedKeys) {
+		t.Errorf("Expected keys %v, got %v", expectedKeys, keys)
+	}
+	if v, _ := merged.Get("timeout"); v != 60 {
+		t.Errorf("Expected resolved timeout 60, got %d", v)
+	}
+	if len(conflicts) != 1 || conflicts[0] != "timeout" {
+		t.Errorf("Expected resolve to be called only for timeout, got %v", conflicts)
+	}
+	if v, _ := base.Get("timeout"); v != 30 || base.Size() != 3 {
+		t.Error("MergeWith should not modify the receiver")
+	}
+
+	lastWins := base.MergeWith(override, nil)
+	if v, _ := lastWins.Get("timeout"); v != 60 || lastWins.Size() != 4 {
+		t.Errorf("Nil resolve should let other win, got timeout %d and size %d", v, lastWins.Size())
+	}
+}
+
+func TestTreeMapCeilingAndFloorValue(t *testing.T) {
+	m := NewTreeMap[int, string]()
+	m.Put(100, "v1")
+	m.Put(200, "v2")
+	m.Put(300, "v3")
+
+	floorCases := map[int]string{100: "v1", 150: "v1", 200: "v2", 299: "v2", 1000: "v3"}
+	for key, expected := range floorCases {
+		if v, ok := m.FloorValue(key); !ok || v != expected {
+			t.Errorf("FloorValue(%d): expected %s, got %s, %v", key, expected, v, ok)
+		}
+	}
+	ceilingCases := map[int]string{0: "v1", 100: "v1", 101: "v2", 250: "v3", 300: "v3"}
+	for key, expected := range ceilingCases {
+		if v, ok := m.CeilingValue(key); !ok || v != expected {
+			t.Errorf("CeilingValue(%d): expected %s, got %s, %v", key, expected, v, ok)
+		}
+	}
+
+	if _, ok := m.FloorValue(99); ok {
+		t.Error("FloorValue below the smallest key should return false")
+	}
+	if _, ok := m.CeilingValue(301); ok {
+		t.Error("CeilingValue above the largest key should return false")
+	}
+	if _, ok := NewTreeMap[int, string]().FloorValue(1); ok {
+		t.Error("FloorValue on an empty map should return false")
+	}
+}
+
+func TestTreeMapBinaryRoundTrip(t *testing.T) {
+	m := NewTreeMap[string, float64]()
+	m.Put("pi", 3.14159)
+	m.Put("e", 2.71828)
+	m.Put("zero", 0)
+
+	var _ common.Serializable = m
+	data, err := m.MarshalBinary()
+	if err != nil {
+		t.Fatalf("MarshalBinary failed: %v", err)
+	}
+
+	var restored TreeMap[string, float64]
+	if err := restored.UnmarshalBinary(data); err != nil {
+		t.Fatalf("UnmarshalBinary failed: %v", err)
+	}
+	if restored.String() != m.String() {
+		t.Errorf("Expected %v, got %v", m, &restored)
+	}
+}
+
+func TestTreeMapStringN(t *testing.T) {
+	m := NewTreeMap[int, string]()
+	for i := 3; i >= 1; i-- {
+		m.Put(i, fmt.Sprint("v", i))
+	}
+	if got := m.StringN(1); got != "{1=v1, … (+2 more)}" {
+		t.Errorf("Expected truncated listing in key order, got %q", got)
+	}
+	if got := m.StringN(3); got != m.String() {
+		t.Errorf("StringN at the size should match String, got %q", got)
+	}
+}
+
+// checkRedBlack returns the black height of node, or -1 if a left-leaning red-black invariant is broken
+func checkRedBlack[K comparable, V any](node *mapNode[K, V]) int {
+	if node == nil {
+		return 1
+	}
+	if isRedMap(node.right) || (node.color == red && isRedMap(node.left)) {
+		return -1
+	}
+	if (node.left != nil && node.left.parent != node) || (node.right != nil && node.right.parent != node) {
+		return -1
+	}
+	if node.size != mapNodeSize(node.left)+mapNodeSize(node.right)+1 {
+		return -1
+	}
+	left, right := checkRedBlack(node.left), checkRedBlack(node.right)
+	if left < 0 || left != right {
+		return -1
+	}
+	if node.color == black {
+		return left + 1
+	}
+	return left
+}
+
+func TestTreeMapUnionSorted(t *testing.T) {
+	a := NewTreeMap[int, string]()
+	b := NewTreeMap[int, string]()
+	c := NewTreeMap[int, string]()
+	for i := 0; i < 100; i += 2 {
+		a.Put(i, "a")
+	}
+	for i := 0; i < 100; i += 3 {
+		b.Put(i, "b")
+	}
+	c.Put(6, "c")
+	c.Put(1000, "c")
+
+	u := UnionSorted(a, nil, b, c)
+	want := NewTreeMap[int, string]()
+	want.PutAll(a)
+	want.PutAll(b)
+	want.PutAll(c)
+	if u.Size() != want.Size() {
+		t.Fatalf("UnionSorted size should be %d, got %d", want.Size(), u.Size())
+	}
+	if !reflect.DeepEqual(u.Keys(), want.Keys()) || !reflect.DeepEqual(u.Values(), want.Values()) {
+		t.Error("UnionSorted should match repeated PutAll")
+	}
+	if v, _ := u.Get(6); v != "c" {
+		t.Errorf("the last map should win for key 6, got %s", v)
+	}
+	if v, _ := u.Get(4); v != "a" {
+		t.Errorf("key 4 should keep a's value, got %s", v)
+	}
+
+	for n := 0; n < 200; n++ {
+		m := NewTreeMap[int, int]()
+		for i := 0; i < n; i++ {
+			m.Put(i, i)
+		}
+		built := UnionSorted(m)
+		if checkRedBlack(built.root) < 0 {
+			t.Fatalf("UnionSorted of %d keys should build a valid red-black tree", n)
+		}
+		if !reflect.DeepEqual(built.Keys(), m.Keys()) {
+			t.Fatalf("UnionSorted of %d keys should keep every key in order", n)
+		}
+	}
+
+	// The built tree stays valid under further inserts
+	for i := -50; i < 300; i += 3 {
+		u.Put(i, "x")
+	}
+	if checkRedBlack(u.root) < 0 {
+		t.Error("UnionSorted tree should stay valid after inserts")
+	}
+	if UnionSorted[int, string]().Size() != 0 {
+		t.Error("UnionSorted of no maps should be empty")
+	}
+}
+
+func TestTreeMapKeyRankSelectKey(t *testing.T) {
+	m := NewTreeMap[int, string]()
+	if _, _, ok := m.SelectKey(0); ok {
+		t.Error("SelectKey on an empty map should report false")
+	}
+
+	r := rand.New(rand.NewSource(11))
+	reference := make(map[int]bool)
+	for i := 0; i < 2000; i++ {
+		k := r.Intn(5000)
+		m.Put(k, fmt.Sprint(k))
+		reference[k] = true
+	}
+	if checkRedBlack(m.root) < 0 {
+		t.Fatal("tree should stay a valid red-black tree with consistent subtree sizes")
+	}
+	for i := 0; i < 1500; i++ {
+		k := r.Intn(5000)
+		if _, found := m.Remove(k); found != reference[k] {
+			t.Fatalf("Remove(%d) reported found=%v, want %v", k, found, reference[k])
+		}
+		delete(reference, k)
+	}
+	if checkRedBlack(m.root) < 0 {
+		t.Fatal("tree should stay a valid red-black tree with consistent subtree sizes after removals")
+	}
+	if m.Size() != len(reference) {
+		t.Fatalf("Size should be %d, got %d", len(reference), m.Size())
+	}
+
+	keys := make([]int, 0, len(reference))
+	for k := range reference {
+		keys = append(keys, k)
+	}
+	sort.Ints(keys)
+	if !reflect.DeepEqual(m.Keys(), keys) {
+		t.Fatal("Keys should match the reference model")
+	}
+	for k, want := range keys {
+		got, value, ok := m.SelectKey(k)
+		if !ok || got != want || value != fmt.Sprint(want) {
+			t.Fatalf("SelectKey(%d) should be %d, got %d=%s (ok=%v)", k, want, got, value, ok)
+		}
+		if rank := m.KeyRank(want); rank != k {
+			t.Fatalf("KeyRank(%d) should be %d, got %d", want, k, rank)
+		}
+	}
+	if _, _, ok := m.SelectKey(m.Size()); ok {
+		t.Error("SelectKey past the end should report false")
+	}
+	if _, _, ok := m.SelectKey(-1); ok {
+		t.Error("SelectKey with a negative index should report false")
+	}
+	if m.KeyRank(-1) != 0 || m.KeyRank(5000) != m.Size() {
+		t.Errorf("KeyRank outside the key range should be 0 and %d, got %d and %d", m.Size(), m.KeyRank(-1), m.KeyRank(5000))
+	}
+
+	u := UnionSorted(m, NewTreeMap[int, string]())
+	if k, _, _ := u.SelectKey(len(keys) / 2); k != keys[len(keys)/2] || u.KeyRank(keys[7]) != 7 {
+		t.Error("UnionSorted should build consistent subtree sizes")
+	}
+}
+
+func TestTreeMapRemoveKeepsTreeValid(t *testing.T) {
+	m := NewTreeMap[int, int]()
+	reference := make(map[int]int)
+	r := rand.New(rand.NewSource(5))
+	for i := 0; i < 20000; i++ {
+		k := r.Intn(2000)
+		if r.Intn(2) == 0 {
+			m.Put(k, i)
+			reference[k] = i
+		} else {
+			_, found := m.Remove(k)
+			_, want := reference[k]
+			if found != want {
+				t.Fatalf("Remove(%d) reported found=%v, want %v", k, found, want)
+			}
+			delete(reference, k)
+		}
+	}
+	if checkRedBlack(m.root) < 0 {
+		t.Fatal("tree should stay a valid red-black tree")
+	}
+	if m.Size() != len(reference) || len(m.Keys()) != len(reference) {
+		t.Fatalf("Size should be %d, got %d with %d keys", len(reference), m.Size(), len(m.Keys()))
+	}
+	for k, v := range reference {
+		if got, ok := m.Get(k); !ok || got != v {
+			t.Fatalf("Get(%d) should be %d, got %d (ok=%v)", k, v, got, ok)
+		}
+	}
+	for k := range reference {
+		m.Remove(k)
+	}
+	if !m.IsEmpty() || m.root != nil {
+		t.Errorf("map should be empty after removing every key, got size %d", m.Size())
+	}
+}
@@ -0,0 +1,104 @@
+package maps
+
+import "time"
+
+// WindowedCounter counts events per key within fixed time windows
+// Windows are aligned to multiples of the window length since the Unix epoch, and a key's
+// count starts again from zero in each new window, which suits fixed-window rate limiting
+// It is safe for concurrent use; counts of different keys are updated in parallel
+type WindowedCounter[K comparable] struct {
+	counts *ConcurrentHashMap[K, windowSlot]
+	window time.Duration
+	now    func() time.Time
+}
+
+// windowSlot is a key's count within the window it was last incremented in
+type windowSlot struct {
+	window int64 // Index of the window since the Unix epoch
+	count  int
+}
+
+// NewWindowedCounter creates a WindowedCounter with the given window length
+// A non-positive window is treated as one second
+func NewWindowedCounter[K comparable](window time.Duration) *WindowedCounter[K] {
+	if window <= 0 {
+		window = time.Second
+	}
+	return &WindowedCounter[K]{
+		counts: NewConcurrentHashMap[K, windowSlot](),
+		window: window,
+		now:    time.Now,
+	}
+}
+
+// Window returns the window length
+func (c *WindowedCounter[K]) Window() time.Duration {
+	return c.window
+}
+
+// Incr adds one to key's count in the current window and returns the new count
+func (c *WindowedCounter[K]) Incr(key K) int {
+	return c.Add(key, 1)
+}
+
+// Add adds delta to key's count in the current window and returns the new count
+func (c *WindowedCounter[K]) Add(key K, delta int) int {
+	current := c.currentWindow()
+	var result int
+	c.counts.WithKeyLocked(key, func(get func() (windowSlot, bool), put func(windowSlot), remove func()) {
+		slot, ok := get()
+		if !ok || slot.window != current {
+			slot = windowSlot{window: current}
+		}
+		slot.count += delta
+		put(slot)
+		result = slot.count
+	})
+	return result
+}
+
+// Count returns key's count in the current window, or 0 if it has none
+func (c *WindowedCounter[K]) Count(key K) int {
+	slot, ok := c.counts.Get(key)
+	if !ok || slot.window != c.currentWindow() {
+		return 0
+	}
+	return slot.count
+}
+
+// Reset clears key's count
+func (c *WindowedCounter[K]) Reset(key K) {
+	c.counts.Remove(key)
+}
+
+// ResetAll clears every count
+func (c *WindowedCounter[K]) ResetAll() {
+	c.counts.Clear()
+}
+
+// Remaining returns when the current window ends
+func (c *WindowedCounter[K]) Remaining() time.Duration {
+	now := c.now().UnixNano()
+	return c.window - time.Duration(now%int64(c.window))
+}
+
+// Sweep removes the keys whose counts belong to a past window and returns how many were removed
+// Stale counts already read as zero; Sweep only releases their memory
+func (c *WindowedCounter[K]) Sweep() int {
+	current := c.currentWindow()
+	removed := 0
+	for _, key := range c.counts.Keys() {
+		c.counts.WithKeyLocked(key, func(get func() (windowSlot, bool), put func(windowSlot), remove func()) {
+			if slot, ok := get(); ok && slot.window != current {
+				remove()
+				removed++
+			}
+		})
+	}
+	return removed
+}
+
+// currentWindow returns the index of the window containing the current time
+func (c *WindowedCounter[K]) currentWindow() int64 {
+	return c.now().UnixNano() / int64(c.window)
+}
@@ -0,0 +1,71 @@
+package maps
+
+import (
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestWindowedCounter(t *testing.T) {
+	current := time.Unix(1000, 0)
+	c := NewWindowedCounter[string](time.Minute)
+	c.now = func() time.Time { return current }
+
+	for i := 1; i <= 3; i++ {
+		if n := c.Incr("client-a"); n != i {
+			t.Errorf("Expected count %d, got %d", i, n)
+		}
+	}
+	c.Add("client-b", 5)
+	if c.Count("client-a") != 3 || c.Count("client-b") != 5 || c.Count("client-c") != 0 {
+		t.Error("Counts should be tracked per key")
+	}
+
+	// 1000s is 40s into its minute, so the window ends in 20s
+	if r := c.Remaining(); r != 20*time.Second {
+		t.Errorf("Expected 20s remaining, got %v", r)
+	}
+
+	current = current.Add(20 * time.Second)
+	if c.Count("client-a") != 0 {
+		t.Error("Counts should reset when a new window starts")
+	}
+	if n := c.Incr("client-a"); n != 1 {
+		t.Errorf("First increment in a new window should return 1, got %d", n)
+	}
+
+	if removed := c.Sweep(); removed != 1 {
+		t.Errorf("Sweep should remove the stale client-b count, removed %d", removed)
+	}
+	c.Reset("client-a")
+	if c.Count("client-a") != 0 {
+		t.Error("Reset should clear the key's count")
+	}
+
+	if NewWindowedCounter[int](0).Window() != time.Second {
+		t.Error("Non-positive window should default to one second")
+	}
+}
+
+func TestWindowedCounterConcurrentIncr(t *testing.T) {
+	c := NewWindowedCounter[int](time.Hour)
+	c.now = func() time.Time { return time.Unix(0, 0) }
+
+	var wg sync.WaitGroup
+	for g := 0; g < 8; g++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 1000; i++ {
+				c.Incr(i % 10)
+			}
+		}()
+	}
+	wg.Wait()
+
+	for k := 0; k < 10; k++ {
+		if n := c.Count(k); n != 800 {
+			t.Errorf("Key %d: expected 800, got %d", k, n)
+		}
+	}
+}
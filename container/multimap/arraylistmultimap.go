@@ -66,6 +66,31 @@ func (m *ArrayListMultimap[K, V]) PutAll(multimap Multimap[K, V]) bool {
 	return changed
 }
 
+// PutValues adds a mapping from key to each of values and returns how many were added
+func (m *ArrayListMultimap[K, V]) PutValues(key K, values []V) int {
+	if len(values) == 0 {
+		return 0
+	}
+
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	existing, exists := m.data[key]
+	if !exists {
+		existing = list.New[V]()
+		m.data[key] = existing
+	}
+
+	added := 0
+	for _, value := range values {
+		if existing.Add(value) {
+			added++
+		}
+	}
+	m.size += added
+	return added
+}
+
 // ReplaceValues replaces all values for a key with the specified collection of values
 func (m *ArrayListMultimap[K, V]) ReplaceValues(key K, values []V) []V {
 	m.mutex.Lock()
@@ -160,6 +185,20 @@ func (m *ArrayListMultimap[K, V]) ContainsValue(value V) bool {
 	return false
 }
 
+// KeysForValue returns the distinct keys that have at least one mapping to value
+func (m *ArrayListMultimap[K, V]) KeysForValue(value V) []K {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	keys := make([]K, 0)
+	for key, values := range m.data {
+		if values.Contains(value) {
+			keys = append(keys, key)
+		}
+	}
+	return keys
+}
+
 // ContainsEntry returns true if this multimap contains the specified key-value mapping
 func (m *ArrayListMultimap[K, V]) ContainsEntry(key K, value V) bool {
 	m.mutex.RLock()
@@ -186,6 +225,20 @@ func (m *ArrayListMultimap[K, V]) Get(key K) []V {
 	return values.ToSlice()
 }
 
+// GetList returns a copy of the values associated with key as an ArrayList
+func (m *ArrayListMultimap[K, V]) GetList(key K) list.List[V] {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	result := list.New[V]()
+	if values, exists := m.data[key]; exists {
+		values.ForEach(func(value V) {
+			result.Add(value)
+		})
+	}
+	return result
+}
+
 // Keys returns all distinct keys in this multimap
 func (m *ArrayListMultimap[K, V]) Keys() []K {
 	m.mutex.RLock()
@@ -257,6 +310,16 @@ func (m *ArrayListMultimap[K, V]) ForEach(f func(K, V)) {
 	}
 }
 
+// ForEachKey executes the given function once for each distinct key with a copy of all its values
+func (m *ArrayListMultimap[K, V]) ForEachKey(f func(K, []V)) {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	for key, values := range m.data {
+		f(key, values.ToSlice())
+	}
+}
+
 // Size returns the number of key-value mappings in this multimap
 func (m *ArrayListMultimap[K, V]) Size() int {
 	m.mutex.RLock()
@@ -265,6 +328,28 @@ func (m *ArrayListMultimap[K, V]) Size() int {
 	return m.size
 }
 
+// EntryCount returns the total number of key-value pairs in this multimap
+func (m *ArrayListMultimap[K, V]) EntryCount() int {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	return m.size
+}
+
+// DistinctValueCount returns the number of distinct values across all keys
+func (m *ArrayListMultimap[K, V]) DistinctValueCount() int {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	seen := make(map[V]struct{})
+	for _, values := range m.data {
+		values.ForEach(func(value V) {
+			seen[value] = struct{}{}
+		})
+	}
+	return len(seen)
+}
+
 // IsEmpty returns true if this multimap contains no key-value mappings
 func (m *ArrayListMultimap[K, V]) IsEmpty() bool {
 	m.mutex.RLock()
@@ -2,6 +2,7 @@ package multimap
 
 import (
     "fmt"
+    "sort"
     "strings"
     "sync"
 
@@ -66,6 +67,31 @@ func (m *HashMultimap[K, V]) PutAll(multimap Multimap[K, V]) bool {
 	return changed
 }
 
+// PutValues adds a mapping from key to each of values and returns how many were added
+func (m *HashMultimap[K, V]) PutValues(key K, values []V) int {
+	if len(values) == 0 {
+		return 0
+	}
+
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	existing, exists := m.data[key]
+	if !exists {
+		existing = set.New[V]()
+		m.data[key] = existing
+	}
+
+	added := 0
+	for _, value := range values {
+		if existing.Add(value) {
+			added++
+		}
+	}
+	m.size += added
+	return added
+}
+
 // ReplaceValues replaces all values for a key with the specified collection of values
 func (m *HashMultimap[K, V]) ReplaceValues(key K, values []V) []V {
 	m.mutex.Lock()
@@ -160,6 +186,20 @@ func (m *HashMultimap[K, V]) ContainsValue(value V) bool {
 	return false
 }
 
+// KeysForValue returns the distinct keys that have at least one mapping to value
+func (m *HashMultimap[K, V]) KeysForValue(value V) []K {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	keys := make([]K, 0)
+	for key, values := range m.data {
+		if values.Contains(value) {
+			keys = append(keys, key)
+		}
+	}
+	return keys
+}
+
 // ContainsEntry returns true if this multimap contains the specified key-value mapping
 func (m *HashMultimap[K, V]) ContainsEntry(key K, value V) bool {
 	m.mutex.RLock()
@@ -186,6 +226,20 @@ func (m *HashMultimap[K, V]) Get(key K) []V {
 	return values.ToSlice()
 }
 
+// GetSet returns a copy of the values associated with key as a HashSet
+func (m *HashMultimap[K, V]) GetSet(key K) set.Set[V] {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	result := set.New[V]()
+	if values, exists := m.data[key]; exists {
+		values.ForEach(func(value V) {
+			result.Add(value)
+		})
+	}
+	return result
+}
+
 // Keys returns all distinct keys in this multimap
 func (m *HashMultimap[K, V]) Keys() []K {
 	m.mutex.RLock()
@@ -257,6 +311,43 @@ func (m *HashMultimap[K, V]) ForEach(f func(K, V)) {
 	}
 }
 
+// ForEachKey executes the given function once for each distinct key with a copy of all its values
+func (m *HashMultimap[K, V]) ForEachKey(f func(K, []V)) {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	for key, values := range m.data {
+		f(key, values.ToSlice())
+	}
+}
+
+// SortedEntries returns all key-value pairs ordered by keyCmp, then by valueCmp within a key
+// A nil comparator falls back to natural order (see common.CompareNatural)
+func (m *HashMultimap[K, V]) SortedEntries(keyCmp func(a, b K) int, valueCmp func(a, b V) int) []common.Entry[K, V] {
+	if keyCmp == nil {
+		keyCmp = common.CompareNatural[K]
+	}
+	if valueCmp == nil {
+		valueCmp = common.CompareNatural[V]
+	}
+	entries := m.Entries()
+	sort.SliceStable(entries, func(i, j int) bool {
+		if c := keyCmp(entries[i].Key, entries[j].Key); c != 0 {
+			return c < 0
+		}
+		return valueCmp(entries[i].Value, entries[j].Value) < 0
+	})
+	return entries
+}
+
+// ForEachSorted executes the given function for each key-value pair in the order of SortedEntries
+// The function runs on a snapshot, so it may modify this multimap
+func (m *HashMultimap[K, V]) ForEachSorted(keyCmp func(a, b K) int, valueCmp func(a, b V) int, f func(K, V)) {
+	for _, entry := range m.SortedEntries(keyCmp, valueCmp) {
+		f(entry.Key, entry.Value)
+	}
+}
+
 // Size returns the number of key-value mappings in this multimap
 func (m *HashMultimap[K, V]) Size() int {
 	m.mutex.RLock()
@@ -265,6 +356,28 @@ func (m *HashMultimap[K, V]) Size() int {
 	return m.size
 }
 
+// EntryCount returns the total number of key-value pairs in this multimap
+func (m *HashMultimap[K, V]) EntryCount() int {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	return m.size
+}
+
+// DistinctValueCount returns the number of distinct values across all keys
+func (m *HashMultimap[K, V]) DistinctValueCount() int {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	seen := make(map[V]struct{})
+	for _, values := range m.data {
+		values.ForEach(func(value V) {
+			seen[value] = struct{}{}
+		})
+	}
+	return len(seen)
+}
+
 // IsEmpty returns true if this multimap contains no key-value mappings
 func (m *HashMultimap[K, V]) IsEmpty() bool {
 	m.mutex.RLock()
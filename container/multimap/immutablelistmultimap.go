@@ -5,6 +5,7 @@ import (
     "strings"
 
     "github.com/chenjianyu/collections/container/common"
+    "github.com/chenjianyu/collections/container/list"
 )
 
 // ImmutableListMultimap is an immutable implementation of a multimap that preserves duplicate values and insertion order
@@ -72,6 +73,11 @@ func (m *ImmutableListMultimap[K, V]) PutAll(multimap Multimap[K, V]) bool {
 	return false
 }
 
+// PutValues is not supported on an immutable multimap and always returns 0
+func (m *ImmutableListMultimap[K, V]) PutValues(key K, values []V) int {
+	return 0
+}
+
 // ReplaceValues returns nil as ImmutableListMultimap is immutable
 func (m *ImmutableListMultimap[K, V]) ReplaceValues(key K, values []V) []V {
 	return nil
@@ -105,6 +111,22 @@ func (m *ImmutableListMultimap[K, V]) ContainsValue(value V) bool {
 	return false
 }
 
+// KeysForValue returns the distinct keys that have at least one mapping to value, in entry order
+func (m *ImmutableListMultimap[K, V]) KeysForValue(value V) []K {
+	keys := make([]K, 0)
+	seen := make(map[K]struct{})
+	for _, entry := range m.entries {
+		if entry.Value != value {
+			continue
+		}
+		if _, ok := seen[entry.Key]; !ok {
+			seen[entry.Key] = struct{}{}
+			keys = append(keys, entry.Key)
+		}
+	}
+	return keys
+}
+
 // ContainsEntry returns true if this multimap contains the specified key-value mapping
 func (m *ImmutableListMultimap[K, V]) ContainsEntry(key K, value V) bool {
 	values, exists := m.data[key]
@@ -134,6 +156,11 @@ func (m *ImmutableListMultimap[K, V]) Get(key K) []V {
 	return result
 }
 
+// GetList returns the values associated with key as an ImmutableList
+func (m *ImmutableListMultimap[K, V]) GetList(key K) list.List[V] {
+	return list.NewImmutableListFromSlice(m.data[key])
+}
+
 // Keys returns all keys in this multimap, including duplicates for each value
 func (m *ImmutableListMultimap[K, V]) Keys() []K {
 	keys := make([]K, 0, len(m.entries))
@@ -188,11 +215,31 @@ func (m *ImmutableListMultimap[K, V]) ForEach(f func(K, V)) {
 	}
 }
 
+// ForEachKey executes the given function once for each distinct key with a copy of all its values
+// Keys are visited in order of their first entry
+func (m *ImmutableListMultimap[K, V]) ForEachKey(f func(K, []V)) {
+	forEachKeyInEntryOrder(m.entries, m.data, f)
+}
+
 // Size returns the number of key-value mappings in this multimap
 func (m *ImmutableListMultimap[K, V]) Size() int {
 	return len(m.entries)
 }
 
+// EntryCount returns the total number of key-value pairs in this multimap
+func (m *ImmutableListMultimap[K, V]) EntryCount() int {
+	return len(m.entries)
+}
+
+// DistinctValueCount returns the number of distinct values across all keys
+func (m *ImmutableListMultimap[K, V]) DistinctValueCount() int {
+	seen := make(map[V]struct{}, len(m.entries))
+	for _, entry := range m.entries {
+		seen[entry.Value] = struct{}{}
+	}
+	return len(seen)
+}
+
 // IsEmpty returns true if this multimap contains no key-value mappings
 func (m *ImmutableListMultimap[K, V]) IsEmpty() bool {
 	return len(m.entries) == 0
@@ -69,6 +69,11 @@ func (m *ImmutableMultimap[K, V]) PutAll(multimap Multimap[K, V]) bool {
 	return false
 }
 
+// PutValues is not supported on an immutable multimap and always returns 0
+func (m *ImmutableMultimap[K, V]) PutValues(key K, values []V) int {
+	return 0
+}
+
 // ReplaceValues is not supported for ImmutableMultimap and returns nil
 func (m *ImmutableMultimap[K, V]) ReplaceValues(key K, values []V) []V {
 	// Return nil to indicate the operation failed
@@ -105,6 +110,22 @@ func (m *ImmutableMultimap[K, V]) ContainsValue(value V) bool {
 	return false
 }
 
+// KeysForValue returns the distinct keys that have at least one mapping to value, in entry order
+func (m *ImmutableMultimap[K, V]) KeysForValue(value V) []K {
+	keys := make([]K, 0)
+	seen := make(map[K]struct{})
+	for _, entry := range m.entries {
+		if entry.Value != value {
+			continue
+		}
+		if _, ok := seen[entry.Key]; !ok {
+			seen[entry.Key] = struct{}{}
+			keys = append(keys, entry.Key)
+		}
+	}
+	return keys
+}
+
 // ContainsEntry returns true if this multimap contains the specified key-value mapping
 func (m *ImmutableMultimap[K, V]) ContainsEntry(key K, value V) bool {
 	values, exists := m.data[key]
@@ -184,11 +205,31 @@ func (m *ImmutableMultimap[K, V]) ForEach(f func(K, V)) {
 	}
 }
 
+// ForEachKey executes the given function once for each distinct key with a copy of all its values
+// Keys are visited in order of their first entry
+func (m *ImmutableMultimap[K, V]) ForEachKey(f func(K, []V)) {
+	forEachKeyInEntryOrder(m.entries, m.data, f)
+}
+
 // Size returns the number of key-value mappings in this multimap
 func (m *ImmutableMultimap[K, V]) Size() int {
 	return len(m.entries)
 }
 
+// EntryCount returns the total number of key-value pairs in this multimap
+func (m *ImmutableMultimap[K, V]) EntryCount() int {
+	return len(m.entries)
+}
+
+// DistinctValueCount returns the number of distinct values across all keys
+func (m *ImmutableMultimap[K, V]) DistinctValueCount() int {
+	seen := make(map[V]struct{}, len(m.entries))
+	for _, entry := range m.entries {
+		seen[entry.Value] = struct{}{}
+	}
+	return len(seen)
+}
+
 // IsEmpty returns true if this multimap contains no key-value mappings
 func (m *ImmutableMultimap[K, V]) IsEmpty() bool {
 	return len(m.entries) == 0
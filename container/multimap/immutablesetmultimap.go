@@ -92,6 +92,11 @@ func (m *ImmutableSetMultimap[K, V]) PutAll(multimap Multimap[K, V]) bool {
 	return false
 }
 
+// PutValues is not supported on an immutable multimap and always returns 0
+func (m *ImmutableSetMultimap[K, V]) PutValues(key K, values []V) int {
+	return 0
+}
+
 // ReplaceValues is not supported for ImmutableSetMultimap and returns nil
 func (m *ImmutableSetMultimap[K, V]) ReplaceValues(key K, values []V) []V {
 	// Return nil to indicate the operation failed
@@ -128,6 +133,22 @@ func (m *ImmutableSetMultimap[K, V]) ContainsValue(value V) bool {
 	return false
 }
 
+// KeysForValue returns the distinct keys that have at least one mapping to value, in entry order
+func (m *ImmutableSetMultimap[K, V]) KeysForValue(value V) []K {
+	keys := make([]K, 0)
+	seen := make(map[K]struct{})
+	for _, entry := range m.entries {
+		if entry.Value != value {
+			continue
+		}
+		if _, ok := seen[entry.Key]; !ok {
+			seen[entry.Key] = struct{}{}
+			keys = append(keys, entry.Key)
+		}
+	}
+	return keys
+}
+
 // ContainsEntry returns true if this multimap contains the specified key-value mapping
 func (m *ImmutableSetMultimap[K, V]) ContainsEntry(key K, value V) bool {
 	set, exists := m.sets[key]
@@ -151,6 +172,11 @@ func (m *ImmutableSetMultimap[K, V]) Get(key K) []V {
 	return result
 }
 
+// GetSet returns the values associated with key as an ImmutableSet
+func (m *ImmutableSetMultimap[K, V]) GetSet(key K) set.Set[V] {
+	return set.NewImmutableSetFromSlice(m.data[key])
+}
+
 // Keys returns all distinct keys in this multimap
 func (m *ImmutableSetMultimap[K, V]) Keys() []K {
 	keys := make([]K, 0, len(m.data))
@@ -201,11 +227,31 @@ func (m *ImmutableSetMultimap[K, V]) ForEach(f func(K, V)) {
 	}
 }
 
+// ForEachKey executes the given function once for each distinct key with a copy of all its values
+// Keys are visited in order of their first entry
+func (m *ImmutableSetMultimap[K, V]) ForEachKey(f func(K, []V)) {
+	forEachKeyInEntryOrder(m.entries, m.data, f)
+}
+
 // Size returns the number of key-value mappings in this multimap
 func (m *ImmutableSetMultimap[K, V]) Size() int {
 	return len(m.entries)
 }
 
+// EntryCount returns the total number of key-value pairs in this multimap
+func (m *ImmutableSetMultimap[K, V]) EntryCount() int {
+	return len(m.entries)
+}
+
+// DistinctValueCount returns the number of distinct values across all keys
+func (m *ImmutableSetMultimap[K, V]) DistinctValueCount() int {
+	seen := make(map[V]struct{}, len(m.entries))
+	for _, entry := range m.entries {
+		seen[entry.Value] = struct{}{}
+	}
+	return len(seen)
+}
+
 // IsEmpty returns true if this multimap contains no key-value mappings
 func (m *ImmutableSetMultimap[K, V]) IsEmpty() bool {
 	return len(m.entries) == 0
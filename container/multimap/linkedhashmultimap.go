@@ -82,6 +82,34 @@ func (m *LinkedHashMultimap[K, V]) PutAll(multimap Multimap[K, V]) bool {
 	return changed
 }
 
+// PutValues adds a mapping from key to each of values and returns how many were added
+func (m *LinkedHashMultimap[K, V]) PutValues(key K, values []V) int {
+	if len(values) == 0 {
+		return 0
+	}
+
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	existing, exists := m.data[key]
+	if !exists {
+		existing = set.NewLinkedHashSet[V]()
+		m.data[key] = existing
+		m.keys = append(m.keys, key)
+		m.values[key] = make(map[V]struct{})
+	}
+
+	added := 0
+	for _, value := range values {
+		if existing.Add(value) {
+			added++
+			m.values[key][value] = struct{}{}
+		}
+	}
+	m.size += added
+	return added
+}
+
 // ReplaceValues replaces all values for a key with the specified collection of values
 func (m *LinkedHashMultimap[K, V]) ReplaceValues(key K, values []V) []V {
 	m.mutex.Lock()
@@ -214,6 +242,20 @@ func (m *LinkedHashMultimap[K, V]) ContainsValue(value V) bool {
 	return false
 }
 
+// KeysForValue returns the distinct keys that have at least one mapping to value, in key insertion order
+func (m *LinkedHashMultimap[K, V]) KeysForValue(value V) []K {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	keys := make([]K, 0)
+	for _, key := range m.keys {
+		if m.data[key].Contains(value) {
+			keys = append(keys, key)
+		}
+	}
+	return keys
+}
+
 // ContainsEntry returns true if this multimap contains the specified key-value mapping
 func (m *LinkedHashMultimap[K, V]) ContainsEntry(key K, value V) bool {
 	m.mutex.RLock()
@@ -240,6 +282,20 @@ func (m *LinkedHashMultimap[K, V]) Get(key K) []V {
 	return values.ToSlice()
 }
 
+// GetSet returns a copy of the values associated with key as a LinkedHashSet in insertion order
+func (m *LinkedHashMultimap[K, V]) GetSet(key K) set.Set[V] {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	result := set.NewLinkedHashSet[V]()
+	if values, exists := m.data[key]; exists {
+		values.ForEach(func(value V) {
+			result.Add(value)
+		})
+	}
+	return result
+}
+
 // Keys returns all distinct keys in this multimap in insertion order
 func (m *LinkedHashMultimap[K, V]) Keys() []K {
 	m.mutex.RLock()
@@ -316,6 +372,17 @@ func (m *LinkedHashMultimap[K, V]) ForEach(f func(K, V)) {
 	}
 }
 
+// ForEachKey executes the given function once for each distinct key with a copy of all its values
+// Keys are visited in insertion order
+func (m *LinkedHashMultimap[K, V]) ForEachKey(f func(K, []V)) {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	for _, key := range m.keys {
+		f(key, m.data[key].ToSlice())
+	}
+}
+
 // Size returns the number of key-value mappings in this multimap
 func (m *LinkedHashMultimap[K, V]) Size() int {
 	m.mutex.RLock()
@@ -324,6 +391,28 @@ func (m *LinkedHashMultimap[K, V]) Size() int {
 	return m.size
 }
 
+// EntryCount returns the total number of key-value pairs in this multimap
+func (m *LinkedHashMultimap[K, V]) EntryCount() int {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	return m.size
+}
+
+// DistinctValueCount returns the number of distinct values across all keys
+func (m *LinkedHashMultimap[K, V]) DistinctValueCount() int {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	seen := make(map[V]struct{})
+	for _, values := range m.data {
+		values.ForEach(func(value V) {
+			seen[value] = struct{}{}
+		})
+	}
+	return len(seen)
+}
+
 // IsEmpty returns true if this multimap contains no key-value mappings
 func (m *LinkedHashMultimap[K, V]) IsEmpty() bool {
 	m.mutex.RLock()
@@ -3,13 +3,22 @@ package multimap
 
 import (
     "github.com/chenjianyu/collections/container/common"
+    "github.com/chenjianyu/collections/container/list"
+    "github.com/chenjianyu/collections/container/set"
 )
 
 // Multimap represents a collection that maps keys to multiple values
 type Multimap[K comparable, V comparable] interface {
     // Size returns the number of key-value mappings in this multimap
+    // It is always equal to EntryCount
     Size() int
 
+	// EntryCount returns the total number of key-value pairs in this multimap
+	EntryCount() int
+
+	// DistinctValueCount returns the number of distinct values across all keys
+	DistinctValueCount() int
+
     // IsEmpty returns true if this multimap contains no key-value mappings
     IsEmpty() bool
 
@@ -30,6 +39,11 @@ type Multimap[K comparable, V comparable] interface {
 	// Returns true if the multimap changed
 	PutAll(multimap Multimap[K, V]) bool
 
+	// PutValues adds a mapping from key to each of values
+	// Returns the number of mappings that were actually added, which excludes
+	// values a SetMultimap already held for the key
+	PutValues(key K, values []V) int
+
 	// ReplaceValues replaces all values for a key with the specified collection of values
 	// Returns the previous values associated with the key, or nil if none
 	ReplaceValues(key K, values []V) []V
@@ -51,6 +65,10 @@ type Multimap[K comparable, V comparable] interface {
 	// ContainsEntry returns true if this multimap contains the specified key-value mapping
 	ContainsEntry(key K, value V) bool
 
+	// KeysForValue returns the distinct keys that have at least one mapping to value
+	// Keys are returned in the same relative order as Keys; the result is empty if none match
+	KeysForValue(value V) []K
+
 	// Get returns all values associated with the specified key
 	// Returns nil if no values are associated with the key
 	Get(key K) []V
@@ -72,6 +90,43 @@ type Multimap[K comparable, V comparable] interface {
 
 	// ForEach executes the given function for each key-value pair in this multimap
 	ForEach(func(K, V))
+
+	// ForEachKey executes the given function once for each distinct key with a copy of all its values
+	ForEachKey(func(K, []V))
+}
+
+// SetMultimap is a Multimap whose values for a key never contain duplicates
+// Putting a key-value pair that is already present leaves the multimap unchanged
+type SetMultimap[K comparable, V comparable] interface {
+	Multimap[K, V]
+
+	// GetSet returns a copy of the values associated with key as a set
+	// Returns an empty set if no values are associated with the key
+	GetSet(key K) set.Set[V]
+}
+
+// ListMultimap is a Multimap that keeps every value put for a key, duplicates included, in insertion order
+type ListMultimap[K comparable, V comparable] interface {
+	Multimap[K, V]
+
+	// GetList returns a copy of the values associated with key as a list
+	// Returns an empty list if no values are associated with the key
+	GetList(key K) list.List[V]
+}
+
+// forEachKeyInEntryOrder calls f once per distinct key of an immutable multimap, in order of the key's first entry
+func forEachKeyInEntryOrder[K comparable, V comparable](entries []common.Entry[K, V], data map[K][]V, f func(K, []V)) {
+	seen := make(map[K]struct{}, len(data))
+	for _, entry := range entries {
+		if _, ok := seen[entry.Key]; ok {
+			continue
+		}
+		seen[entry.Key] = struct{}{}
+		values := data[entry.Key]
+		result := make([]V, len(values))
+		copy(result, values)
+		f(entry.Key, result)
+	}
 }
 
 // Deprecated compatibility alias removed; use common.Entry/common.NewEntry directly.
\ No newline at end of file
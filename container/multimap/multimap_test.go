@@ -1,6 +1,8 @@
 package multimap
 
 import (
+    "fmt"
+    "strings"
     "testing"
 
     "github.com/chenjianyu/collections/container/common"
@@ -240,6 +242,41 @@ func TestTreeMultimap(t *testing.T) {
 	assert.Equal(t, expectedValues, values)
 }
 
+func TestTreeMultimapNavigation(t *testing.T) {
+	m := NewTreeMultimap[int, string]()
+	_, ok := m.FirstKey()
+	assert.False(t, ok)
+	_, ok = m.LastKey()
+	assert.False(t, ok)
+
+	// Events bucketed by timestamp
+	m.Put(30, "c")
+	m.Put(10, "a")
+	m.Put(20, "b1")
+	m.Put(20, "b2")
+	m.Put(40, "d")
+
+	first, ok := m.FirstKey()
+	assert.True(t, ok)
+	assert.Equal(t, 10, first)
+	last, ok := m.LastKey()
+	assert.True(t, ok)
+	assert.Equal(t, 40, last)
+
+	sub := m.SubMultimap(15, 40)
+	assert.Equal(t, []int{20, 30}, sub.Keys())
+	assert.Equal(t, 3, sub.Size())
+	assert.Equal(t, []string{"b1", "b2"}, sub.Get(20))
+
+	// The sub-multimap is independent of the source
+	sub.Put(35, "x")
+	assert.False(t, m.ContainsKey(35))
+	assert.Equal(t, 5, m.Size())
+
+	assert.True(t, m.SubMultimap(40, 10).IsEmpty())
+	assert.Equal(t, []int{10, 20, 30, 40}, m.SubMultimap(0, 100).Keys())
+}
+
 func TestImmutableMultimap(t *testing.T) {
 	// Create a mutable multimap and add some entries
 	mutable := NewHashMultimap[string, int]()
@@ -352,4 +389,274 @@ func TestImmutableSetMultimap(t *testing.T) {
 	assert.Equal(t, 2, len(values))
 	assert.Contains(t, values, 1)
 	assert.Contains(t, values, 2)
-}
\ No newline at end of file
+}
+func TestMultimapEntryAndDistinctValueCounts(t *testing.T) {
+	entries := []common.Entry[string, int]{
+		common.NewEntry("a", 1),
+		common.NewEntry("a", 2),
+		common.NewEntry("b", 1),
+		common.NewEntry("b", 3),
+		common.NewEntry("a", 1),
+	}
+	fill := func(m Multimap[string, int]) Multimap[string, int] {
+		for _, e := range entries {
+			m.Put(e.Key, e.Value)
+		}
+		return m
+	}
+
+	tests := []struct {
+		name          string
+		m             Multimap[string, int]
+		entryCount    int
+		distinctCount int
+	}{
+		{"ArrayListMultimap", fill(NewArrayListMultimap[string, int]()), 5, 3},
+		{"HashMultimap", fill(NewHashMultimap[string, int]()), 4, 3},
+		{"LinkedHashMultimap", fill(NewLinkedHashMultimap[string, int]()), 4, 3},
+		{"TreeMultimap", fill(NewTreeMultimap[string, int]()), 4, 3},
+		{"ImmutableMultimap", NewImmutableMultimap(entries), 5, 3},
+		{"ImmutableListMultimap", NewImmutableListMultimap(entries), 5, 3},
+		{"ImmutableSetMultimap", NewImmutableSetMultimap(entries), 4, 3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.entryCount, tt.m.EntryCount())
+			assert.Equal(t, tt.m.Size(), tt.m.EntryCount())
+			assert.Equal(t, tt.distinctCount, tt.m.DistinctValueCount())
+		})
+	}
+
+	empty := NewHashMultimap[string, int]()
+	assert.Equal(t, 0, empty.EntryCount())
+	assert.Equal(t, 0, empty.DistinctValueCount())
+}
+
+func TestMultimapKeysForValue(t *testing.T) {
+	entries := []common.Entry[string, int]{
+		common.NewEntry("c", 1),
+		common.NewEntry("a", 1),
+		common.NewEntry("a", 2),
+		common.NewEntry("b", 2),
+		common.NewEntry("a", 1),
+	}
+	fill := func(m Multimap[string, int]) Multimap[string, int] {
+		for _, e := range entries {
+			m.Put(e.Key, e.Value)
+		}
+		return m
+	}
+
+	tests := []struct {
+		name    string
+		m       Multimap[string, int]
+		ordered bool
+		keysFor []string
+	}{
+		{"ArrayListMultimap", fill(NewArrayListMultimap[string, int]()), false, []string{"a", "c"}},
+		{"HashMultimap", fill(NewHashMultimap[string, int]()), false, []string{"a", "c"}},
+		{"LinkedHashMultimap", fill(NewLinkedHashMultimap[string, int]()), true, []string{"c", "a"}},
+		{"TreeMultimap", fill(NewTreeMultimap[string, int]()), true, []string{"a", "c"}},
+		{"ImmutableMultimap", NewImmutableMultimap(entries), true, []string{"c", "a"}},
+		{"ImmutableListMultimap", NewImmutableListMultimap(entries), true, []string{"c", "a"}},
+		{"ImmutableSetMultimap", NewImmutableSetMultimap(entries), true, []string{"c", "a"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			keys := tt.m.KeysForValue(1)
+			if tt.ordered {
+				assert.Equal(t, tt.keysFor, keys)
+			} else {
+				assert.ElementsMatch(t, tt.keysFor, keys)
+			}
+			assert.ElementsMatch(t, []string{"a", "b"}, tt.m.KeysForValue(2))
+			assert.Empty(t, tt.m.KeysForValue(9))
+		})
+	}
+}
+
+func TestSetMultimapGetSet(t *testing.T) {
+	entries := []common.Entry[string, int]{
+		common.NewEntry("a", 3),
+		common.NewEntry("a", 1),
+		common.NewEntry("a", 3),
+		common.NewEntry("b", 2),
+	}
+	fill := func(m SetMultimap[string, int]) SetMultimap[string, int] {
+		for _, e := range entries {
+			m.Put(e.Key, e.Value)
+		}
+		return m
+	}
+
+	tests := []struct {
+		name string
+		m    SetMultimap[string, int]
+	}{
+		{"HashMultimap", fill(NewHashMultimap[string, int]())},
+		{"LinkedHashMultimap", fill(NewLinkedHashMultimap[string, int]())},
+		{"TreeMultimap", fill(NewTreeMultimap[string, int]())},
+		{"ImmutableSetMultimap", NewImmutableSetMultimap(entries)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			values := tt.m.GetSet("a")
+			assert.Equal(t, 2, values.Size())
+			assert.True(t, values.Contains(1))
+			assert.True(t, values.Contains(3))
+			assert.True(t, tt.m.GetSet("missing").IsEmpty())
+		})
+	}
+
+	// GetSet returns a copy
+	m := NewHashMultimap[string, int]()
+	m.Put("k", 1)
+	m.GetSet("k").Add(2)
+	assert.Equal(t, 1, len(m.Get("k")))
+}
+
+func TestListMultimapGetList(t *testing.T) {
+	entries := []common.Entry[string, int]{
+		common.NewEntry("a", 3),
+		common.NewEntry("a", 1),
+		common.NewEntry("a", 3),
+	}
+	mutable := NewArrayListMultimap[string, int]()
+	for _, e := range entries {
+		mutable.Put(e.Key, e.Value)
+	}
+
+	tests := []struct {
+		name string
+		m    ListMultimap[string, int]
+	}{
+		{"ArrayListMultimap", mutable},
+		{"ImmutableListMultimap", NewImmutableListMultimap(entries)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, []int{3, 1, 3}, tt.m.GetList("a").ToSlice())
+			assert.True(t, tt.m.GetList("missing").IsEmpty())
+		})
+	}
+
+	mutable.GetList("a").Add(9)
+	assert.Equal(t, 3, len(mutable.Get("a")))
+}
+
+func TestMultimapPutValues(t *testing.T) {
+	tests := []struct {
+		name       string
+		m          Multimap[string, int]
+		firstAdd   int
+		secondAdd  int
+		finalCount int
+	}{
+		{"ArrayListMultimap", NewArrayListMultimap[string, int](), 3, 2, 5},
+		{"HashMultimap", NewHashMultimap[string, int](), 2, 1, 3},
+		{"LinkedHashMultimap", NewLinkedHashMultimap[string, int](), 2, 1, 3},
+		{"TreeMultimap", NewTreeMultimap[string, int](), 2, 1, 3},
+		{"ImmutableMultimap", NewImmutableMultimap[string, int](nil), 0, 0, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.firstAdd, tt.m.PutValues("a", []int{1, 2, 1}))
+			assert.Equal(t, tt.secondAdd, tt.m.PutValues("b", []int{7, 7}))
+			assert.Equal(t, tt.finalCount, tt.m.Size())
+			assert.Equal(t, 0, tt.m.PutValues("c", nil))
+			assert.False(t, tt.m.ContainsKey("c"))
+		})
+	}
+
+	linked := NewLinkedHashMultimap[string, int]()
+	linked.Put("z", 0)
+	linked.PutValues("a", []int{3, 1, 2})
+	assert.Equal(t, []string{"z", "a"}, linked.Keys())
+	assert.Equal(t, []int{3, 1, 2}, linked.Get("a"))
+
+	tree := NewTreeMultimap[string, int]()
+	tree.PutValues("b", []int{1})
+	tree.PutValues("a", []int{1})
+	assert.Equal(t, []string{"a", "b"}, tree.Keys())
+}
+
+func TestMultimapForEachKey(t *testing.T) {
+	entries := []common.Entry[string, int]{
+		common.NewEntry("c", 1),
+		common.NewEntry("a", 1),
+		common.NewEntry("a", 2),
+		common.NewEntry("b", 2),
+		common.NewEntry("a", 1),
+	}
+	fill := func(m Multimap[string, int]) Multimap[string, int] {
+		for _, e := range entries {
+			m.Put(e.Key, e.Value)
+		}
+		return m
+	}
+
+	tests := []struct {
+		name      string
+		m         Multimap[string, int]
+		keyOrder  []string // nil when key order is unspecified
+		allowsDup bool
+	}{
+		{"ArrayListMultimap", fill(NewArrayListMultimap[string, int]()), nil, true},
+		{"HashMultimap", fill(NewHashMultimap[string, int]()), nil, false},
+		{"LinkedHashMultimap", fill(NewLinkedHashMultimap[string, int]()), []string{"c", "a", "b"}, false},
+		{"TreeMultimap", fill(NewTreeMultimap[string, int]()), []string{"a", "b", "c"}, false},
+		{"ImmutableMultimap", NewImmutableMultimap(entries), []string{"c", "a", "b"}, true},
+		{"ImmutableListMultimap", NewImmutableListMultimap(entries), []string{"c", "a", "b"}, true},
+		{"ImmutableSetMultimap", NewImmutableSetMultimap(entries), []string{"c", "a", "b"}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var keys []string
+			grouped := make(map[string][]int)
+			tt.m.ForEachKey(func(key string, values []int) {
+				keys = append(keys, key)
+				grouped[key] = values
+			})
+
+			if tt.keyOrder != nil {
+				assert.Equal(t, tt.keyOrder, keys)
+			} else {
+				assert.ElementsMatch(t, []string{"a", "b", "c"}, keys)
+			}
+			if tt.allowsDup {
+				assert.ElementsMatch(t, []int{1, 2, 1}, grouped["a"])
+			} else {
+				assert.ElementsMatch(t, []int{1, 2}, grouped["a"])
+			}
+			assert.Equal(t, []int{2}, grouped["b"])
+			assert.Equal(t, []int{1}, grouped["c"])
+
+			// The callback receives a copy
+			grouped["b"][0] = 99
+			assert.Equal(t, []int{2}, tt.m.Get("b"))
+		})
+	}
+}
+
+func TestHashMultimapSortedTraversal(t *testing.T) {
+	m := NewHashMultimap[string, int]()
+	m.PutValues("b", []int{3, 1, 2})
+	m.PutValues("a", []int{9, 4})
+	m.Put("c", 0)
+
+	expected := []common.Entry[string, int]{
+		common.NewEntry("a", 4), common.NewEntry("a", 9),
+		common.NewEntry("b", 1), common.NewEntry("b", 2), common.NewEntry("b", 3),
+		common.NewEntry("c", 0),
+	}
+	assert.Equal(t, expected, m.SortedEntries(nil, nil))
+
+	var visited []string
+	m.ForEachSorted(
+		func(a, b string) int { return strings.Compare(b, a) },
+		func(a, b int) int { return b - a },
+		func(k string, v int) { visited = append(visited, fmt.Sprintf("%s%d", k, v)) })
+	assert.Equal(t, []string{"c0", "b3", "b2", "b1", "a9", "a4"}, visited)
+
+	assert.Empty(t, NewHashMultimap[string, int]().SortedEntries(nil, nil))
+}
@@ -2,6 +2,7 @@ package multimap
 
 import (
     "fmt"
+    "sort"
     "strings"
     "sync"
 
@@ -123,6 +124,33 @@ func (m *TreeMultimap[K, V]) PutAll(multimap Multimap[K, V]) bool {
 	return changed
 }
 
+// PutValues adds a mapping from key to each of values and returns how many were added
+func (m *TreeMultimap[K, V]) PutValues(key K, values []V) int {
+	if len(values) == 0 {
+		return 0
+	}
+
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	existing, exists := m.data[key]
+	if !exists {
+		existing = set.NewTreeSetWithComparator[V](defaultValueComparator[V])
+		m.data[key] = existing
+		m.keys = append(m.keys, key)
+		m.sortKeys()
+	}
+
+	added := 0
+	for _, value := range values {
+		if existing.Add(value) {
+			added++
+		}
+	}
+	m.size += added
+	return added
+}
+
 // ReplaceValues replaces all values for a key with the specified collection of values
 func (m *TreeMultimap[K, V]) ReplaceValues(key K, values []V) []V {
 	m.mutex.Lock()
@@ -236,6 +264,20 @@ func (m *TreeMultimap[K, V]) ContainsValue(value V) bool {
 	return false
 }
 
+// KeysForValue returns the distinct keys that have at least one mapping to value, in sorted key order
+func (m *TreeMultimap[K, V]) KeysForValue(value V) []K {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	keys := make([]K, 0)
+	for _, key := range m.keys {
+		if m.data[key].Contains(value) {
+			keys = append(keys, key)
+		}
+	}
+	return keys
+}
+
 // ContainsEntry returns true if this multimap contains the specified key-value mapping
 func (m *TreeMultimap[K, V]) ContainsEntry(key K, value V) bool {
 	m.mutex.RLock()
@@ -262,6 +304,20 @@ func (m *TreeMultimap[K, V]) Get(key K) []V {
 	return values.ToSlice()
 }
 
+// GetSet returns a copy of the values associated with key as a TreeSet in value order
+func (m *TreeMultimap[K, V]) GetSet(key K) set.Set[V] {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	result := set.NewTreeSetWithComparator[V](defaultValueComparator[V])
+	if values, exists := m.data[key]; exists {
+		values.ForEach(func(value V) {
+			result.Add(value)
+		})
+	}
+	return result
+}
+
 // Keys returns all distinct keys in this multimap in sorted order
 func (m *TreeMultimap[K, V]) Keys() []K {
 	m.mutex.RLock()
@@ -272,6 +328,59 @@ func (m *TreeMultimap[K, V]) Keys() []K {
 	return result
 }
 
+// FirstKey returns the smallest key in this multimap
+// Returns false if the multimap is empty
+func (m *TreeMultimap[K, V]) FirstKey() (K, bool) {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	if len(m.keys) == 0 {
+		var zero K
+		return zero, false
+	}
+	return m.keys[0], true
+}
+
+// LastKey returns the largest key in this multimap
+// Returns false if the multimap is empty
+func (m *TreeMultimap[K, V]) LastKey() (K, bool) {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	if len(m.keys) == 0 {
+		var zero K
+		return zero, false
+	}
+	return m.keys[len(m.keys)-1], true
+}
+
+// SubMultimap returns a new multimap holding the entries whose keys lie in [from, to)
+// The result uses the same key comparator; it is empty if from is not less than to
+func (m *TreeMultimap[K, V]) SubMultimap(from, to K) *TreeMultimap[K, V] {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	result := NewTreeMultimapWithComparator[K, V](m.keyComparator)
+	start := sort.Search(len(m.keys), func(i int) bool {
+		return m.keyComparator(m.keys[i], from) >= 0
+	})
+	for _, key := range m.keys[start:] {
+		if m.keyComparator(key, to) >= 0 {
+			break
+		}
+		values := set.NewTreeSetWithComparator[V](defaultValueComparator[V])
+		m.data[key].ForEach(func(value V) {
+			values.Add(value)
+		})
+		// Keys are visited in sorted order, so no re-sort is needed
+		result.data[key] = values
+		result.keys = append(result.keys, key)
+		result.size += values.Size()
+	}
+
+	return result
+}
+
 // Values returns all values in this multimap
 func (m *TreeMultimap[K, V]) Values() []V {
 	m.mutex.RLock()
@@ -338,6 +447,17 @@ func (m *TreeMultimap[K, V]) ForEach(f func(K, V)) {
 	}
 }
 
+// ForEachKey executes the given function once for each distinct key with a copy of all its values
+// Keys are visited in sorted order
+func (m *TreeMultimap[K, V]) ForEachKey(f func(K, []V)) {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	for _, key := range m.keys {
+		f(key, m.data[key].ToSlice())
+	}
+}
+
 // Size returns the number of key-value mappings in this multimap
 func (m *TreeMultimap[K, V]) Size() int {
 	m.mutex.RLock()
@@ -346,6 +466,28 @@ func (m *TreeMultimap[K, V]) Size() int {
 	return m.size
 }
 
+// EntryCount returns the total number of key-value pairs in this multimap
+func (m *TreeMultimap[K, V]) EntryCount() int {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	return m.size
+}
+
+// DistinctValueCount returns the number of distinct values across all keys
+func (m *TreeMultimap[K, V]) DistinctValueCount() int {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	seen := make(map[V]struct{})
+	for _, values := range m.data {
+		values.ForEach(func(value V) {
+			seen[value] = struct{}{}
+		})
+	}
+	return len(seen)
+}
+
 // IsEmpty returns true if this multimap contains no key-value mappings
 func (m *TreeMultimap[K, V]) IsEmpty() bool {
 	m.mutex.RLock()
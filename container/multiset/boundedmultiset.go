@@ -0,0 +1,218 @@
+package multiset
+
+import (
+	"math/rand"
+	"time"
+
+	"github.com/chenjianyu/collections/container/common"
+)
+
+// OverflowPolicy decides what a BoundedMultiset does with additions past its cap
+type OverflowPolicy int
+
+const (
+	// RejectOverflow drops additions once the total count reaches the cap
+	RejectOverflow OverflowPolicy = iota
+	// SampleOverflow keeps a uniform random sample of every occurrence offered so far,
+	// replacing a random stored occurrence with probability maxTotal/seen (reservoir sampling)
+	SampleOverflow
+)
+
+// BoundedMultiset is a HashMultiset whose total number of occurrences never exceeds a cap
+// Reads and removals behave exactly like HashMultiset; Add, AddCount and SetCount honour the cap
+type BoundedMultiset[E comparable] struct {
+	*HashMultiset[E]
+	maxTotal int
+	policy   OverflowPolicy
+	seen     int
+	rand     *rand.Rand
+}
+
+// NewBoundedMultiset creates a BoundedMultiset that rejects additions past maxTotal occurrences
+// A non-positive maxTotal is treated as 1
+func NewBoundedMultiset[E comparable](maxTotal int) *BoundedMultiset[E] {
+	return NewBoundedMultisetWithPolicy[E](maxTotal, RejectOverflow)
+}
+
+// NewBoundedMultisetWithPolicy creates a BoundedMultiset that handles overflow according to policy
+// A non-positive maxTotal is treated as 1
+func NewBoundedMultisetWithPolicy[E comparable](maxTotal int, policy OverflowPolicy) *BoundedMultiset[E] {
+	if maxTotal <= 0 {
+		maxTotal = 1
+	}
+	return &BoundedMultiset[E]{
+		HashMultiset: NewHashMultiset[E](),
+		maxTotal:     maxTotal,
+		policy:       policy,
+		rand:         rand.New(rand.NewSource(time.Now().UnixNano())),
+	}
+}
+
+// MaxTotal returns the cap on the total number of occurrences
+func (ms *BoundedMultiset[E]) MaxTotal() int {
+	return ms.maxTotal
+}
+
+// Remaining returns how many more occurrences fit before the cap is reached
+func (ms *BoundedMultiset[E]) Remaining() int {
+	ms.mu.RLock()
+	defer ms.mu.RUnlock()
+	return ms.maxTotal - ms.size
+}
+
+// Seen returns the number of occurrences offered through Add and AddCount, including dropped ones
+func (ms *BoundedMultiset[E]) Seen() int {
+	ms.mu.RLock()
+	defer ms.mu.RUnlock()
+	return ms.seen
+}
+
+// Stats returns the total and distinct element counts with the cap as Capacity
+func (ms *BoundedMultiset[E]) Stats() common.ContainerStats {
+	ms.mu.RLock()
+	defer ms.mu.RUnlock()
+	return common.NewContainerStats(ms.size, len(ms.counts), ms.maxTotal)
+}
+
+// Add adds one occurrence of element if the cap allows it and returns the previous count
+// At the cap, RejectOverflow leaves the multiset unchanged and SampleOverflow may replace a
+// random stored occurrence; use TryAdd to learn whether the occurrence was kept
+func (ms *BoundedMultiset[E]) Add(element E) int {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+
+	prevCount := ms.counts[element]
+	ms.offer(element)
+	return prevCount
+}
+
+// TryAdd adds one occurrence of element and reports whether it was stored
+func (ms *BoundedMultiset[E]) TryAdd(element E) bool {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+	return ms.offer(element)
+}
+
+// AddCount adds count occurrences of element and returns the previous count
+// With RejectOverflow the call is all-or-nothing and fails with a full container error when
+// the occurrences do not fit; with SampleOverflow each occurrence is offered to the sample in turn
+func (ms *BoundedMultiset[E]) AddCount(element E, count int) (int, error) {
+	if count < 0 {
+		return 0, common.NegativeCountError(count)
+	}
+
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+
+	prevCount := ms.counts[element]
+	if ms.policy == RejectOverflow && count > ms.maxTotal-ms.size {
+		ms.seen += count
+		return prevCount, common.FullContainerError("BoundedMultiset", ms.maxTotal)
+	}
+	for i := 0; i < count; i++ {
+		ms.offer(element)
+	}
+	return prevCount, nil
+}
+
+// SetCount sets the count of element and returns the previous count
+// It fails with a full container error, leaving the multiset unchanged, if the new total would exceed the cap
+func (ms *BoundedMultiset[E]) SetCount(element E, count int) (int, error) {
+	if count < 0 {
+		return 0, common.NegativeCountError(count)
+	}
+
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+
+	prevCount := ms.counts[element]
+	if ms.size-prevCount+count > ms.maxTotal {
+		return prevCount, common.FullContainerError("BoundedMultiset", ms.maxTotal)
+	}
+	if count == 0 {
+		delete(ms.counts, element)
+	} else {
+		ms.counts[element] = count
+	}
+	ms.size += count - prevCount
+	return prevCount, nil
+}
+
+// Clear removes all elements and resets the count of offered occurrences,
+// so SampleOverflow starts a fresh reservoir
+func (ms *BoundedMultiset[E]) Clear() {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+	ms.counts = make(map[E]int)
+	ms.size = 0
+	ms.seen = 0
+}
+
+// Clone returns a copy of the multiset with the same cap, overflow policy and count of offered occurrences
+// The copy draws its samples from its own random source
+func (ms *BoundedMultiset[E]) Clone() *BoundedMultiset[E] {
+	ms.mu.RLock()
+	defer ms.mu.RUnlock()
+
+	counts := make(map[E]int, len(ms.counts))
+	for element, count := range ms.counts {
+		counts[element] = count
+	}
+	return &BoundedMultiset[E]{
+		HashMultiset: &HashMultiset[E]{counts: counts, size: ms.size},
+		maxTotal:     ms.maxTotal,
+		policy:       ms.policy,
+		seen:         ms.seen,
+		rand:         rand.New(rand.NewSource(time.Now().UnixNano())),
+	}
+}
+
+// UnmarshalBinary replaces the contents with the counts decoded from data
+// It fails with a full container error, leaving the multiset unchanged, if they exceed the cap;
+// the decoded occurrences count as the ones offered so far
+func (ms *BoundedMultiset[E]) UnmarshalBinary(data []byte) error {
+	decoded := NewHashMultiset[E]()
+	if err := decoded.UnmarshalBinary(data); err != nil {
+		return err
+	}
+	if decoded.size > ms.maxTotal {
+		return common.FullContainerError("BoundedMultiset", ms.maxTotal)
+	}
+
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+	ms.counts = decoded.counts
+	ms.size = decoded.size
+	ms.seen = decoded.size
+	return nil
+}
+
+// offer records one offered occurrence of element and stores it if the policy allows
+// The caller must hold the write lock
+func (ms *BoundedMultiset[E]) offer(element E) bool {
+	ms.seen++
+	if ms.size < ms.maxTotal {
+		ms.counts[element]++
+		ms.size++
+		return true
+	}
+	if ms.policy != SampleOverflow || ms.rand.Intn(ms.seen) >= ms.maxTotal {
+		return false
+	}
+
+	// Replace a stored occurrence chosen uniformly at random
+	target := ms.rand.Intn(ms.size)
+	for victim, count := range ms.counts {
+		if target < count {
+			if count == 1 {
+				delete(ms.counts, victim)
+			} else {
+				ms.counts[victim] = count - 1
+			}
+			break
+		}
+		target -= count
+	}
+	ms.counts[element]++
+	return true
+}
@@ -2,19 +2,23 @@ package multiset
 
 import (
 	"fmt"
+	"math/rand"
+	"sort"
 	"strings"
 	"sync"
 	"sync/atomic"
 
 	"github.com/chenjianyu/collections/container/common"
+	"github.com/chenjianyu/collections/container/set"
 )
 
 // ConcurrentHashMultiset is a thread-safe multiset implementation
 // It uses fine-grained locking with segment-based approach for better concurrency
 type ConcurrentHashMultiset[E comparable] struct {
-	segments []*segment[E]
-	segMask  uint32
-	size     int64
+	segments    []*segment[E]
+	segMask     uint32
+	size        int64
+	segmentSize int // initial capacity of each segment's map, reused by Clear
 }
 
 type segment[E comparable] struct {
@@ -34,6 +38,13 @@ func NewConcurrentHashMultiset[E comparable]() *ConcurrentHashMultiset[E] {
 
 // NewConcurrentHashMultisetWithSegments creates a new ConcurrentHashMultiset with specified segment count
 func NewConcurrentHashMultisetWithSegments[E comparable](segmentCount int) *ConcurrentHashMultiset[E] {
+	return NewConcurrentHashMultisetWithCapacity[E](segmentCount, 0)
+}
+
+// NewConcurrentHashMultisetWithCapacity creates a new ConcurrentHashMultiset with specified segment count,
+// pre-sizing each segment's map so that about expectedDistinct distinct elements fit without rehashing
+// A non-positive expectedDistinct uses the default per-segment size
+func NewConcurrentHashMultisetWithCapacity[E comparable](segmentCount, expectedDistinct int) *ConcurrentHashMultiset[E] {
 	// Ensure segment count is a power of 2
 	if segmentCount <= 0 {
 		segmentCount = defaultSegmentCount
@@ -44,17 +55,23 @@ func NewConcurrentHashMultisetWithSegments[E comparable](segmentCount int) *Conc
 	for actualSegmentCount < segmentCount {
 		actualSegmentCount <<= 1
 	}
+
+	segmentSize := defaultSegmentSize
+	if perSegment := (expectedDistinct + actualSegmentCount - 1) / actualSegmentCount; perSegment > segmentSize {
+		segmentSize = perSegment
+	}
 	
 	segments := make([]*segment[E], actualSegmentCount)
 	for i := range segments {
 		segments[i] = &segment[E]{
-			counts: make(map[E]int, defaultSegmentSize),
+			counts: make(map[E]int, segmentSize),
 		}
 	}
 	
 	return &ConcurrentHashMultiset[E]{
-		segments: segments,
-		segMask:  uint32(actualSegmentCount - 1),
+		segments:    segments,
+		segMask:     uint32(actualSegmentCount - 1),
+		segmentSize: segmentSize,
 	}
 }
 
@@ -178,6 +195,40 @@ func (ms *ConcurrentHashMultiset[E]) RemoveAll(element E) int {
 	return prevCount
 }
 
+// IncrementAndGet adds one occurrence of element and returns the new count
+// The update and the returned count are atomic with respect to other operations on element
+func (ms *ConcurrentHashMultiset[E]) IncrementAndGet(element E) int {
+	seg := ms.getSegment(element)
+	seg.mu.Lock()
+	defer seg.mu.Unlock()
+
+	newCount := seg.counts[element] + 1
+	seg.counts[element] = newCount
+	atomic.AddInt64(&ms.size, 1)
+	return newCount
+}
+
+// DecrementAndGet removes one occurrence of element and returns the new count
+// Returns 0 without changing the multiset if element is not present
+func (ms *ConcurrentHashMultiset[E]) DecrementAndGet(element E) int {
+	seg := ms.getSegment(element)
+	seg.mu.Lock()
+	defer seg.mu.Unlock()
+
+	prevCount := seg.counts[element]
+	if prevCount == 0 {
+		return 0
+	}
+	newCount := prevCount - 1
+	if newCount == 0 {
+		delete(seg.counts, element)
+	} else {
+		seg.counts[element] = newCount
+	}
+	atomic.AddInt64(&ms.size, -1)
+	return newCount
+}
+
 // Count returns the number of occurrences of the specified element
 func (ms *ConcurrentHashMultiset[E]) Count(element E) int {
 	seg := ms.getSegment(element)
@@ -211,6 +262,29 @@ func (ms *ConcurrentHashMultiset[E]) SetCount(element E, count int) (int, error)
 	return prevCount, nil
 }
 
+// CompareAndSetCount atomically sets the count of element to newCount if its current count is expected
+// Returns true if the count was updated; negative counts never match and are never set
+func (ms *ConcurrentHashMultiset[E]) CompareAndSetCount(element E, expected, newCount int) bool {
+	if expected < 0 || newCount < 0 {
+		return false
+	}
+
+	seg := ms.getSegment(element)
+	seg.mu.Lock()
+	defer seg.mu.Unlock()
+
+	if seg.counts[element] != expected {
+		return false
+	}
+	if newCount == 0 {
+		delete(seg.counts, element)
+	} else {
+		seg.counts[element] = newCount
+	}
+	atomic.AddInt64(&ms.size, int64(newCount-expected))
+	return true
+}
+
 // Contains checks if the multiset contains the specified element
 func (ms *ConcurrentHashMultiset[E]) Contains(element E) bool {
 	return ms.Count(element) > 0
@@ -242,16 +316,115 @@ func (ms *ConcurrentHashMultiset[E]) DistinctElements() int {
 	return ms.Size()
 }
 
+// Stats returns the total and distinct element counts
+// Segments are read one at a time, so the snapshot is not atomic; Capacity is 0 because
+// the segment maps do not expose their capacity
+func (ms *ConcurrentHashMultiset[E]) Stats() common.ContainerStats {
+	return common.NewContainerStats(ms.TotalSize(), ms.Size(), 0)
+}
+
 // Clear removes all elements from the multiset
 func (ms *ConcurrentHashMultiset[E]) Clear() {
 	for _, seg := range ms.segments {
 		seg.mu.Lock()
-		seg.counts = make(map[E]int, defaultSegmentSize)
+		seg.counts = make(map[E]int, ms.segmentSize)
 		seg.mu.Unlock()
 	}
 	atomic.StoreInt64(&ms.size, 0)
 }
 
+// Reset removes all elements like Clear but empties each segment's map in place,
+// keeping its buckets so a clear-and-refill cycle does not reallocate
+// Each segment is emptied under its own lock, so the size stays consistent with concurrent writers
+func (ms *ConcurrentHashMultiset[E]) Reset() {
+	for _, seg := range ms.segments {
+		seg.mu.Lock()
+		removed := 0
+		for _, count := range seg.counts {
+			removed += count
+		}
+		for element := range seg.counts {
+			delete(seg.counts, element)
+		}
+		atomic.AddInt64(&ms.size, -int64(removed))
+		seg.mu.Unlock()
+	}
+}
+
+// Clone returns an independent copy of this multiset
+// All segments are read-locked while copying, so the copy is a consistent snapshot
+func (ms *ConcurrentHashMultiset[E]) Clone() *ConcurrentHashMultiset[E] {
+	for _, seg := range ms.segments {
+		seg.mu.RLock()
+	}
+	defer func() {
+		for _, seg := range ms.segments {
+			seg.mu.RUnlock()
+		}
+	}()
+
+	segments := make([]*segment[E], len(ms.segments))
+	var total int64
+	for i, seg := range ms.segments {
+		counts := make(map[E]int, len(seg.counts))
+		for element, count := range seg.counts {
+			counts[element] = count
+			total += int64(count)
+		}
+		segments[i] = &segment[E]{counts: counts}
+	}
+
+	return &ConcurrentHashMultiset[E]{
+		segments:    segments,
+		segMask:     ms.segMask,
+		size:        total,
+		segmentSize: ms.segmentSize,
+	}
+}
+
+// Mode returns the most frequent element and its count
+// Segments are scanned one at a time, so under concurrent updates the result reflects
+// each segment at the moment it was read; returns false if the multiset is empty
+func (ms *ConcurrentHashMultiset[E]) Mode() (E, int, bool) {
+	var mode E
+	maxCount := 0
+	for _, seg := range ms.segments {
+		seg.mu.RLock()
+		for element, count := range seg.counts {
+			if count > maxCount {
+				mode, maxCount = element, count
+			}
+		}
+		seg.mu.RUnlock()
+	}
+	return mode, maxCount, maxCount > 0
+}
+
+// RemoveAllOccurrences removes up to other.Count(e) occurrences of each element e
+// Returns a new multiset holding the occurrences that were actually removed
+// Each element is removed atomically, but the call as a whole is not atomic
+func (ms *ConcurrentHashMultiset[E]) RemoveAllOccurrences(other Multiset[E]) Multiset[E] {
+	return removeOccurrences[E](ms, other, NewConcurrentHashMultiset[E]())
+}
+
+// WeightedRandom returns an element chosen with probability proportional to its count
+// A nil r uses the global math/rand source; returns false if the multiset is empty
+func (ms *ConcurrentHashMultiset[E]) WeightedRandom(r *rand.Rand) (E, bool) {
+	// Sample from a snapshot so concurrent updates cannot skew the cumulative walk
+	return weightedPick(ms.EntrySet(), r)
+}
+
+// FilterByCount returns a new multiset holding only the elements whose count is within [min, max]
+func (ms *ConcurrentHashMultiset[E]) FilterByCount(min, max int) Multiset[E] {
+	result := NewConcurrentHashMultiset[E]()
+	for _, entry := range ms.EntrySet() {
+		if entry.Count >= min && entry.Count <= max {
+			result.AddCount(entry.Element, entry.Count)
+		}
+	}
+	return result
+}
+
 // ElementSet returns a slice of distinct elements
 func (ms *ConcurrentHashMultiset[E]) ElementSet() []E {
 	var elements []E
@@ -267,6 +440,11 @@ func (ms *ConcurrentHashMultiset[E]) ElementSet() []E {
 	return elements
 }
 
+// ElementSetAsSet returns a new Set holding the distinct elements of this multiset
+func (ms *ConcurrentHashMultiset[E]) ElementSetAsSet() set.Set[E] {
+	return set.FromSlice(ms.ElementSet())
+}
+
 // EntrySet returns a slice of entries (element-count pairs)
 func (ms *ConcurrentHashMultiset[E]) EntrySet() []Entry[E] {
 	var entries []Entry[E]
@@ -282,9 +460,63 @@ func (ms *ConcurrentHashMultiset[E]) EntrySet() []Entry[E] {
 	return entries
 }
 
+// EntrySetSnapshotSorted returns a consistent snapshot of the entries sorted by cmp
+// All segments are read-locked only while the entries are copied; sorting happens
+// after the locks are released, so writers are blocked for the copy alone
+func (ms *ConcurrentHashMultiset[E]) EntrySetSnapshotSorted(cmp func(a, b Entry[E]) int) []Entry[E] {
+	entries := ms.snapshotEntries()
+	sort.SliceStable(entries, func(i, j int) bool {
+		return cmp(entries[i], entries[j]) < 0
+	})
+	return entries
+}
+
+// TopEntriesSnapshot returns up to n entries with the highest counts, in descending order of count
+// Entries with equal counts are ordered by their natural element order
+// The entries are taken from a consistent snapshot, see EntrySetSnapshotSorted
+func (ms *ConcurrentHashMultiset[E]) TopEntriesSnapshot(n int) []Entry[E] {
+	if n <= 0 {
+		return []Entry[E]{}
+	}
+	entries := ms.EntrySetSnapshotSorted(func(a, b Entry[E]) int {
+		if a.Count != b.Count {
+			return b.Count - a.Count
+		}
+		return common.CompareNatural(a.Element, b.Element)
+	})
+	if len(entries) > n {
+		entries = entries[:n]
+	}
+	return entries
+}
+
+// snapshotEntries copies the entries of all segments while holding every segment's read lock
+func (ms *ConcurrentHashMultiset[E]) snapshotEntries() []Entry[E] {
+	for _, seg := range ms.segments {
+		seg.mu.RLock()
+	}
+	defer func() {
+		for _, seg := range ms.segments {
+			seg.mu.RUnlock()
+		}
+	}()
+
+	distinct := 0
+	for _, seg := range ms.segments {
+		distinct += len(seg.counts)
+	}
+	entries := make([]Entry[E], 0, distinct)
+	for _, seg := range ms.segments {
+		for element, count := range seg.counts {
+			entries = append(entries, Entry[E]{Element: element, Count: count})
+		}
+	}
+	return entries
+}
+
 // ToSlice returns a slice containing all elements (including duplicates)
 func (ms *ConcurrentHashMultiset[E]) ToSlice() []E {
-	var result []E
+	result := make([]E, 0, ms.TotalSize())
 	
 	for _, seg := range ms.segments {
 		seg.mu.RLock()
@@ -314,11 +546,17 @@ func (ms *ConcurrentHashMultiset[E]) Iterator() common.Iterator[E] {
 }
 
 // ForEach executes the given function for each element in the multiset
+// Segments are walked in place under their read locks, so no entry slice is allocated;
+// fn must not modify this multiset
 func (ms *ConcurrentHashMultiset[E]) ForEach(fn func(E)) {
-	for _, entry := range ms.EntrySet() {
-		for i := 0; i < entry.Count; i++ {
-			fn(entry.Element)
+	for _, seg := range ms.segments {
+		seg.mu.RLock()
+		for element, count := range seg.counts {
+			for i := 0; i < count; i++ {
+				fn(element)
+			}
 		}
+		seg.mu.RUnlock()
 	}
 }
 
@@ -389,6 +627,12 @@ func (ms *ConcurrentHashMultiset[E]) IsSupersetOf(other Multiset[E]) bool {
 	return other.IsSubsetOf(ms)
 }
 
+// EnclosesCounts returns true if every required element occurs at least its required count
+// Each element's count is read separately, so the check is not atomic across segments
+func (ms *ConcurrentHashMultiset[E]) EnclosesCounts(required map[E]int) bool {
+	return enclosesCounts(ms.Count, required)
+}
+
 // String returns a string representation of the multiset
 func (ms *ConcurrentHashMultiset[E]) String() string {
 	if ms.TotalSize() == 0 {
@@ -414,6 +658,26 @@ func (ms *ConcurrentHashMultiset[E]) String() string {
 	return builder.String()
 }
 
+// StringN returns a representation like String that shows at most n distinct elements,
+// most frequent first; the remaining distinct elements are summarised as "… (+k more)"
+func (ms *ConcurrentHashMultiset[E]) StringN(n int) string {
+	for _, seg := range ms.segments {
+		seg.mu.RLock()
+	}
+	top := newTopEntries[E](n)
+	distinct := 0
+	for _, seg := range ms.segments {
+		distinct += len(seg.counts)
+		for element, count := range seg.counts {
+			top.offer(Entry[E]{Element: element, Count: count})
+		}
+	}
+	for _, seg := range ms.segments {
+		seg.mu.RUnlock()
+	}
+	return stringN("ConcurrentHashMultiset[", top, distinct)
+}
+
 // concurrentHashMultisetIterator implements Iterator for ConcurrentHashMultiset
 type concurrentHashMultisetIterator[E comparable] struct {
 	multiset *ConcurrentHashMultiset[E]
@@ -0,0 +1,146 @@
+package multiset
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/chenjianyu/collections/container/common"
+)
+
+// CountMinSketch is a probabilistic frequency table with bounded memory
+// It never underestimates: EstimateCount returns at least the true count of an element,
+// and overestimates by at most 2*TotalCount()/width with probability 1-(1/2)^depth
+// Unlike a Multiset it cannot enumerate or remove elements
+type CountMinSketch[E any] struct {
+	counts       [][]int
+	width        int
+	depth        int
+	total        int
+	hashStrategy common.HashStrategy[E]
+	mu           sync.RWMutex
+}
+
+// NewCountMinSketch creates a new CountMinSketch with the given width (counters per row)
+// and depth (number of rows), using the default hash strategy
+// Width and depth less than 1 are treated as 1
+func NewCountMinSketch[E comparable](width, depth int) *CountMinSketch[E] {
+	return NewCountMinSketchWithHashStrategy[E](width, depth, common.NewComparableHashStrategy[E]())
+}
+
+// NewCountMinSketchWithHashStrategy creates a new CountMinSketch using a custom hash strategy
+// Width and depth less than 1 are treated as 1
+func NewCountMinSketchWithHashStrategy[E any](width, depth int, hashStrategy common.HashStrategy[E]) *CountMinSketch[E] {
+	if width < 1 {
+		width = 1
+	}
+	if depth < 1 {
+		depth = 1
+	}
+	counts := make([][]int, depth)
+	for i := range counts {
+		counts[i] = make([]int, width)
+	}
+	return &CountMinSketch[E]{
+		counts:       counts,
+		width:        width,
+		depth:        depth,
+		hashStrategy: hashStrategy,
+	}
+}
+
+// Add records one occurrence of the element
+func (s *CountMinSketch[E]) Add(element E) {
+	s.AddCount(element, 1)
+}
+
+// AddCount records count occurrences of the element
+// Returns an error if count is negative
+func (s *CountMinSketch[E]) AddCount(element E, count int) error {
+	if count < 0 {
+		return common.NegativeCountError(count)
+	}
+	if count == 0 {
+		return nil
+	}
+
+	h1, h2 := s.hashes(element)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for row := 0; row < s.depth; row++ {
+		s.counts[row][s.index(h1, h2, row)] += count
+	}
+	s.total += count
+	return nil
+}
+
+// EstimateCount returns the estimated number of occurrences of the element
+// The estimate is never lower than the true count
+func (s *CountMinSketch[E]) EstimateCount(element E) int {
+	h1, h2 := s.hashes(element)
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	estimate := s.counts[0][s.index(h1, h2, 0)]
+	for row := 1; row < s.depth; row++ {
+		if count := s.counts[row][s.index(h1, h2, row)]; count < estimate {
+			estimate = count
+		}
+	}
+	return estimate
+}
+
+// TotalCount returns the total number of occurrences recorded
+func (s *CountMinSketch[E]) TotalCount() int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.total
+}
+
+// Width returns the number of counters per row
+func (s *CountMinSketch[E]) Width() int {
+	return s.width
+}
+
+// Depth returns the number of rows
+func (s *CountMinSketch[E]) Depth() int {
+	return s.depth
+}
+
+// Clear resets all counters
+func (s *CountMinSketch[E]) Clear() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, row := range s.counts {
+		for i := range row {
+			row[i] = 0
+		}
+	}
+	s.total = 0
+}
+
+// String returns a string representation of the sketch
+func (s *CountMinSketch[E]) String() string {
+	return fmt.Sprintf("CountMinSketch{width=%d, depth=%d, total=%d}", s.width, s.depth, s.TotalCount())
+}
+
+// hashes derives two independent 32-bit hashes from the element's hash
+// The hash is mixed first so strategies with weak high bits still spread across rows
+func (s *CountMinSketch[E]) hashes(element E) (uint32, uint32) {
+	h := s.hashStrategy.Hash(element)
+	// splitmix64 finalizer
+	h ^= h >> 30
+	h *= 0xbf58476d1ce4e5b9
+	h ^= h >> 27
+	h *= 0x94d049bb133111eb
+	h ^= h >> 31
+	return uint32(h), uint32(h>>32) | 1
+}
+
+// index returns the counter position for a row using double hashing
+func (s *CountMinSketch[E]) index(h1, h2 uint32, row int) int {
+	return int((uint64(h1) + uint64(row)*uint64(h2)) % uint64(s.width))
+}
@@ -2,10 +2,12 @@ package multiset
 
 import (
 	"fmt"
+	"math/rand"
 	"strings"
 	"sync"
 
 	"github.com/chenjianyu/collections/container/common"
+	"github.com/chenjianyu/collections/container/set"
 )
 
 // HashMultiset is a multiset implementation based on a hash map
@@ -33,6 +35,22 @@ func NewHashMultisetFromSlice[E comparable](elements []E) *HashMultiset[E] {
 	return ms
 }
 
+// FromIterator creates a new HashMultiset counting every remaining element of it
+// A nil iterator yields an empty multiset
+func FromIterator[E comparable](it common.Iterator[E]) *HashMultiset[E] {
+	ms := NewHashMultiset[E]()
+	if it == nil {
+		return ms
+	}
+	for it.HasNext() {
+		if element, ok := it.Next(); ok {
+			ms.counts[element]++
+			ms.size++
+		}
+	}
+	return ms
+}
+
 // Add adds one occurrence of the specified element
 func (ms *HashMultiset[E]) Add(element E) int {
 	ms.mu.Lock()
@@ -184,6 +202,14 @@ func (ms *HashMultiset[E]) DistinctElements() int {
 	return ms.Size()
 }
 
+// Stats returns the total and distinct element counts
+// The backing Go map does not expose its capacity, so Capacity is 0
+func (ms *HashMultiset[E]) Stats() common.ContainerStats {
+	ms.mu.RLock()
+	defer ms.mu.RUnlock()
+	return common.NewContainerStats(ms.size, len(ms.counts), 0)
+}
+
 // Clear removes all elements from the multiset
 func (ms *HashMultiset[E]) Clear() {
 	ms.mu.Lock()
@@ -192,6 +218,74 @@ func (ms *HashMultiset[E]) Clear() {
 	ms.size = 0
 }
 
+// Clone returns an independent copy of this multiset
+func (ms *HashMultiset[E]) Clone() *HashMultiset[E] {
+	ms.mu.RLock()
+	defer ms.mu.RUnlock()
+
+	counts := make(map[E]int, len(ms.counts))
+	for element, count := range ms.counts {
+		counts[element] = count
+	}
+	return &HashMultiset[E]{
+		counts: counts,
+		size:   ms.size,
+	}
+}
+
+// Mode returns the most frequent element and its count
+// Ties are broken arbitrarily; returns false if the multiset is empty
+func (ms *HashMultiset[E]) Mode() (E, int, bool) {
+	ms.mu.RLock()
+	defer ms.mu.RUnlock()
+
+	var mode E
+	maxCount := 0
+	for element, count := range ms.counts {
+		if count > maxCount {
+			mode, maxCount = element, count
+		}
+	}
+	return mode, maxCount, maxCount > 0
+}
+
+// RemoveAllOccurrences removes up to other.Count(e) occurrences of each element e
+// Returns a new multiset holding the occurrences that were actually removed
+func (ms *HashMultiset[E]) RemoveAllOccurrences(other Multiset[E]) Multiset[E] {
+	return removeOccurrences[E](ms, other, NewHashMultiset[E]())
+}
+
+// WeightedRandom returns an element chosen with probability proportional to its count
+// A nil r uses the global math/rand source; returns false if the multiset is empty
+func (ms *HashMultiset[E]) WeightedRandom(r *rand.Rand) (E, bool) {
+	ms.mu.RLock()
+	defer ms.mu.RUnlock()
+
+	var zero E
+	if ms.size == 0 {
+		return zero, false
+	}
+	target := weightedTarget(r, ms.size)
+	for element, count := range ms.counts {
+		if target < count {
+			return element, true
+		}
+		target -= count
+	}
+	return zero, false
+}
+
+// FilterByCount returns a new multiset holding only the elements whose count is within [min, max]
+func (ms *HashMultiset[E]) FilterByCount(min, max int) Multiset[E] {
+	result := NewHashMultiset[E]()
+	for _, entry := range ms.EntrySet() {
+		if entry.Count >= min && entry.Count <= max {
+			result.AddCount(entry.Element, entry.Count)
+		}
+	}
+	return result
+}
+
 // ElementSet returns a slice of distinct elements
 func (ms *HashMultiset[E]) ElementSet() []E {
 	ms.mu.RLock()
@@ -204,6 +298,11 @@ func (ms *HashMultiset[E]) ElementSet() []E {
 	return elements
 }
 
+// ElementSetAsSet returns a new Set holding the distinct elements of this multiset
+func (ms *HashMultiset[E]) ElementSetAsSet() set.Set[E] {
+	return set.FromSlice(ms.ElementSet())
+}
+
 // EntrySet returns a slice of entries (element-count pairs)
 func (ms *HashMultiset[E]) EntrySet() []Entry[E] {
 	ms.mu.RLock()
@@ -320,6 +419,13 @@ func (ms *HashMultiset[E]) IsSupersetOf(other Multiset[E]) bool {
 	return other.IsSubsetOf(ms)
 }
 
+// EnclosesCounts returns true if every required element occurs at least its required count
+func (ms *HashMultiset[E]) EnclosesCounts(required map[E]int) bool {
+	ms.mu.RLock()
+	defer ms.mu.RUnlock()
+	return enclosesCounts(func(element E) int { return ms.counts[element] }, required)
+}
+
 // String returns a string representation of the multiset
 func (ms *HashMultiset[E]) String() string {
 	ms.mu.RLock()
@@ -349,6 +455,19 @@ func (ms *HashMultiset[E]) String() string {
 	return builder.String()
 }
 
+// StringN returns a representation like String that shows at most n distinct elements,
+// most frequent first; the remaining distinct elements are summarised as "… (+k more)"
+func (ms *HashMultiset[E]) StringN(n int) string {
+	ms.mu.RLock()
+	top := newTopEntries[E](n)
+	for element, count := range ms.counts {
+		top.offer(Entry[E]{Element: element, Count: count})
+	}
+	distinct := len(ms.counts)
+	ms.mu.RUnlock()
+	return stringN("HashMultiset[", top, distinct)
+}
+
 // hashMultisetIterator implements Iterator for HashMultiset
 type hashMultisetIterator[E comparable] struct {
 	multiset *HashMultiset[E]
@@ -401,4 +520,40 @@ func (it *hashMultisetIterator[E]) Remove() bool {
 	}
 	
 	return true
-}
\ No newline at end of file
+}
+// MarshalBinary encodes each distinct element with its count using common.EncodeEntries
+func (ms *HashMultiset[E]) MarshalBinary() ([]byte, error) {
+	ms.mu.RLock()
+	entries := make([]common.Entry[E, int], 0, len(ms.counts))
+	for element, count := range ms.counts {
+		entries = append(entries, common.NewEntry(element, count))
+	}
+	ms.mu.RUnlock()
+	return common.EncodeEntries(entries)
+}
+
+// UnmarshalBinary replaces the multiset contents with the element counts decoded from data
+// The multiset is left unchanged if data is malformed or holds a negative count
+func (ms *HashMultiset[E]) UnmarshalBinary(data []byte) error {
+	entries, err := common.DecodeEntries[E, int](data)
+	if err != nil {
+		return err
+	}
+	counts := make(map[E]int, len(entries))
+	size := 0
+	for _, entry := range entries {
+		if entry.Value < 0 {
+			return common.NegativeCountError(entry.Value)
+		}
+		if entry.Value > 0 {
+			counts[entry.Key] += entry.Value
+			size += entry.Value
+		}
+	}
+
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+	ms.counts = counts
+	ms.size = size
+	return nil
+}
@@ -2,9 +2,11 @@ package multiset
 
 import (
 	"fmt"
+	"math/rand"
 	"strings"
 
 	"github.com/chenjianyu/collections/container/common"
+	"github.com/chenjianyu/collections/container/set"
 )
 
 // ImmutableMultiset is an immutable multiset implementation
@@ -272,6 +274,11 @@ func (ms *ImmutableMultiset[E]) DistinctElements() int {
 	return len(ms.counts)
 }
 
+// Stats returns the total and distinct element counts
+func (ms *ImmutableMultiset[E]) Stats() common.ContainerStats {
+	return common.NewContainerStats(ms.size, len(ms.counts), 0)
+}
+
 // Clear returns an empty ImmutableMultiset
 func (ms *ImmutableMultiset[E]) Clear() {
 	// This violates the interface but is needed for immutability
@@ -283,6 +290,57 @@ func (ms *ImmutableMultiset[E]) WithClear() *ImmutableMultiset[E] {
 	return NewImmutableMultiset[E]()
 }
 
+// Clone returns this multiset, since an ImmutableMultiset can be shared safely
+func (ms *ImmutableMultiset[E]) Clone() *ImmutableMultiset[E] {
+	return ms
+}
+
+// Mode returns the most frequent element and its count
+// Ties are broken arbitrarily; returns false if the multiset is empty
+func (ms *ImmutableMultiset[E]) Mode() (E, int, bool) {
+	var mode E
+	maxCount := 0
+	for element, count := range ms.counts {
+		if count > maxCount {
+			mode, maxCount = element, count
+		}
+	}
+	return mode, maxCount, maxCount > 0
+}
+
+// WeightedRandom returns an element chosen with probability proportional to its count
+// A nil r uses the global math/rand source; returns false if the multiset is empty
+func (ms *ImmutableMultiset[E]) WeightedRandom(r *rand.Rand) (E, bool) {
+	var zero E
+	if ms.size == 0 {
+		return zero, false
+	}
+	target := weightedTarget(r, ms.size)
+	for element, count := range ms.counts {
+		if target < count {
+			return element, true
+		}
+		target -= count
+	}
+	return zero, false
+}
+
+// FilterByCount returns a new multiset holding only the elements whose count is within [min, max]
+func (ms *ImmutableMultiset[E]) FilterByCount(min, max int) Multiset[E] {
+	newCounts := make(map[E]int)
+	newSize := 0
+	for element, count := range ms.counts {
+		if count >= min && count <= max {
+			newCounts[element] = count
+			newSize += count
+		}
+	}
+	return &ImmutableMultiset[E]{
+		counts: newCounts,
+		size:   newSize,
+	}
+}
+
 // ElementSet returns a slice of distinct elements
 func (ms *ImmutableMultiset[E]) ElementSet() []E {
 	elements := make([]E, 0, len(ms.counts))
@@ -292,6 +350,11 @@ func (ms *ImmutableMultiset[E]) ElementSet() []E {
 	return elements
 }
 
+// ElementSetAsSet returns a new Set holding the distinct elements of this multiset
+func (ms *ImmutableMultiset[E]) ElementSetAsSet() set.Set[E] {
+	return set.FromSlice(ms.ElementSet())
+}
+
 // EntrySet returns a slice of entries (element-count pairs)
 func (ms *ImmutableMultiset[E]) EntrySet() []Entry[E] {
 	entries := make([]Entry[E], 0, len(ms.counts))
@@ -409,6 +472,11 @@ func (ms *ImmutableMultiset[E]) IsSupersetOf(other Multiset[E]) bool {
 	return other.IsSubsetOf(ms)
 }
 
+// EnclosesCounts returns true if every required element occurs at least its required count
+func (ms *ImmutableMultiset[E]) EnclosesCounts(required map[E]int) bool {
+	return enclosesCounts(ms.Count, required)
+}
+
 // String returns a string representation of the multiset
 func (ms *ImmutableMultiset[E]) String() string {
 	if ms.size == 0 {
@@ -2,10 +2,12 @@ package multiset
 
 import (
 	"fmt"
+	"math/rand"
 	"strings"
 	"sync"
 
 	"github.com/chenjianyu/collections/container/common"
+	"github.com/chenjianyu/collections/container/set"
 )
 
 // LinkedHashMultiset is a multiset implementation that maintains insertion order
@@ -174,6 +176,32 @@ func (ms *LinkedHashMultiset[E]) RemoveAll(element E) int {
 	return prevCount
 }
 
+// Replace relabels old as new, keeping old's position in the insertion order
+// If new is already present its occurrences are merged into old's position
+// Returns the number of occurrences moved from old, or an error if old is absent
+func (ms *LinkedHashMultiset[E]) Replace(old, new E) (int, error) {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+	
+	entry, exists := ms.counts[old]
+	if !exists {
+		return 0, common.ElementNotFoundError(old)
+	}
+	moved := entry.count
+	if old == new {
+		return moved, nil
+	}
+	
+	if existing, ok := ms.counts[new]; ok {
+		entry.count += existing.count
+		ms.removeFromList(existing)
+	}
+	delete(ms.counts, old)
+	entry.element = new
+	ms.counts[new] = entry
+	return moved, nil
+}
+
 // Count returns the number of occurrences of the specified element
 func (ms *LinkedHashMultiset[E]) Count(element E) int {
 	ms.mu.RLock()
@@ -254,6 +282,14 @@ func (ms *LinkedHashMultiset[E]) DistinctElements() int {
 	return ms.Size()
 }
 
+// Stats returns the total and distinct element counts
+// The backing Go map does not expose its capacity, so Capacity is 0
+func (ms *LinkedHashMultiset[E]) Stats() common.ContainerStats {
+	ms.mu.RLock()
+	defer ms.mu.RUnlock()
+	return common.NewContainerStats(ms.size, len(ms.counts), 0)
+}
+
 // Clear removes all elements from the multiset
 func (ms *LinkedHashMultiset[E]) Clear() {
 	ms.mu.Lock()
@@ -264,6 +300,79 @@ func (ms *LinkedHashMultiset[E]) Clear() {
 	ms.size = 0
 }
 
+// Clone returns an independent copy of this multiset that preserves insertion order
+func (ms *LinkedHashMultiset[E]) Clone() *LinkedHashMultiset[E] {
+	ms.mu.RLock()
+	defer ms.mu.RUnlock()
+
+	result := NewLinkedHashMultiset[E]()
+	for entry := ms.head.next; entry != ms.tail; entry = entry.next {
+		copied := &linkedEntry[E]{
+			element: entry.element,
+			count:   entry.count,
+		}
+		result.counts[entry.element] = copied
+		result.addToTail(copied)
+	}
+	result.size = ms.size
+	return result
+}
+
+// Mode returns the most frequent element and its count
+// Ties are broken in favour of the element inserted first; returns false if the multiset is empty
+func (ms *LinkedHashMultiset[E]) Mode() (E, int, bool) {
+	ms.mu.RLock()
+	defer ms.mu.RUnlock()
+
+	var mode E
+	maxCount := 0
+	for current := ms.head.next; current != ms.tail; current = current.next {
+		if current.count > maxCount {
+			mode, maxCount = current.element, current.count
+		}
+	}
+	return mode, maxCount, maxCount > 0
+}
+
+// RemoveAllOccurrences removes up to other.Count(e) occurrences of each element e
+// Returns a new multiset holding the occurrences that were actually removed
+// The removed elements are ordered as they appear in other
+func (ms *LinkedHashMultiset[E]) RemoveAllOccurrences(other Multiset[E]) Multiset[E] {
+	return removeOccurrences[E](ms, other, NewLinkedHashMultiset[E]())
+}
+
+// WeightedRandom returns an element chosen with probability proportional to its count
+// A nil r uses the global math/rand source; returns false if the multiset is empty
+func (ms *LinkedHashMultiset[E]) WeightedRandom(r *rand.Rand) (E, bool) {
+	ms.mu.RLock()
+	defer ms.mu.RUnlock()
+
+	var zero E
+	if ms.size == 0 {
+		return zero, false
+	}
+	target := weightedTarget(r, ms.size)
+	for current := ms.head.next; current != ms.tail; current = current.next {
+		if target < current.count {
+			return current.element, true
+		}
+		target -= current.count
+	}
+	return zero, false
+}
+
+// FilterByCount returns a new multiset holding only the elements whose count is within [min, max]
+// Elements keep their insertion order
+func (ms *LinkedHashMultiset[E]) FilterByCount(min, max int) Multiset[E] {
+	result := NewLinkedHashMultiset[E]()
+	for _, entry := range ms.EntrySet() {
+		if entry.Count >= min && entry.Count <= max {
+			result.AddCount(entry.Element, entry.Count)
+		}
+	}
+	return result
+}
+
 // ElementSet returns a slice of distinct elements in insertion order
 func (ms *LinkedHashMultiset[E]) ElementSet() []E {
 	ms.mu.RLock()
@@ -278,6 +387,15 @@ func (ms *LinkedHashMultiset[E]) ElementSet() []E {
 	return elements
 }
 
+// ElementSetAsSet returns a new LinkedHashSet holding the distinct elements in insertion order
+func (ms *LinkedHashMultiset[E]) ElementSetAsSet() set.Set[E] {
+	result := set.NewLinkedHashSet[E]()
+	for _, element := range ms.ElementSet() {
+		result.Add(element)
+	}
+	return result
+}
+
 // EntrySet returns a slice of entries (element-count pairs) in insertion order
 func (ms *LinkedHashMultiset[E]) EntrySet() []Entry[E] {
 	ms.mu.RLock()
@@ -403,6 +521,18 @@ func (ms *LinkedHashMultiset[E]) IsSupersetOf(other Multiset[E]) bool {
 	return other.IsSubsetOf(ms)
 }
 
+// EnclosesCounts returns true if every required element occurs at least its required count
+func (ms *LinkedHashMultiset[E]) EnclosesCounts(required map[E]int) bool {
+	ms.mu.RLock()
+	defer ms.mu.RUnlock()
+	return enclosesCounts(func(element E) int {
+		if entry, exists := ms.counts[element]; exists {
+			return entry.count
+		}
+		return 0
+	}, required)
+}
+
 // String returns a string representation of the multiset
 func (ms *LinkedHashMultiset[E]) String() string {
 	ms.mu.RLock()
@@ -4,7 +4,10 @@
 package multiset
 
 import (
+	"math/rand"
+
 	"github.com/chenjianyu/collections/container/common"
+	"github.com/chenjianyu/collections/container/set"
 )
 
 // Entry represents an element and its count in the multiset
@@ -49,6 +52,9 @@ type Multiset[E comparable] interface {
 	// ElementSet returns a set view of the distinct elements in this multiset
 	ElementSet() []E
 
+	// ElementSetAsSet returns a new Set holding the distinct elements of this multiset
+	ElementSetAsSet() set.Set[E]
+
 	// EntrySet returns a set view of the entries (element-count pairs) in this multiset
 	EntrySet() []Entry[E]
 
@@ -58,6 +64,18 @@ type Multiset[E comparable] interface {
 	// DistinctElements returns the number of distinct elements in this multiset
 	DistinctElements() int
 
+	// Mode returns the most frequent element and its count
+	// Returns false if the multiset is empty
+	Mode() (E, int, bool)
+
+	// WeightedRandom returns an element chosen with probability proportional to its count
+	// A nil r uses the global math/rand source; returns false if the multiset is empty
+	WeightedRandom(r *rand.Rand) (E, bool)
+
+	// FilterByCount returns a new multiset holding only the elements whose count is within [min, max]
+	// Returns an empty multiset if min is greater than max
+	FilterByCount(min, max int) Multiset[E]
+
 	// ToSlice returns a slice containing all elements in this multiset (including duplicates)
 	ToSlice() []E
 
@@ -79,4 +97,8 @@ type Multiset[E comparable] interface {
 
 	// IsSupersetOf checks if this multiset is a superset of another multiset
 	IsSupersetOf(other Multiset[E]) bool
+
+	// EnclosesCounts returns true if every element of required occurs at least its required count
+	// Non-positive requirements are always satisfied
+	EnclosesCounts(required map[E]int) bool
 }
\ No newline at end of file
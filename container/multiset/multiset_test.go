@@ -1,7 +1,16 @@
 package multiset
 
 import (
+	"errors"
+	"math"
+	"math/rand"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
+
+	"github.com/chenjianyu/collections/container/common"
+	"github.com/chenjianyu/collections/container/set"
 )
 
 // Test HashMultiset
@@ -126,6 +135,117 @@ func TestConcurrentHashMultisetConcurrency(t *testing.T) {
 	}
 }
 
+func TestConcurrentHashMultisetIncrementAndGet(t *testing.T) {
+	ms := NewConcurrentHashMultiset[string]()
+
+	if got := ms.IncrementAndGet("a"); got != 1 {
+		t.Errorf("IncrementAndGet on a new element should return 1, got %d", got)
+	}
+	if got := ms.IncrementAndGet("a"); got != 2 {
+		t.Errorf("IncrementAndGet should return 2, got %d", got)
+	}
+	if got := ms.DecrementAndGet("a"); got != 1 {
+		t.Errorf("DecrementAndGet should return 1, got %d", got)
+	}
+	if got := ms.DecrementAndGet("a"); got != 0 || ms.Contains("a") {
+		t.Errorf("DecrementAndGet to zero should remove the element, got %d", got)
+	}
+	if got := ms.DecrementAndGet("missing"); got != 0 || ms.TotalSize() != 0 {
+		t.Errorf("DecrementAndGet on a missing element should be a no-op, got %d", got)
+	}
+
+	// Every caller observes a distinct post-increment value
+	const goroutines, perGoroutine = 8, 250
+	seen := make([]int32, goroutines*perGoroutine+1)
+	var wg sync.WaitGroup
+	for i := 0; i < goroutines; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < perGoroutine; j++ {
+				atomic.AddInt32(&seen[ms.IncrementAndGet("hits")], 1)
+			}
+		}()
+	}
+	wg.Wait()
+	for count := 1; count < len(seen); count++ {
+		if seen[count] != 1 {
+			t.Fatalf("Count %d was returned %d times", count, seen[count])
+		}
+	}
+	if ms.TotalSize() != goroutines*perGoroutine {
+		t.Errorf("Expected total size %d, got %d", goroutines*perGoroutine, ms.TotalSize())
+	}
+}
+
+func TestConcurrentHashMultisetReset(t *testing.T) {
+	ms := NewConcurrentHashMultiset[int]()
+	for i := 0; i < 200; i++ {
+		ms.AddCount(i, 2)
+	}
+	ms.Reset()
+	if !ms.IsEmpty() || ms.TotalSize() != 0 || ms.DistinctElements() != 0 || ms.Contains(5) {
+		t.Errorf("Reset should empty the multiset, got %v", ms)
+	}
+
+	// Refilling after Reset reuses the existing maps, so it allocates less than after Clear
+	cycle := func(clear func()) float64 {
+		return testing.AllocsPerRun(10, func() {
+			for i := 0; i < 200; i++ {
+				ms.Add(i)
+			}
+			clear()
+		})
+	}
+	if resetAllocs, clearAllocs := cycle(ms.Reset), cycle(ms.Clear); resetAllocs >= clearAllocs {
+		t.Errorf("Expected Reset (%v allocs) to allocate less than Clear (%v allocs)", resetAllocs, clearAllocs)
+	}
+
+	// Size stays consistent when Reset races with writers
+	var wg sync.WaitGroup
+	for g := 0; g < 4; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			for i := 0; i < 500; i++ {
+				ms.Add(g*1000 + i)
+				if i%100 == 0 {
+					ms.Reset()
+				}
+			}
+		}(g)
+	}
+	wg.Wait()
+	sum := 0
+	for _, entry := range ms.EntrySet() {
+		sum += entry.Count
+	}
+	if ms.TotalSize() != sum {
+		t.Errorf("TotalSize %d should match the stored counts %d", ms.TotalSize(), sum)
+	}
+}
+
+func BenchmarkConcurrentHashMultisetClearRefill(b *testing.B) {
+	for _, bc := range []struct {
+		name  string
+		clear func(*ConcurrentHashMultiset[int])
+	}{
+		{"Clear", (*ConcurrentHashMultiset[int]).Clear},
+		{"Reset", (*ConcurrentHashMultiset[int]).Reset},
+	} {
+		b.Run(bc.name, func(b *testing.B) {
+			ms := NewConcurrentHashMultiset[int]()
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				for j := 0; j < 256; j++ {
+					ms.Add(j)
+				}
+				bc.clear(ms)
+			}
+		})
+	}
+}
+
 // Test ImmutableMultiset
 func TestImmutableMultiset(t *testing.T) {
 	// Test basic operations
@@ -417,4 +537,1086 @@ func TestMultisetErrorConditions(t *testing.T) {
 	if count != 0 {
 		t.Errorf("SetCount with negative count should return 0, got %d", count)
 	}
-}
\ No newline at end of file
+}
+// Test Clone
+func TestMultisetClone(t *testing.T) {
+	elements := []string{"c", "a", "b", "a", "c", "c"}
+
+	check := func(name string, original, clone Multiset[string]) {
+		if clone.TotalSize() != original.TotalSize() {
+			t.Errorf("%s: clone total size should be %d, got %d", name, original.TotalSize(), clone.TotalSize())
+		}
+		if clone.DistinctElements() != original.DistinctElements() {
+			t.Errorf("%s: clone distinct elements should be %d, got %d", name, original.DistinctElements(), clone.DistinctElements())
+		}
+		clone.Add("z")
+		clone.RemoveAll("a")
+		if original.Contains("z") || original.Count("a") != 2 {
+			t.Errorf("%s: modifying the clone should not affect the original", name)
+		}
+		original.Add("y")
+		if clone.Contains("y") {
+			t.Errorf("%s: modifying the original should not affect the clone", name)
+		}
+	}
+
+	hash := NewHashMultisetFromSlice(elements)
+	check("HashMultiset", hash, hash.Clone())
+
+	tree := NewTreeMultisetWithComparator(func(a, b string) int {
+		if a > b {
+			return -1
+		} else if a < b {
+			return 1
+		}
+		return 0
+	})
+	for _, e := range elements {
+		tree.Add(e)
+	}
+	treeClone := tree.Clone()
+	if treeClone.root.height != tree.root.height || treeClone.root.element != tree.root.element {
+		t.Error("TreeMultiset clone should keep the tree shape")
+	}
+	check("TreeMultiset", tree, treeClone)
+	if got := treeClone.ElementSet(); got[0] != "z" || got[len(got)-1] != "b" {
+		t.Errorf("TreeMultiset clone should keep the comparator, got %v", got)
+	}
+
+	linked := NewLinkedHashMultisetFromSlice(elements)
+	linkedClone := linked.Clone()
+	order := linkedClone.ElementSet()
+	expected := []string{"c", "a", "b"}
+	for i, e := range expected {
+		if order[i] != e {
+			t.Errorf("LinkedHashMultiset clone should preserve insertion order, got %v", order)
+			break
+		}
+	}
+	check("LinkedHashMultiset", linked, linkedClone)
+
+	concurrent := NewConcurrentHashMultisetFromSlice(elements)
+	check("ConcurrentHashMultiset", concurrent, concurrent.Clone())
+
+	immutable := NewImmutableMultisetFromSlice(elements)
+	if immutable.Clone().TotalSize() != immutable.TotalSize() {
+		t.Error("ImmutableMultiset clone should have the same total size")
+	}
+}
+
+// Test TreeMultiset iterator snapshot under concurrent modification
+func TestTreeMultisetIteratorSnapshot(t *testing.T) {
+	ms := NewTreeMultiset[int]()
+	for i := 0; i < 100; i++ {
+		ms.AddCount(i, 2)
+	}
+
+	it := ms.Iterator()
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for i := 100; i < 1100; i++ {
+			ms.Add(i)
+			_ = ms.String()
+		}
+	}()
+
+	seen := 0
+	prev := -1
+	for it.HasNext() {
+		v, ok := it.Next()
+		if !ok {
+			t.Fatal("Next should succeed while HasNext is true")
+		}
+		if v < prev {
+			t.Errorf("Iterator should yield sorted elements, got %d after %d", v, prev)
+		}
+		prev = v
+		seen++
+	}
+	wg.Wait()
+
+	if seen != 200 {
+		t.Errorf("Iterator should yield the 200 elements of its snapshot, got %d", seen)
+	}
+
+	// Reset picks up the current contents
+	it.(*treeMultisetIterator[int]).Reset()
+	seen = 0
+	for it.HasNext() {
+		it.Next()
+		seen++
+	}
+	if seen != ms.TotalSize() {
+		t.Errorf("Iterator after Reset should yield %d elements, got %d", ms.TotalSize(), seen)
+	}
+}
+
+func TestTreeMultisetIteratorRemove(t *testing.T) {
+	ms := NewTreeMultisetFromSlice([]string{"a", "a", "a", "b", "c"})
+	it := ms.Iterator()
+
+	if it.Remove() {
+		t.Error("Remove before Next should return false")
+	}
+
+	var yielded []string
+	for it.HasNext() {
+		v, _ := it.Next()
+		yielded = append(yielded, v)
+		if v == "a" || v == "c" {
+			if !it.Remove() {
+				t.Errorf("Remove of %s should succeed", v)
+			}
+		}
+	}
+
+	if len(yielded) != 5 {
+		t.Errorf("Iterator should still yield every occurrence, got %v", yielded)
+	}
+	if ms.TotalSize() != 1 || ms.Count("b") != 1 {
+		t.Errorf("Only b should remain, got %s", ms.String())
+	}
+
+	// Each Next allows exactly one Remove
+	ms = NewTreeMultisetFromSlice([]string{"a", "a", "a"})
+	it = ms.Iterator()
+	it.Next()
+	it.Next()
+	if !it.Remove() {
+		t.Error("First Remove after Next should succeed")
+	}
+	if it.Remove() {
+		t.Error("Second Remove after the last Next should return false")
+	}
+	if ms.Count("a") != 2 {
+		t.Errorf("Only one occurrence should be removed, got %d", ms.Count("a"))
+	}
+	it.(*treeMultisetIterator[string]).Reset()
+	if it.Remove() {
+		t.Error("Remove after Reset should return false")
+	}
+}
+
+// Test UnionAll and IntersectAll
+func TestUnionAllIntersectAll(t *testing.T) {
+	a := NewHashMultisetFromSlice([]string{"a", "a", "b", "c"})
+	b := NewTreeMultisetFromSlice([]string{"a", "b", "b", "b", "c"})
+	c := NewLinkedHashMultisetFromSlice([]string{"a", "a", "a", "c", "d"})
+
+	union := UnionAll[string](a, b, c)
+	expectedUnion := map[string]int{"a": 3, "b": 3, "c": 1, "d": 1}
+	for element, count := range expectedUnion {
+		if union.Count(element) != count {
+			t.Errorf("Union count of %s should be %d, got %d", element, count, union.Count(element))
+		}
+	}
+	if union.TotalSize() != 8 || union.DistinctElements() != 4 {
+		t.Errorf("Union should have 8 elements (4 distinct), got %d (%d)", union.TotalSize(), union.DistinctElements())
+	}
+
+	intersection := IntersectAll[string](a, b, c)
+	if intersection.Count("a") != 1 || intersection.Count("c") != 1 || intersection.Contains("b") {
+		t.Errorf("Intersection should be [a, c], got %v", intersection)
+	}
+	if intersection.TotalSize() != 2 {
+		t.Errorf("Intersection total size should be 2, got %d", intersection.TotalSize())
+	}
+
+	if !IntersectAll[string](a, NewHashMultiset[string]()).IsEmpty() {
+		t.Error("Intersection with an empty multiset should be empty")
+	}
+	if !UnionAll[string]().IsEmpty() || !IntersectAll[string]().IsEmpty() {
+		t.Error("Combining no multisets should give an empty multiset")
+	}
+}
+
+func TestMultisetMode(t *testing.T) {
+	elements := []string{"a", "b", "b", "c", "b", "a"}
+	multisets := map[string]Multiset[string]{
+		"HashMultiset":           NewHashMultisetFromSlice(elements),
+		"TreeMultiset":           NewTreeMultisetFromSlice(elements),
+		"LinkedHashMultiset":     NewLinkedHashMultisetFromSlice(elements),
+		"ConcurrentHashMultiset": NewConcurrentHashMultisetFromSlice(elements),
+		"ImmutableMultiset":      NewImmutableMultisetFromSlice(elements),
+	}
+	for name, ms := range multisets {
+		mode, count, ok := ms.Mode()
+		if !ok || mode != "b" || count != 3 {
+			t.Errorf("%s: Mode should be (b, 3, true), got (%s, %d, %v)", name, mode, count, ok)
+		}
+	}
+
+	if _, _, ok := NewHashMultiset[int]().Mode(); ok {
+		t.Error("Mode of an empty multiset should return false")
+	}
+
+	// Ties follow the iteration order of ordered multisets
+	tree := NewTreeMultisetFromSlice([]int{3, 1, 3, 1, 2})
+	if mode, _, _ := tree.Mode(); mode != 1 {
+		t.Errorf("TreeMultiset Mode tie should resolve to smallest element 1, got %d", mode)
+	}
+	linked := NewLinkedHashMultisetFromSlice([]int{3, 1, 3, 1, 2})
+	if mode, _, _ := linked.Mode(); mode != 3 {
+		t.Errorf("LinkedHashMultiset Mode tie should resolve to first inserted element 3, got %d", mode)
+	}
+}
+
+func TestTreeMultisetMedian(t *testing.T) {
+	ms := NewTreeMultiset[int]()
+	if _, ok := ms.Median(); ok {
+		t.Error("Median of an empty multiset should return false")
+	}
+
+	ms.AddCount(1, 1)
+	ms.AddCount(5, 3)
+	ms.AddCount(9, 1)
+	// Sorted: 1 5 5 5 9
+	if median, ok := ms.Median(); !ok || median != 5 {
+		t.Errorf("Median should be 5, got %d", median)
+	}
+
+	ms.AddCount(10, 4)
+	// Sorted: 1 5 5 5 9 10 10 10 10, middle index 4
+	if median, _ := ms.Median(); median != 9 {
+		t.Errorf("Median should be 9, got %d", median)
+	}
+
+	ms.Add(11)
+	// Even total size returns the lower middle element
+	if median, _ := ms.Median(); median != 9 {
+		t.Errorf("Median of even-sized multiset should be lower middle 9, got %d", median)
+	}
+}
+
+func TestConcurrentHashMultisetSnapshots(t *testing.T) {
+	ms := NewConcurrentHashMultiset[string]()
+	ms.AddCount("a", 2)
+	ms.AddCount("b", 5)
+	ms.AddCount("c", 1)
+	ms.AddCount("d", 5)
+
+	top := ms.TopEntriesSnapshot(3)
+	expected := []Entry[string]{{"b", 5}, {"d", 5}, {"a", 2}}
+	if len(top) != len(expected) {
+		t.Fatalf("TopEntriesSnapshot(3) should return 3 entries, got %v", top)
+	}
+	for i, entry := range expected {
+		if top[i] != entry {
+			t.Errorf("TopEntriesSnapshot[%d] should be %v, got %v", i, entry, top[i])
+		}
+	}
+	if len(ms.TopEntriesSnapshot(10)) != 4 {
+		t.Error("TopEntriesSnapshot with n larger than the multiset should return all entries")
+	}
+	if len(ms.TopEntriesSnapshot(0)) != 0 {
+		t.Error("TopEntriesSnapshot(0) should return no entries")
+	}
+
+	byElement := ms.EntrySetSnapshotSorted(func(a, b Entry[string]) int {
+		return strings.Compare(a.Element, b.Element)
+	})
+	for i, element := range []string{"a", "b", "c", "d"} {
+		if byElement[i].Element != element || byElement[i].Count != ms.Count(element) {
+			t.Errorf("EntrySetSnapshotSorted[%d] should be %s, got %v", i, element, byElement[i])
+		}
+	}
+
+	// Snapshots can be taken while writers are active
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				ms.Add(string(rune('e' + i)))
+				ms.TopEntriesSnapshot(2)
+			}
+		}(i)
+	}
+	wg.Wait()
+	if top := ms.TopEntriesSnapshot(1); top[0].Count != 100 {
+		t.Errorf("Top entry should have count 100, got %v", top[0])
+	}
+}
+
+func TestCountMinSketch(t *testing.T) {
+	sketch := NewCountMinSketch[int](256, 4)
+	exact := NewHashMultiset[int]()
+
+	// Heavy hitters 0..4 followed by a long tail
+	for i := 0; i < 5; i++ {
+		sketch.AddCount(i, 1000)
+		exact.AddCount(i, 1000)
+	}
+	for i := 5; i < 2000; i++ {
+		sketch.Add(i)
+		exact.Add(i)
+	}
+
+	if sketch.TotalCount() != exact.TotalSize() {
+		t.Errorf("TotalCount should be %d, got %d", exact.TotalSize(), sketch.TotalCount())
+	}
+	// Error bound with high probability: 2 * total / width
+	bound := 2 * sketch.TotalCount() / sketch.Width()
+	for i := 0; i < 2000; i++ {
+		estimate := sketch.EstimateCount(i)
+		if estimate < exact.Count(i) {
+			t.Fatalf("EstimateCount(%d) = %d should never be below true count %d", i, estimate, exact.Count(i))
+		}
+		if i < 5 && estimate-exact.Count(i) > bound {
+			t.Errorf("EstimateCount(%d) = %d exceeds error bound %d", i, estimate, bound)
+		}
+	}
+
+	if err := sketch.AddCount(1, -1); err == nil {
+		t.Error("AddCount with negative count should return error")
+	}
+
+	sketch.Clear()
+	if sketch.TotalCount() != 0 || sketch.EstimateCount(0) != 0 {
+		t.Error("Clear should reset all counters")
+	}
+
+	// Custom hash strategy groups equal elements
+	caseless := NewCountMinSketchWithHashStrategy[string](64, 3, common.NewCaseInsensitiveStringHashStrategy())
+	caseless.Add("Go")
+	caseless.Add("GO")
+	if caseless.EstimateCount("go") < 2 {
+		t.Errorf("Case-insensitive sketch should count go at least twice, got %d", caseless.EstimateCount("go"))
+	}
+
+	tiny := NewCountMinSketch[string](0, -1)
+	if tiny.Width() != 1 || tiny.Depth() != 1 {
+		t.Errorf("Non-positive dimensions should be clamped to 1, got %dx%d", tiny.Width(), tiny.Depth())
+	}
+}
+
+func TestMultisetFilterByCount(t *testing.T) {
+	// rare: 1, common: 3 and 4, noisy: 10
+	var elements []string
+	for word, count := range map[string]int{"rare": 1, "alpha": 3, "beta": 4, "noise": 10} {
+		for i := 0; i < count; i++ {
+			elements = append(elements, word)
+		}
+	}
+	multisets := map[string]Multiset[string]{
+		"HashMultiset":           NewHashMultisetFromSlice(elements),
+		"TreeMultiset":           NewTreeMultisetFromSlice(elements),
+		"LinkedHashMultiset":     NewLinkedHashMultisetFromSlice(elements),
+		"ConcurrentHashMultiset": NewConcurrentHashMultisetFromSlice(elements),
+		"ImmutableMultiset":      NewImmutableMultisetFromSlice(elements),
+	}
+	for name, ms := range multisets {
+		filtered := ms.FilterByCount(3, 5)
+		if filtered.DistinctElements() != 2 || filtered.TotalSize() != 7 {
+			t.Errorf("%s: FilterByCount(3, 5) should keep alpha and beta, got %v", name, filtered.EntrySet())
+		}
+		if filtered.Count("alpha") != 3 || filtered.Count("beta") != 4 || filtered.Count("noise") != 0 {
+			t.Errorf("%s: FilterByCount should keep original counts", name)
+		}
+		if ms.TotalSize() != 18 {
+			t.Errorf("%s: FilterByCount should not modify the source", name)
+		}
+		if !ms.FilterByCount(5, 3).IsEmpty() {
+			t.Errorf("%s: FilterByCount with min > max should be empty", name)
+		}
+	}
+
+	tree := NewTreeMultisetFromSlice([]int{3, 3, 1, 1, 2}).FilterByCount(2, 2)
+	if got := tree.ElementSet(); len(got) != 2 || got[0] != 1 || got[1] != 3 {
+		t.Errorf("TreeMultiset FilterByCount should stay sorted, got %v", got)
+	}
+}
+
+func TestMultisetElementSetAsSet(t *testing.T) {
+	elements := []string{"b", "a", "b", "c", "a"}
+	multisets := map[string]Multiset[string]{
+		"HashMultiset":           NewHashMultisetFromSlice(elements),
+		"TreeMultiset":           NewTreeMultisetFromSlice(elements),
+		"LinkedHashMultiset":     NewLinkedHashMultisetFromSlice(elements),
+		"ConcurrentHashMultiset": NewConcurrentHashMultisetFromSlice(elements),
+		"ImmutableMultiset":      NewImmutableMultisetFromSlice(elements),
+	}
+	for name, ms := range multisets {
+		distinct := ms.ElementSetAsSet()
+		if distinct.Size() != 3 || !distinct.Contains("a") || !distinct.Contains("b") || !distinct.Contains("c") {
+			t.Errorf("%s: ElementSetAsSet should hold a, b and c, got %v", name, distinct)
+		}
+		distinct.Remove("a")
+		if ms.Count("a") != 2 {
+			t.Errorf("%s: ElementSetAsSet should return an independent set", name)
+		}
+	}
+
+	if got := NewTreeMultisetFromSlice(elements).ElementSetAsSet().ToSlice(); got[0] != "a" || got[2] != "c" {
+		t.Errorf("TreeMultiset should return a sorted set, got %v", got)
+	}
+	if got := NewLinkedHashMultisetFromSlice(elements).ElementSetAsSet().ToSlice(); got[0] != "b" || got[1] != "a" {
+		t.Errorf("LinkedHashMultiset should keep insertion order, got %v", got)
+	}
+}
+
+func TestMultisetFromSet(t *testing.T) {
+	ms := MultisetFromSet[string](set.FromSlice([]string{"x", "y", "z"}))
+	if ms.TotalSize() != 3 || ms.DistinctElements() != 3 || ms.Count("y") != 1 {
+		t.Errorf("MultisetFromSet should hold each element once, got %v", ms)
+	}
+	ms.Add("y")
+	if ms.Count("y") != 2 {
+		t.Error("Result should be a mutable multiset")
+	}
+	if !MultisetFromSet[int](set.New[int]()).IsEmpty() {
+		t.Error("MultisetFromSet of an empty set should be empty")
+	}
+}
+
+func TestMultisetWeightedRandom(t *testing.T) {
+	elements := []string{"a", "b", "b", "c", "c", "c"}
+	multisets := map[string]Multiset[string]{
+		"HashMultiset":           NewHashMultisetFromSlice(elements),
+		"TreeMultiset":           NewTreeMultisetFromSlice(elements),
+		"LinkedHashMultiset":     NewLinkedHashMultisetFromSlice(elements),
+		"ConcurrentHashMultiset": NewConcurrentHashMultisetFromSlice(elements),
+		"ImmutableMultiset":      NewImmutableMultisetFromSlice(elements),
+	}
+	const draws = 6000
+	for name, ms := range multisets {
+		r := rand.New(rand.NewSource(42))
+		counts := make(map[string]int)
+		for i := 0; i < draws; i++ {
+			element, ok := ms.WeightedRandom(r)
+			if !ok {
+				t.Fatalf("%s: WeightedRandom should succeed on a non-empty multiset", name)
+			}
+			counts[element]++
+		}
+		for _, element := range []string{"a", "b", "c"} {
+			expected := draws * ms.Count(element) / ms.TotalSize()
+			if diff := counts[element] - expected; diff < -300 || diff > 300 {
+				t.Errorf("%s: expected about %d draws of %s, got %d", name, expected, element, counts[element])
+			}
+		}
+	}
+
+	if _, ok := NewTreeMultiset[int]().WeightedRandom(nil); ok {
+		t.Error("WeightedRandom of an empty multiset should return false")
+	}
+	single := NewHashMultisetFromSlice([]int{7, 7})
+	if element, ok := single.WeightedRandom(nil); !ok || element != 7 {
+		t.Errorf("WeightedRandom with a nil source should still pick 7, got %d", element)
+	}
+}
+
+func TestMultisetRemoveAllOccurrences(t *testing.T) {
+	type subtractable interface {
+		Multiset[string]
+		RemoveAllOccurrences(other Multiset[string]) Multiset[string]
+	}
+	inventory := []string{"apple", "apple", "apple", "pear", "plum", "plum"}
+	multisets := map[string]subtractable{
+		"HashMultiset":           NewHashMultisetFromSlice(inventory),
+		"TreeMultiset":           NewTreeMultisetFromSlice(inventory),
+		"LinkedHashMultiset":     NewLinkedHashMultisetFromSlice(inventory),
+		"ConcurrentHashMultiset": NewConcurrentHashMultisetFromSlice(inventory),
+	}
+	shipped := NewHashMultisetFromSlice([]string{"apple", "apple", "plum", "plum", "plum", "kiwi"})
+
+	for name, ms := range multisets {
+		removed := ms.RemoveAllOccurrences(shipped)
+
+		if removed.Count("apple") != 2 || removed.Count("plum") != 2 || removed.Contains("kiwi") {
+			t.Errorf("%s: removed should hold 2 apples and 2 plums, got %v", name, removed)
+		}
+		if removed.TotalSize() != 4 {
+			t.Errorf("%s: expected 4 removed occurrences, got %d", name, removed.TotalSize())
+		}
+		if ms.Count("apple") != 1 || ms.Count("pear") != 1 || ms.Contains("plum") {
+			t.Errorf("%s: remaining inventory should be apple and pear, got %v", name, ms)
+		}
+		if ms.TotalSize() != 2 {
+			t.Errorf("%s: expected 2 remaining occurrences, got %d", name, ms.TotalSize())
+		}
+
+		all := ms.RemoveAllOccurrences(ms)
+		if all.TotalSize() != 2 || !ms.IsEmpty() {
+			t.Errorf("%s: subtracting itself should remove everything, got %v", name, ms)
+		}
+		if ms.RemoveAllOccurrences(nil).TotalSize() != 0 {
+			t.Errorf("%s: subtracting nil should remove nothing", name)
+		}
+	}
+}
+
+func TestHashMultisetBinaryRoundTrip(t *testing.T) {
+	ms := NewHashMultiset[string]()
+	ms.AddCount("a", 3)
+	ms.AddCount("b", 1)
+
+	var _ common.Serializable = ms
+	data, err := ms.MarshalBinary()
+	if err != nil {
+		t.Fatalf("MarshalBinary failed: %v", err)
+	}
+
+	restored := NewHashMultiset[string]()
+	restored.Add("stale")
+	if err := restored.UnmarshalBinary(data); err != nil {
+		t.Fatalf("UnmarshalBinary failed: %v", err)
+	}
+	if restored.Count("a") != 3 || restored.Count("b") != 1 || restored.Contains("stale") || restored.TotalSize() != 4 {
+		t.Errorf("Expected {a:3, b:1}, got %v", restored)
+	}
+
+	negative, _ := common.EncodeEntries([]common.Entry[string, int]{common.NewEntry("a", -1)})
+	if err := restored.UnmarshalBinary(negative); !errors.Is(err, common.ErrNegativeCount) {
+		t.Errorf("Expected ErrNegativeCount, got %v", err)
+	}
+	if restored.TotalSize() != 4 {
+		t.Errorf("Failed UnmarshalBinary should leave the multiset unchanged, got %v", restored)
+	}
+}
+
+func TestMultisetStringN(t *testing.T) {
+	ms := NewHashMultiset[string]()
+	ms.AddCount("rare", 1)
+	ms.AddCount("common", 9)
+	ms.AddCount("medium", 4)
+	if got := ms.StringN(2); got != "HashMultiset[common x 9, medium x 4, … (+1 more)]" {
+		t.Errorf("Expected most frequent elements first, got %q", got)
+	}
+
+	cms := NewConcurrentHashMultiset[string]()
+	cms.AddCount("b", 2)
+	cms.AddCount("a", 2)
+	cms.Add("c")
+	if got := cms.StringN(5); got != "ConcurrentHashMultiset[a x 2, b x 2, c]" {
+		t.Errorf("Expected ties in natural order, got %q", got)
+	}
+
+	// The bounded selection agrees with sorting every entry
+	big := NewHashMultiset[int]()
+	for i := 0; i < 1000; i++ {
+		big.AddCount(i, i%37+1)
+	}
+	want := "HashMultiset[36 x 37, 73 x 37, 110 x 37, 147 x 37, … (+996 more)]"
+	if got := big.StringN(4); got != want {
+		t.Errorf("Expected %q, got %q", want, got)
+	}
+	if got := big.StringN(0); got != "HashMultiset[… (+1000 more)]" {
+		t.Errorf("StringN(0) should only summarise, got %q", got)
+	}
+}
+
+func TestBoundedMultisetReject(t *testing.T) {
+	ms := NewBoundedMultiset[string](3)
+	var _ Multiset[string] = ms
+
+	ms.Add("a")
+	ms.Add("a")
+	if !ms.TryAdd("b") {
+		t.Error("TryAdd below the cap should store the occurrence")
+	}
+	if ms.TryAdd("c") || ms.Contains("c") {
+		t.Error("TryAdd at the cap should be rejected")
+	}
+	if prev := ms.Add("a"); prev != 2 || ms.Count("a") != 2 {
+		t.Errorf("Add at the cap should leave the count at 2, got prev %d, count %d", prev, ms.Count("a"))
+	}
+	if ms.TotalSize() != 3 || ms.Remaining() != 0 || ms.Seen() != 5 {
+		t.Errorf("Expected total 3, remaining 0, seen 5, got %d, %d, %d", ms.TotalSize(), ms.Remaining(), ms.Seen())
+	}
+
+	ms.Remove("a")
+	if _, err := ms.AddCount("d", 2); !errors.Is(err, common.ErrFullContainer) || ms.Contains("d") {
+		t.Errorf("AddCount past the cap should fail without changes, got %v", err)
+	}
+	if _, err := ms.AddCount("d", 1); err != nil || ms.Count("d") != 1 {
+		t.Errorf("AddCount within the cap should succeed, got %v", err)
+	}
+	if _, err := ms.SetCount("b", 5); !errors.Is(err, common.ErrFullContainer) || ms.Count("b") != 1 {
+		t.Errorf("SetCount past the cap should fail without changes, got %v", err)
+	}
+	if _, err := ms.SetCount("a", 0); err != nil || ms.Contains("a") || ms.Remaining() != 1 {
+		t.Errorf("SetCount to zero should free capacity, got %v", err)
+	}
+
+	if NewBoundedMultiset[int](0).MaxTotal() != 1 {
+		t.Error("A non-positive cap should be treated as 1")
+	}
+}
+
+func TestBoundedMultisetSample(t *testing.T) {
+	ms := NewBoundedMultisetWithPolicy[int](100, SampleOverflow)
+	ms.rand = rand.New(rand.NewSource(7))
+
+	// Offer 10000 occurrences with a 3:1 ratio between 0 and 1
+	for i := 0; i < 10000; i++ {
+		ms.Add(i % 4 / 3)
+	}
+	if ms.TotalSize() != 100 || ms.Seen() != 10000 {
+		t.Fatalf("Expected 100 stored of 10000 seen, got %d of %d", ms.TotalSize(), ms.Seen())
+	}
+	if zeros := ms.Count(0); zeros < 60 || zeros > 90 {
+		t.Errorf("Expected the sample to keep roughly 75%% zeros, got %d of 100", zeros)
+	}
+	if ms.Count(0)+ms.Count(1) != 100 {
+		t.Errorf("Counts should add up to the total, got %v", ms)
+	}
+}
+
+func TestFromIterator(t *testing.T) {
+	source := NewTreeMultisetFromSlice([]string{"a", "b", "a"})
+	ms := FromIterator(source.Iterator())
+	if ms.Count("a") != 2 || ms.Count("b") != 1 || ms.TotalSize() != 3 {
+		t.Errorf("Expected {a:2, b:1}, got %v", ms)
+	}
+	if FromIterator[int](nil).TotalSize() != 0 {
+		t.Error("A nil iterator should yield an empty multiset")
+	}
+}
+
+func TestMultisetEnclosesCounts(t *testing.T) {
+	elements := []string{"bolt", "bolt", "bolt", "nut", "nut", "washer"}
+	multisets := map[string]Multiset[string]{
+		"HashMultiset":           NewHashMultisetFromSlice(elements),
+		"TreeMultiset":           NewTreeMultisetFromSlice(elements),
+		"LinkedHashMultiset":     NewLinkedHashMultisetFromSlice(elements),
+		"ConcurrentHashMultiset": NewConcurrentHashMultisetFromSlice(elements),
+		"ImmutableMultiset":      NewImmutableMultisetFromSlice(elements),
+	}
+	for name, ms := range multisets {
+		if !ms.EnclosesCounts(map[string]int{"bolt": 3, "nut": 2}) {
+			t.Errorf("%s: exact counts should be enclosed", name)
+		}
+		if ms.EnclosesCounts(map[string]int{"bolt": 4}) {
+			t.Errorf("%s: insufficient count should not be enclosed", name)
+		}
+		if ms.EnclosesCounts(map[string]int{"screw": 1}) {
+			t.Errorf("%s: missing element should not be enclosed", name)
+		}
+		if !ms.EnclosesCounts(map[string]int{"screw": 0, "washer": -2}) {
+			t.Errorf("%s: non-positive requirements should always be met", name)
+		}
+		if !ms.EnclosesCounts(nil) {
+			t.Errorf("%s: empty requirements should be enclosed", name)
+		}
+	}
+}
+
+func TestConcurrentHashMultisetForEachWalksSegments(t *testing.T) {
+	ms := NewConcurrentHashMultiset[int]()
+	for i := 0; i < 1000; i++ {
+		ms.AddCount(i, i%3+1)
+	}
+
+	total, sum := 0, 0
+	ms.ForEach(func(e int) {
+		total++
+		sum += e
+	})
+	if total != ms.TotalSize() {
+		t.Errorf("ForEach should visit %d occurrences, visited %d", ms.TotalSize(), total)
+	}
+	want := 0
+	for i := 0; i < 1000; i++ {
+		want += i * (i%3 + 1)
+	}
+	if sum != want {
+		t.Errorf("ForEach element sum should be %d, got %d", want, sum)
+	}
+
+	allocs := testing.AllocsPerRun(10, func() {
+		ms.ForEach(func(int) { total++ })
+	})
+	if allocs > 0 {
+		t.Errorf("ForEach should not allocate, got %.0f allocations per run", allocs)
+	}
+
+	if got := len(ms.ToSlice()); got != ms.TotalSize() {
+		t.Errorf("ToSlice length should be %d, got %d", ms.TotalSize(), got)
+	}
+}
+
+func TestTreeMultisetUnionSorted(t *testing.T) {
+	a := NewTreeMultisetFromSlice([]int{1, 1, 2, 5, 9})
+	b := NewTreeMultisetFromSlice([]int{1, 2, 2, 2, 7})
+	c := NewTreeMultisetFromSlice([]int{9, 9, 9})
+
+	u := UnionSorted(a, nil, b, c)
+	want := UnionAll[int](a, b, c)
+	if u.TotalSize() != want.TotalSize() || u.DistinctElements() != want.DistinctElements() {
+		t.Fatalf("UnionSorted should match UnionAll, got %v want %v", u, want)
+	}
+	for _, e := range want.EntrySet() {
+		if got := u.Count(e.Element); got != e.Count {
+			t.Errorf("count of %d should be %d, got %d", e.Element, e.Count, got)
+		}
+	}
+	entries := u.EntrySet()
+	for i := 1; i < len(entries); i++ {
+		if entries[i-1].Element >= entries[i].Element {
+			t.Fatal("UnionSorted entries should be in ascending order")
+		}
+	}
+
+	// The built tree is height-balanced and keeps accepting updates
+	for n := 0; n < 100; n++ {
+		ms := NewTreeMultiset[int]()
+		for i := 0; i < n; i++ {
+			ms.AddCount(i, i%4+1)
+		}
+		built := UnionSorted(ms)
+		if built.height(built.root) > 1 && (built.balanceFactor(built.root) > 1 || built.balanceFactor(built.root) < -1) {
+			t.Fatalf("UnionSorted of %d elements should build a balanced tree", n)
+		}
+		if built.TotalSize() != ms.TotalSize() {
+			t.Fatalf("UnionSorted of %d elements should keep total size %d, got %d", n, ms.TotalSize(), built.TotalSize())
+		}
+	}
+	u.Add(3)
+	u.Remove(1)
+	if u.Count(3) != 1 || u.Count(1) != 1 {
+		t.Error("UnionSorted result should accept further updates")
+	}
+
+	if UnionSorted[int]().TotalSize() != 0 {
+		t.Error("UnionSorted of no multisets should be empty")
+	}
+}
+
+func TestLinkedHashMultisetReplace(t *testing.T) {
+	ms := NewLinkedHashMultisetFromSlice([]string{"a", "Go", "Go", "b", "go", "c"})
+
+	moved, err := ms.Replace("Go", "golang")
+	if err != nil || moved != 2 {
+		t.Fatalf("Replace should move 2 occurrences, got %d, %v", moved, err)
+	}
+	if got := ms.ElementSet(); strings.Join(got, ",") != "a,golang,b,go,c" {
+		t.Errorf("Replace should keep the old position, got %v", got)
+	}
+
+	// Replacing into an existing element merges it at the old position
+	moved, err = ms.Replace("golang", "go")
+	if err != nil || moved != 2 {
+		t.Fatalf("Replace should move 2 occurrences, got %d, %v", moved, err)
+	}
+	if got := ms.ElementSet(); strings.Join(got, ",") != "a,go,b,c" {
+		t.Errorf("merged element should take the old position, got %v", got)
+	}
+	if ms.Count("go") != 3 || ms.Count("golang") != 0 || ms.TotalSize() != 6 {
+		t.Errorf("merge should keep all occurrences, got go=%d total=%d", ms.Count("go"), ms.TotalSize())
+	}
+
+	if moved, err = ms.Replace("b", "b"); err != nil || moved != 1 {
+		t.Errorf("Replace with itself should be a no-op, got %d, %v", moved, err)
+	}
+	if _, err = ms.Replace("missing", "x"); !errors.Is(err, common.ErrElementNotFound) {
+		t.Errorf("Replace of a missing element should return ErrElementNotFound, got %v", err)
+	}
+}
+
+func TestMultisetStats(t *testing.T) {
+	elements := []string{"a", "b", "b", "c", "c", "c"}
+	multisets := map[string]common.StatsReporter{
+		"HashMultiset":           NewHashMultisetFromSlice(elements),
+		"TreeMultiset":           NewTreeMultisetFromSlice(elements),
+		"LinkedHashMultiset":     NewLinkedHashMultisetFromSlice(elements),
+		"ConcurrentHashMultiset": NewConcurrentHashMultisetFromSlice(elements),
+		"ImmutableMultiset":      NewImmutableMultisetFromSlice(elements),
+	}
+	for name, ms := range multisets {
+		stats := ms.Stats()
+		if stats.Len != 6 || stats.Distinct != 3 || stats.Capacity != 0 || stats.LoadFactor != 0 {
+			t.Errorf("%s: unexpected stats %+v", name, stats)
+		}
+	}
+
+	bounded := NewBoundedMultiset[string](8)
+	bounded.AddCount("a", 2)
+	bounded.AddCount("b", 4)
+	if stats := bounded.Stats(); stats.Len != 6 || stats.Distinct != 2 || stats.Capacity != 8 || stats.LoadFactor != 0.75 {
+		t.Errorf("BoundedMultiset: unexpected stats %+v", stats)
+	}
+}
+
+func TestGroupCount(t *testing.T) {
+	type ticket struct {
+		category string
+		status   string
+	}
+	tickets := []ticket{
+		{"bug", "open"},
+		{"bug", "closed"},
+		{"feature", "open"},
+		{"bug", "open"},
+		{"feature", "open"},
+	}
+
+	groups := GroupCount(tickets,
+		func(t ticket) string { return t.category },
+		func(t ticket) string { return t.status })
+	if len(groups) != 2 {
+		t.Fatalf("Expected 2 groups, got %d", len(groups))
+	}
+	if bugs := groups["bug"]; bugs.Count("open") != 2 || bugs.Count("closed") != 1 || bugs.TotalSize() != 3 {
+		t.Errorf("Expected bug statuses [open x 2, closed], got %v", bugs)
+	}
+	if features := groups["feature"]; features.Count("open") != 2 || features.DistinctElements() != 1 {
+		t.Errorf("Expected feature statuses [open x 2], got %v", features)
+	}
+	if _, ok := groups["docs"]; ok {
+		t.Error("Keys without elements should be absent")
+	}
+
+	if empty := GroupCount(nil, func(t ticket) string { return t.category }, func(t ticket) string { return t.status }); len(empty) != 0 {
+		t.Errorf("Expected no groups for no elements, got %v", empty)
+	}
+}
+
+func TestConcurrentHashMultisetWithCapacity(t *testing.T) {
+	ms := NewConcurrentHashMultisetWithCapacity[int](3, 10000)
+	if len(ms.segments) != 4 {
+		t.Errorf("Segment count should round up to 4, got %d", len(ms.segments))
+	}
+	if ms.segmentSize != 2500 {
+		t.Errorf("Each segment should be sized for 2500 elements, got %d", ms.segmentSize)
+	}
+	for i := 0; i < 10000; i++ {
+		ms.AddCount(i, 2)
+	}
+	if ms.Size() != 10000 || ms.TotalSize() != 20000 {
+		t.Errorf("Expected 10000 distinct and 20000 total, got %d and %d", ms.Size(), ms.TotalSize())
+	}
+	if clone := ms.Clone(); clone.segmentSize != 2500 {
+		t.Errorf("Clone should keep the segment sizing, got %d", clone.segmentSize)
+	}
+	ms.Clear()
+	if !ms.IsEmpty() || ms.segmentSize != 2500 {
+		t.Errorf("Clear should empty the multiset and keep its sizing, got %v with %d", ms, ms.segmentSize)
+	}
+
+	small := NewConcurrentHashMultisetWithCapacity[int](0, -1)
+	if len(small.segments) != defaultSegmentCount || small.segmentSize != defaultSegmentSize {
+		t.Errorf("Non-positive hints should use defaults, got %d segments of %d", len(small.segments), small.segmentSize)
+	}
+}
+
+func TestConcurrentHashMultisetCompareAndSetCount(t *testing.T) {
+	ms := NewConcurrentHashMultiset[string]()
+	if !ms.CompareAndSetCount("a", 0, 3) || ms.Count("a") != 3 {
+		t.Errorf("CAS from 0 should set count to 3, got %d", ms.Count("a"))
+	}
+	if ms.CompareAndSetCount("a", 2, 5) || ms.Count("a") != 3 {
+		t.Errorf("CAS with a stale expectation should fail, got %d", ms.Count("a"))
+	}
+	if !ms.CompareAndSetCount("a", 3, 0) || ms.Contains("a") || ms.TotalSize() != 0 {
+		t.Errorf("CAS to 0 should remove the element, got %v", ms)
+	}
+	if ms.CompareAndSetCount("a", -1, 1) || ms.CompareAndSetCount("a", 0, -1) {
+		t.Error("CAS with negative counts should fail")
+	}
+
+	// Concurrent increments through CAS loops never lose an update
+	const workers, increments = 8, 500
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < increments; i++ {
+				for {
+					current := ms.Count("tokens")
+					if ms.CompareAndSetCount("tokens", current, current+1) {
+						break
+					}
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	if ms.Count("tokens") != workers*increments || ms.TotalSize() != workers*increments {
+		t.Errorf("Expected %d tokens, got %d (total %d)", workers*increments, ms.Count("tokens"), ms.TotalSize())
+	}
+}
+
+func TestTreeMultisetQuantile(t *testing.T) {
+	ms := NewTreeMultiset[int]()
+	if _, ok := ms.Quantile(0.5); ok {
+		t.Error("Quantile of an empty multiset should report false")
+	}
+
+	// 1 x 50, 2 x 45, 3 x 4, 4 x 1
+	ms.AddCount(3, 4)
+	ms.AddCount(1, 50)
+	ms.AddCount(4, 1)
+	ms.AddCount(2, 45)
+	tests := []struct {
+		q    float64
+		want int
+	}{
+		{-1, 1}, {0, 1}, {0.5, 1}, {0.51, 2}, {0.95, 2}, {0.96, 3}, {0.99, 3}, {1, 4}, {2, 4},
+	}
+	for _, tt := range tests {
+		if got, ok := ms.Quantile(tt.q); !ok || got != tt.want {
+			t.Errorf("Quantile(%v) should be %d, got %d (ok=%v)", tt.q, tt.want, got, ok)
+		}
+	}
+	if p95, _ := ms.Percentile(95); p95 != 2 {
+		t.Errorf("Percentile(95) should be 2, got %d", p95)
+	}
+	if _, ok := ms.Quantile(math.NaN()); ok {
+		t.Error("Quantile(NaN) should report false")
+	}
+
+	// Subtree totals stay consistent through rotations, partial removals and deletions
+	r := rand.New(rand.NewSource(7))
+	ms = NewTreeMultiset[int]()
+	var reference []int
+	for i := 0; i < 2000; i++ {
+		e := r.Intn(200)
+		switch r.Intn(4) {
+		case 0:
+			ms.RemoveCount(e, r.Intn(3))
+		case 1:
+			ms.RemoveAll(e)
+		default:
+			ms.AddCount(e, 1+r.Intn(3))
+		}
+	}
+	reference = ms.ToSlice()
+	for i := range reference {
+		q := (float64(i) + 0.5) / float64(len(reference))
+		if got, _ := ms.Quantile(q); got != reference[i] {
+			t.Fatalf("Quantile(%v) should be %d, got %d", q, reference[i], got)
+		}
+	}
+	if median, _ := ms.Median(); median != reference[(len(reference)-1)/2] {
+		t.Errorf("Median should be %d, got %d", reference[(len(reference)-1)/2], median)
+	}
+}
+
+func TestSlidingWindowMultiset(t *testing.T) {
+	ms := NewSlidingWindowMultiset[string](3)
+	if !ms.IsEmpty() || ms.WindowSize() != 3 {
+		t.Errorf("New window should be empty with size 3, got %v", ms)
+	}
+
+	for _, e := range []string{"a", "b", "a"} {
+		if _, evicted := ms.Add(e); evicted {
+			t.Errorf("Adding %s to a window that is not full should not evict", e)
+		}
+	}
+	if ms.Count("a") != 2 || ms.Count("b") != 1 || ms.TotalSize() != 3 {
+		t.Errorf("Expected [a x 2, b], got %v", ms)
+	}
+
+	if old, evicted := ms.Add("c"); !evicted || old != "a" {
+		t.Errorf("Adding to a full window should evict a, got %s (evicted=%v)", old, evicted)
+	}
+	if old, evicted := ms.Add("c"); !evicted || old != "b" {
+		t.Errorf("Expected b to be evicted, got %s (evicted=%v)", old, evicted)
+	}
+	if ms.Count("a") != 1 || ms.Contains("b") || ms.Count("c") != 2 || ms.DistinctElements() != 2 {
+		t.Errorf("Expected [a, c x 2], got %v", ms)
+	}
+	if got := strings.Join(ms.ToSlice(), ""); got != "acc" {
+		t.Errorf("Window should hold acc from oldest to newest, got %s", got)
+	}
+	if got := ms.String(); got != "SlidingWindowMultiset(3)[a, c x 2]" {
+		t.Errorf("Unexpected string %s", got)
+	}
+
+	ms.Clear()
+	if !ms.IsEmpty() || ms.Contains("c") || len(ms.ToSlice()) != 0 {
+		t.Errorf("Clear should empty the window, got %v", ms)
+	}
+	ms.Add("z")
+	if ms.Count("z") != 1 || ms.TotalSize() != 1 {
+		t.Errorf("Window should be reusable after Clear, got %v", ms)
+	}
+
+	// Counts always match the last N elements of a long stream
+	const n = 50
+	stream := NewSlidingWindowMultiset[int](n)
+	var history []int
+	for i := 0; i < 1000; i++ {
+		v := (i * 7) % 13
+		stream.Add(v)
+		history = append(history, v)
+	}
+	expected := make(map[int]int)
+	for _, v := range history[len(history)-n:] {
+		expected[v]++
+	}
+	for v := 0; v < 13; v++ {
+		if stream.Count(v) != expected[v] {
+			t.Errorf("Count(%d) should be %d, got %d", v, expected[v], stream.Count(v))
+		}
+	}
+
+	if one := NewSlidingWindowMultiset[int](0); one.WindowSize() != 1 {
+		t.Errorf("Non-positive size should be treated as 1, got %d", one.WindowSize())
+	}
+}
+
+func TestBoundedMultisetUnmarshalAndClear(t *testing.T) {
+	data, err := NewHashMultisetFromSlice([]int{1, 1, 2, 3, 4, 5, 6, 7, 8, 9}).MarshalBinary()
+	if err != nil {
+		t.Fatalf("MarshalBinary failed: %v", err)
+	}
+
+	ms := NewBoundedMultiset[int](3)
+	ms.Add(42)
+	if err := ms.UnmarshalBinary(data); !errors.Is(err, common.ErrFullContainer) {
+		t.Errorf("Unmarshalling past the cap should fail with ErrFullContainer, got %v", err)
+	}
+	if ms.TotalSize() != 1 || ms.Count(42) != 1 || ms.Remaining() != 2 {
+		t.Errorf("A failed unmarshal should leave the multiset unchanged, got %v", ms)
+	}
+
+	small, _ := NewHashMultisetFromSlice([]int{7, 7}).MarshalBinary()
+	if err := ms.UnmarshalBinary(small); err != nil || ms.Count(7) != 2 || ms.Remaining() != 1 || ms.Seen() != 2 {
+		t.Errorf("Unmarshalling within the cap should succeed, got %v (err=%v, seen=%d)", ms, err, ms.Seen())
+	}
+
+	sampled := NewBoundedMultisetWithPolicy[int](2, SampleOverflow)
+	for i := 0; i < 100; i++ {
+		sampled.Add(i)
+	}
+	sampled.Clear()
+	if !sampled.IsEmpty() || sampled.Seen() != 0 {
+		t.Errorf("Clear should empty the multiset and reset Seen, got %v (seen=%d)", sampled, sampled.Seen())
+	}
+	sampled.Add(1)
+	sampled.Add(2)
+	if sampled.Count(1) != 1 || sampled.Count(2) != 1 || sampled.Seen() != 2 {
+		t.Errorf("A fresh reservoir should keep the first occurrences, got %v", sampled)
+	}
+}
+
+func TestBoundedMultisetClone(t *testing.T) {
+	ms := NewBoundedMultiset[string](3)
+	ms.AddCount("a", 2)
+	ms.Add("b")
+	ms.Add("c")
+
+	clone := ms.Clone()
+	if clone.MaxTotal() != 3 || clone.TotalSize() != 3 || clone.Count("a") != 2 || clone.Seen() != 4 {
+		t.Errorf("Clone should keep the contents, cap and Seen, got %v (cap=%d, seen=%d)", clone, clone.MaxTotal(), clone.Seen())
+	}
+	if clone.TryAdd("d") || clone.Count("d") != 0 {
+		t.Error("Adding past the cap on the clone should still be rejected")
+	}
+	clone.Remove("a")
+	if ms.Count("a") != 2 {
+		t.Error("Changing the clone should not affect the original")
+	}
+
+	sampled := NewBoundedMultisetWithPolicy[int](2, SampleOverflow)
+	if copied := sampled.Clone(); copied.policy != SampleOverflow {
+		t.Error("Clone should keep the overflow policy")
+	}
+}
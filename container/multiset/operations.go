@@ -0,0 +1,245 @@
+package multiset
+
+import (
+	"container/heap"
+	"math/rand"
+	"sort"
+
+	"github.com/chenjianyu/collections/container/common"
+	"github.com/chenjianyu/collections/container/set"
+)
+
+// UnionAll returns a new multiset whose count for each element is the maximum
+// count of that element across all the given multisets
+// The result is built in a single pass into one map, so combining k multisets is
+// linear in their total number of entries
+func UnionAll[E comparable](sets ...Multiset[E]) Multiset[E] {
+	counts := make(map[E]int)
+	for _, ms := range sets {
+		if ms == nil {
+			continue
+		}
+		for _, entry := range ms.EntrySet() {
+			if entry.Count > counts[entry.Element] {
+				counts[entry.Element] = entry.Count
+			}
+		}
+	}
+	return newHashMultisetFromCounts(counts)
+}
+
+// IntersectAll returns a new multiset whose count for each element is the minimum
+// count of that element across all the given multisets
+// Returns an empty multiset if no multisets are given or any of them is empty
+func IntersectAll[E comparable](sets ...Multiset[E]) Multiset[E] {
+	if len(sets) == 0 {
+		return NewHashMultiset[E]()
+	}
+
+	// Drive the intersection from the multiset with the fewest distinct elements
+	smallest := -1
+	for i, ms := range sets {
+		if ms == nil || ms.IsEmpty() {
+			return NewHashMultiset[E]()
+		}
+		if smallest < 0 || ms.DistinctElements() < sets[smallest].DistinctElements() {
+			smallest = i
+		}
+	}
+
+	counts := make(map[E]int)
+	for _, entry := range sets[smallest].EntrySet() {
+		minCount := entry.Count
+		for i, ms := range sets {
+			if i == smallest {
+				continue
+			}
+			if count := ms.Count(entry.Element); count < minCount {
+				minCount = count
+			}
+			if minCount == 0 {
+				break
+			}
+		}
+		if minCount > 0 {
+			counts[entry.Element] = minCount
+		}
+	}
+	return newHashMultisetFromCounts(counts)
+}
+
+// MultisetFromSet returns a new multiset holding each element of s once
+func MultisetFromSet[E comparable](s set.Set[E]) Multiset[E] {
+	counts := make(map[E]int, s.Size())
+	s.ForEach(func(element E) {
+		counts[element] = 1
+	})
+	return newHashMultisetFromCounts(counts)
+}
+
+// GroupCount groups elems by keyFn and counts the values produced by valFn within each group
+// Each key maps to a multiset of its group's values; keys with no elements are absent
+func GroupCount[E any, K comparable, V comparable](elems []E, keyFn func(E) K, valFn func(E) V) map[K]Multiset[V] {
+	groups := make(map[K]map[V]int)
+	for _, element := range elems {
+		key := keyFn(element)
+		counts := groups[key]
+		if counts == nil {
+			counts = make(map[V]int)
+			groups[key] = counts
+		}
+		counts[valFn(element)]++
+	}
+
+	result := make(map[K]Multiset[V], len(groups))
+	for key, counts := range groups {
+		result[key] = newHashMultisetFromCounts(counts)
+	}
+	return result
+}
+
+// newHashMultisetFromCounts wraps a count map in a HashMultiset without copying it
+func newHashMultisetFromCounts[E comparable](counts map[E]int) *HashMultiset[E] {
+	size := 0
+	for _, count := range counts {
+		size += count
+	}
+	return &HashMultiset[E]{
+		counts: counts,
+		size:   size,
+	}
+}
+
+// removeOccurrences subtracts other from ms and records the removed occurrences in removed
+// other's entries are snapshotted first, so other may be ms itself
+func removeOccurrences[E comparable](ms, other, removed Multiset[E]) Multiset[E] {
+	if other == nil {
+		return removed
+	}
+	for _, entry := range other.EntrySet() {
+		prevCount, err := ms.RemoveCount(entry.Element, entry.Count)
+		if err != nil {
+			continue
+		}
+		n := entry.Count
+		if prevCount < n {
+			n = prevCount
+		}
+		if n > 0 {
+			removed.AddCount(entry.Element, n)
+		}
+	}
+	return removed
+}
+
+// enclosesCounts reports whether count meets every positive requirement in required
+func enclosesCounts[E comparable](count func(E) int, required map[E]int) bool {
+	for element, n := range required {
+		if n > 0 && count(element) < n {
+			return false
+		}
+	}
+	return true
+}
+
+// weightedTarget draws a position in [0, total) from r, or from the global source when r is nil
+func weightedTarget(r *rand.Rand, total int) int {
+	if r == nil {
+		return rand.Intn(total)
+	}
+	return r.Intn(total)
+}
+
+// weightedPick chooses an entry with probability proportional to its count
+func weightedPick[E comparable](entries []Entry[E], r *rand.Rand) (E, bool) {
+	total := 0
+	for _, entry := range entries {
+		total += entry.Count
+	}
+	var zero E
+	if total <= 0 {
+		return zero, false
+	}
+	target := weightedTarget(r, total)
+	for _, entry := range entries {
+		if target < entry.Count {
+			return entry.Element, true
+		}
+		target -= entry.Count
+	}
+	return zero, false
+}
+
+// stringN formats the entries kept by top, highest count first with ties in natural order,
+// summarising the rest of the distinct elements
+func stringN[E comparable](prefix string, top *topEntries[E], distinct int) string {
+	b := common.NewLimitedStringBuilder(prefix, top.limit)
+	for _, entry := range top.sorted() {
+		if entry.Count == 1 {
+			b.Addf("%v", entry.Element)
+		} else {
+			b.Addf("%v x %d", entry.Element, entry.Count)
+		}
+	}
+	return b.Finish("]", distinct)
+}
+
+// listedBefore reports whether a is shown before b: higher count first, ties in natural order
+func listedBefore[E comparable](a, b Entry[E]) bool {
+	if a.Count != b.Count {
+		return a.Count > b.Count
+	}
+	return common.CompareNatural(a.Element, b.Element) < 0
+}
+
+// topEntries keeps the limit entries listed first among those offered
+// It is a container/heap whose root is the kept entry listed last, so selecting from d entries
+// takes O(d log limit) time and O(limit) memory
+type topEntries[E comparable] struct {
+	entries []Entry[E]
+	limit   int
+}
+
+// newTopEntries creates an empty selection of at most limit entries
+// A negative limit is treated as 0
+func newTopEntries[E comparable](limit int) *topEntries[E] {
+	if limit < 0 {
+		limit = 0
+	}
+	return &topEntries[E]{limit: limit}
+}
+
+// offer keeps entry if it is listed before one of the entries kept so far
+func (h *topEntries[E]) offer(entry Entry[E]) {
+	if h.limit == 0 {
+		return
+	}
+	if len(h.entries) < h.limit {
+		heap.Push(h, entry)
+	} else if listedBefore(entry, h.entries[0]) {
+		h.entries[0] = entry
+		heap.Fix(h, 0)
+	}
+}
+
+// sorted returns the kept entries in listing order
+func (h *topEntries[E]) sorted() []Entry[E] {
+	sort.Slice(h.entries, func(i, j int) bool {
+		return listedBefore(h.entries[i], h.entries[j])
+	})
+	return h.entries
+}
+
+func (h *topEntries[E]) Len() int { return len(h.entries) }
+
+func (h *topEntries[E]) Less(i, j int) bool { return listedBefore(h.entries[j], h.entries[i]) }
+
+func (h *topEntries[E]) Swap(i, j int) { h.entries[i], h.entries[j] = h.entries[j], h.entries[i] }
+
+func (h *topEntries[E]) Push(x interface{}) { h.entries = append(h.entries, x.(Entry[E])) }
+
+func (h *topEntries[E]) Pop() interface{} {
+	last := h.entries[len(h.entries)-1]
+	h.entries = h.entries[:len(h.entries)-1]
+	return last
+}
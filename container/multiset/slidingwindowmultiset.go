@@ -0,0 +1,142 @@
+package multiset
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// SlidingWindowMultiset counts the occurrences among the most recent elements of a stream
+// Add pushes an element and, once the window is full, evicts the oldest one, so counts always
+// reflect exactly the last WindowSize elements added
+// Counts are kept in a LinkedHashMultiset and arrival order in a ring buffer
+type SlidingWindowMultiset[E comparable] struct {
+	counts *LinkedHashMultiset[E]
+	window []E // ring buffer of the elements in the window
+	head   int // index of the oldest element
+	length int
+	mu     sync.RWMutex
+}
+
+// NewSlidingWindowMultiset creates a SlidingWindowMultiset over the last size elements
+// A non-positive size is treated as 1
+func NewSlidingWindowMultiset[E comparable](size int) *SlidingWindowMultiset[E] {
+	if size <= 0 {
+		size = 1
+	}
+	return &SlidingWindowMultiset[E]{
+		counts: NewLinkedHashMultiset[E](),
+		window: make([]E, size),
+	}
+}
+
+// Add pushes element into the window
+// Returns the evicted oldest element and true if the window was already full
+func (ms *SlidingWindowMultiset[E]) Add(element E) (E, bool) {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+
+	var evicted E
+	full := ms.length == len(ms.window)
+	if full {
+		evicted = ms.window[ms.head]
+		ms.counts.Remove(evicted)
+		ms.window[ms.head] = element
+		ms.head = (ms.head + 1) % len(ms.window)
+	} else {
+		ms.window[(ms.head+ms.length)%len(ms.window)] = element
+		ms.length++
+	}
+	ms.counts.Add(element)
+	return evicted, full
+}
+
+// WindowSize returns the maximum number of elements the window holds
+func (ms *SlidingWindowMultiset[E]) WindowSize() int {
+	return len(ms.window)
+}
+
+// Count returns the number of occurrences of element in the window
+func (ms *SlidingWindowMultiset[E]) Count(element E) int {
+	ms.mu.RLock()
+	defer ms.mu.RUnlock()
+	return ms.counts.Count(element)
+}
+
+// Contains returns true if element occurs in the window
+func (ms *SlidingWindowMultiset[E]) Contains(element E) bool {
+	return ms.Count(element) > 0
+}
+
+// TotalSize returns the number of elements currently in the window
+func (ms *SlidingWindowMultiset[E]) TotalSize() int {
+	ms.mu.RLock()
+	defer ms.mu.RUnlock()
+	return ms.length
+}
+
+// DistinctElements returns the number of distinct elements in the window
+func (ms *SlidingWindowMultiset[E]) DistinctElements() int {
+	ms.mu.RLock()
+	defer ms.mu.RUnlock()
+	return ms.counts.Size()
+}
+
+// IsEmpty returns true if no element has been added since creation or the last Clear
+func (ms *SlidingWindowMultiset[E]) IsEmpty() bool {
+	return ms.TotalSize() == 0
+}
+
+// EntrySet returns each distinct element in the window with its count
+// Elements are ordered as in LinkedHashMultiset, by when they entered the window with no other occurrence present
+func (ms *SlidingWindowMultiset[E]) EntrySet() []Entry[E] {
+	ms.mu.RLock()
+	defer ms.mu.RUnlock()
+	return ms.counts.EntrySet()
+}
+
+// ToSlice returns the elements in the window from oldest to newest
+func (ms *SlidingWindowMultiset[E]) ToSlice() []E {
+	ms.mu.RLock()
+	defer ms.mu.RUnlock()
+
+	result := make([]E, ms.length)
+	for i := range result {
+		result[i] = ms.window[(ms.head+i)%len(ms.window)]
+	}
+	return result
+}
+
+// Clear empties the window, keeping its size
+func (ms *SlidingWindowMultiset[E]) Clear() {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+
+	var zero E
+	for i := range ms.window {
+		ms.window[i] = zero
+	}
+	ms.head = 0
+	ms.length = 0
+	ms.counts.Clear()
+}
+
+// String returns a string representation of the counts in the window
+func (ms *SlidingWindowMultiset[E]) String() string {
+	entries := ms.EntrySet()
+
+	var builder strings.Builder
+	builder.WriteString(fmt.Sprintf("SlidingWindowMultiset(%d)[", len(ms.window)))
+	for i, entry := range entries {
+		if i > 0 {
+			builder.WriteString(", ")
+		}
+		if entry.Count == 1 {
+			builder.WriteString(fmt.Sprintf("%v", entry.Element))
+		} else {
+			builder.WriteString(fmt.Sprintf("%v x %d", entry.Element, entry.Count))
+		}
+	}
+	builder.WriteString("]")
+	return builder.String()
+}
@@ -2,10 +2,14 @@ package multiset
 
 import (
 	"fmt"
+	"math"
+	"math/rand"
 	"strings"
 	"sync"
 
 	"github.com/chenjianyu/collections/container/common"
+	"github.com/chenjianyu/collections/container/queue"
+	"github.com/chenjianyu/collections/container/set"
 )
 
 // TreeMultiset is a multiset implementation based on a balanced binary search tree
@@ -20,6 +24,7 @@ type TreeMultiset[E comparable] struct {
 type treeNode[E comparable] struct {
 	element E
 	count   int
+	total   int // sum of counts in this subtree, for rank queries
 	left    *treeNode[E]
 	right   *treeNode[E]
 	height  int
@@ -67,6 +72,7 @@ func (ms *TreeMultiset[E]) addNode(node *treeNode[E], element E) (*treeNode[E],
 		return &treeNode[E]{
 			element: element,
 			count:   1,
+			total:   1,
 			height:  1,
 		}, 0
 	}
@@ -81,6 +87,7 @@ func (ms *TreeMultiset[E]) addNode(node *treeNode[E], element E) (*treeNode[E],
 	} else {
 		prevCount = node.count
 		node.count++
+		node.total++
 		return node, prevCount
 	}
 	
@@ -110,6 +117,7 @@ func (ms *TreeMultiset[E]) addCountNode(node *treeNode[E], element E, count int)
 		return &treeNode[E]{
 			element: element,
 			count:   count,
+			total:   count,
 			height:  1,
 		}, 0
 	}
@@ -124,6 +132,7 @@ func (ms *TreeMultiset[E]) addCountNode(node *treeNode[E], element E, count int)
 	} else {
 		prevCount = node.count
 		node.count += count
+		node.total += count
 		return node, prevCount
 	}
 	
@@ -161,6 +170,7 @@ func (ms *TreeMultiset[E]) removeNode(node *treeNode[E], element E, count int) (
 		if node.count <= 0 {
 			return ms.deleteNode(node), prevCount
 		}
+		node.total -= count
 		return node, prevCount
 	}
 	
@@ -209,6 +219,7 @@ func (ms *TreeMultiset[E]) removeCountNode(node *treeNode[E], element E, count i
 		if node.count <= 0 {
 			return ms.deleteNode(node), prevCount, actualRemoved
 		}
+		node.total -= actualRemoved
 		return node, prevCount, actualRemoved
 	}
 	
@@ -370,6 +381,14 @@ func (ms *TreeMultiset[E]) DistinctElements() int {
 	return ms.Size()
 }
 
+// Stats returns the total and distinct element counts; tree nodes are allocated on demand,
+// so Capacity is 0
+func (ms *TreeMultiset[E]) Stats() common.ContainerStats {
+	ms.mu.RLock()
+	defer ms.mu.RUnlock()
+	return common.NewContainerStats(ms.size, ms.countNodes(ms.root), 0)
+}
+
 // Clear removes all elements from the multiset
 func (ms *TreeMultiset[E]) Clear() {
 	ms.mu.Lock()
@@ -378,6 +397,158 @@ func (ms *TreeMultiset[E]) Clear() {
 	ms.size = 0
 }
 
+// Clone returns an independent copy of this multiset
+// The copy shares the comparator and has the same tree shape as the original
+func (ms *TreeMultiset[E]) Clone() *TreeMultiset[E] {
+	ms.mu.RLock()
+	defer ms.mu.RUnlock()
+
+	return &TreeMultiset[E]{
+		root: ms.cloneNode(ms.root),
+		size: ms.size,
+		cmp:  ms.cmp,
+	}
+}
+
+func (ms *TreeMultiset[E]) cloneNode(node *treeNode[E]) *treeNode[E] {
+	if node == nil {
+		return nil
+	}
+	return &treeNode[E]{
+		element: node.element,
+		count:   node.count,
+		total:   node.total,
+		left:    ms.cloneNode(node.left),
+		right:   ms.cloneNode(node.right),
+		height:  node.height,
+	}
+}
+
+// Mode returns the most frequent element and its count
+// Ties are broken in favour of the smallest element; returns false if the multiset is empty
+func (ms *TreeMultiset[E]) Mode() (E, int, bool) {
+	ms.mu.RLock()
+	defer ms.mu.RUnlock()
+
+	var mode E
+	maxCount := 0
+	ms.forEachNodeInorder(ms.root, func(node *treeNode[E]) bool {
+		if node.count > maxCount {
+			mode, maxCount = node.element, node.count
+		}
+		return true
+	})
+	return mode, maxCount, maxCount > 0
+}
+
+// RemoveAllOccurrences removes up to other.Count(e) occurrences of each element e
+// Returns a new multiset holding the occurrences that were actually removed
+// The removed elements are ordered by this multiset's comparator
+func (ms *TreeMultiset[E]) RemoveAllOccurrences(other Multiset[E]) Multiset[E] {
+	return removeOccurrences[E](ms, other, NewTreeMultisetWithComparator(ms.cmp))
+}
+
+// WeightedRandom returns an element chosen with probability proportional to its count
+// A nil r uses the global math/rand source; returns false if the multiset is empty
+func (ms *TreeMultiset[E]) WeightedRandom(r *rand.Rand) (E, bool) {
+	ms.mu.RLock()
+	defer ms.mu.RUnlock()
+
+	var picked E
+	if ms.size == 0 {
+		return picked, false
+	}
+	target := weightedTarget(r, ms.size)
+	found := false
+	ms.forEachNodeInorder(ms.root, func(node *treeNode[E]) bool {
+		if target < node.count {
+			picked, found = node.element, true
+			return false
+		}
+		target -= node.count
+		return true
+	})
+	return picked, found
+}
+
+// FilterByCount returns a new multiset holding only the elements whose count is within [min, max]
+func (ms *TreeMultiset[E]) FilterByCount(min, max int) Multiset[E] {
+	result := NewTreeMultisetWithComparator(ms.cmp)
+	for _, entry := range ms.EntrySet() {
+		if entry.Count >= min && entry.Count <= max {
+			result.AddCount(entry.Element, entry.Count)
+		}
+	}
+	return result
+}
+
+// Median returns the median element, counting duplicates
+// For an even total size the lower of the two middle elements is returned
+// Returns false if the multiset is empty
+func (ms *TreeMultiset[E]) Median() (E, bool) {
+	ms.mu.RLock()
+	defer ms.mu.RUnlock()
+
+	var median E
+	if ms.size == 0 {
+		return median, false
+	}
+	return ms.nodeAtRank((ms.size - 1) / 2).element, true
+}
+
+// Quantile returns the element at quantile q of the sorted occurrences, counting duplicates
+// It uses the nearest-rank method: the smallest element whose cumulative count reaches q of the total,
+// so Quantile(0.5) equals Median; q is clamped to [0, 1]
+// Returns false if the multiset is empty or q is NaN; runs in O(log n) using per-node subtree totals
+func (ms *TreeMultiset[E]) Quantile(q float64) (E, bool) {
+	ms.mu.RLock()
+	defer ms.mu.RUnlock()
+
+	var result E
+	if ms.size == 0 || math.IsNaN(q) {
+		return result, false
+	}
+	rank := int(math.Ceil(q*float64(ms.size))) - 1
+	if rank < 0 || q <= 0 {
+		rank = 0
+	} else if rank >= ms.size || q >= 1 {
+		rank = ms.size - 1
+	}
+	return ms.nodeAtRank(rank).element, true
+}
+
+// Percentile returns the element at percentile p, which is Quantile(p / 100)
+func (ms *TreeMultiset[E]) Percentile(p float64) (E, bool) {
+	return ms.Quantile(p / 100)
+}
+
+// nodeAtRank returns the node holding the occurrence at 0-based rank in sorted order
+// The caller must hold the lock and ensure 0 <= rank < ms.size
+func (ms *TreeMultiset[E]) nodeAtRank(rank int) *treeNode[E] {
+	node := ms.root
+	for node != nil {
+		left := subtreeTotal(node.left)
+		if rank < left {
+			node = node.left
+		} else if rank < left+node.count {
+			return node
+		} else {
+			rank -= left + node.count
+			node = node.right
+		}
+	}
+	return nil
+}
+
+// forEachNodeInorder visits nodes in sorted order until fn returns false
+// Returns false if the traversal was stopped early
+func (ms *TreeMultiset[E]) forEachNodeInorder(node *treeNode[E], fn func(*treeNode[E]) bool) bool {
+	if node == nil {
+		return true
+	}
+	return ms.forEachNodeInorder(node.left, fn) && fn(node) && ms.forEachNodeInorder(node.right, fn)
+}
+
 // ElementSet returns a slice of distinct elements in sorted order
 func (ms *TreeMultiset[E]) ElementSet() []E {
 	ms.mu.RLock()
@@ -388,6 +559,15 @@ func (ms *TreeMultiset[E]) ElementSet() []E {
 	return elements
 }
 
+// ElementSetAsSet returns a new TreeSet holding the distinct elements, ordered by this multiset's comparator
+func (ms *TreeMultiset[E]) ElementSetAsSet() set.Set[E] {
+	result := set.NewTreeSetWithComparator[E](ms.cmp)
+	for _, element := range ms.ElementSet() {
+		result.Add(element)
+	}
+	return result
+}
+
 func (ms *TreeMultiset[E]) inorderElements(node *treeNode[E], elements *[]E) {
 	if node != nil {
 		ms.inorderElements(node.left, elements)
@@ -435,6 +615,9 @@ func (ms *TreeMultiset[E]) inorderSlice(node *treeNode[E], result *[]E) {
 }
 
 // Iterator returns an iterator over the multiset elements in sorted order
+// The iterator works on a snapshot of the entries taken under the read lock, so it
+// is safe to use while other goroutines modify the multiset: it yields exactly the
+// contents at the time of the call (or of the last Reset) and never observes later changes
 func (ms *TreeMultiset[E]) Iterator() common.Iterator[E] {
 	return &treeMultisetIterator[E]{
 		multiset: ms,
@@ -528,6 +711,18 @@ func (ms *TreeMultiset[E]) IsSupersetOf(other Multiset[E]) bool {
 	return other.IsSubsetOf(ms)
 }
 
+// EnclosesCounts returns true if every required element occurs at least its required count
+func (ms *TreeMultiset[E]) EnclosesCounts(required map[E]int) bool {
+	ms.mu.RLock()
+	defer ms.mu.RUnlock()
+	return enclosesCounts(func(element E) int {
+		if node := ms.findNode(ms.root, element); node != nil {
+			return node.count
+		}
+		return 0
+	}, required)
+}
+
 // String returns a string representation of the multiset
 func (ms *TreeMultiset[E]) String() string {
 	ms.mu.RLock()
@@ -540,7 +735,9 @@ func (ms *TreeMultiset[E]) String() string {
 	var builder strings.Builder
 	builder.WriteString("TreeMultiset[")
 	
-	entries := ms.EntrySet()
+	// Collect entries directly; calling EntrySet here would re-acquire the read lock
+	var entries []Entry[E]
+	ms.inorderEntries(ms.root, &entries)
 	for i, entry := range entries {
 		if i > 0 {
 			builder.WriteString(", ")
@@ -564,6 +761,7 @@ func (ms *TreeMultiset[E]) height(node *treeNode[E]) int {
 	return node.height
 }
 
+// updateHeight recomputes the node's height and subtree total from its children
 func (ms *TreeMultiset[E]) updateHeight(node *treeNode[E]) {
 	leftHeight := ms.height(node.left)
 	rightHeight := ms.height(node.right)
@@ -572,6 +770,15 @@ func (ms *TreeMultiset[E]) updateHeight(node *treeNode[E]) {
 	} else {
 		node.height = rightHeight + 1
 	}
+	node.total = node.count + subtreeTotal(node.left) + subtreeTotal(node.right)
+}
+
+// subtreeTotal returns the number of occurrences stored under node
+func subtreeTotal[E comparable](node *treeNode[E]) int {
+	if node == nil {
+		return 0
+	}
+	return node.total
 }
 
 func (ms *TreeMultiset[E]) balanceFactor(node *treeNode[E]) int {
@@ -629,6 +836,8 @@ type treeMultisetIterator[E comparable] struct {
 	entries  []Entry[E]
 	index    int
 	current  int
+	// canRemove is set by Next and cleared by Remove, so each returned occurrence is removed at most once
+	canRemove bool
 }
 
 func (it *treeMultisetIterator[E]) HasNext() bool {
@@ -648,31 +857,119 @@ func (it *treeMultisetIterator[E]) Next() (E, bool) {
 	
 	element := it.entries[it.index].Element
 	it.current++
+	it.canRemove = true
 	return element, true
 }
 
+// Reset takes a fresh snapshot of the multiset and restarts the iteration
 func (it *treeMultisetIterator[E]) Reset() {
-	it.entries = it.multiset.EntrySet()
+	it.multiset.mu.RLock()
+	defer it.multiset.mu.RUnlock()
+
+	var entries []Entry[E]
+	it.multiset.inorderEntries(it.multiset.root, &entries)
+	it.entries = entries
 	it.index = 0
 	it.current = 0
+	it.canRemove = false
 }
 
+// Remove removes one occurrence of the last returned element from the multiset
+// Returns false if Next has not been called since the last Remove or Reset
+// The snapshot is adjusted in place rather than re-read, so concurrent modifications
+// cannot shift the iterator's position
 func (it *treeMultisetIterator[E]) Remove() bool {
-	if it.index >= len(it.entries) || it.current == 0 {
+	if !it.canRemove {
 		return false
 	}
+	it.canRemove = false
 	
-	element := it.entries[it.index].Element
-	it.multiset.Remove(element)
-	
-	// Refresh entries after removal
-	it.entries = it.multiset.EntrySet()
-	if it.index >= len(it.entries) {
-		it.index = len(it.entries)
-		it.current = 0
-	} else if it.current > it.entries[it.index].Count {
-		it.current = it.entries[it.index].Count
+	entry := &it.entries[it.index]
+	if it.multiset.Remove(entry.Element) == 0 {
+		return false
 	}
+	entry.Count--
+	it.current--
 	
 	return true
-}
\ No newline at end of file
+}
+
+// UnionSorted returns a new TreeMultiset holding the union of the given tree multisets
+// As with Union, each element's count is its maximum count across the inputs
+// The already-sorted entries are combined with a single k-way merge and the result tree is
+// built directly from the merged run, avoiding one tree insertion per element
+// All inputs are expected to share the comparator of the first non-nil multiset
+func UnionSorted[E comparable](sets ...*TreeMultiset[E]) *TreeMultiset[E] {
+	var result *TreeMultiset[E]
+	var runs [][]Entry[E]
+	for _, ms := range sets {
+		if ms == nil {
+			continue
+		}
+		if result == nil {
+			result = &TreeMultiset[E]{cmp: ms.cmp}
+		}
+		if entries := ms.EntrySet(); len(entries) > 0 {
+			runs = append(runs, entries)
+		}
+	}
+	if result == nil {
+		return NewTreeMultiset[E]()
+	}
+
+	// Each cursor points at the next unread entry of one run
+	type cursor struct {
+		run int
+		pos int
+	}
+	cmp := result.cmp
+	heads := queue.NewPriorityQueueWithComparator(func(a, b cursor) int {
+		if c := cmp(runs[a.run][a.pos].Element, runs[b.run][b.pos].Element); c != 0 {
+			return c
+		}
+		return a.run - b.run
+	})
+	total := 0
+	for i, run := range runs {
+		total += len(run)
+		heads.Offer(cursor{run: i})
+	}
+
+	merged := make([]Entry[E], 0, total)
+	for !heads.IsEmpty() {
+		c, _ := heads.Poll()
+		entry := runs[c.run][c.pos]
+		if n := len(merged); n > 0 && cmp(merged[n-1].Element, entry.Element) == 0 {
+			if entry.Count > merged[n-1].Count {
+				merged[n-1].Count = entry.Count
+			}
+		} else {
+			merged = append(merged, entry)
+		}
+		if c.pos+1 < len(runs[c.run]) {
+			heads.Offer(cursor{run: c.run, pos: c.pos + 1})
+		}
+	}
+
+	result.root = result.buildBalanced(merged)
+	for _, entry := range merged {
+		result.size += entry.Count
+	}
+	return result
+}
+
+// buildBalanced builds a height-balanced subtree from entries sorted by element
+func (ms *TreeMultiset[E]) buildBalanced(entries []Entry[E]) *treeNode[E] {
+	if len(entries) == 0 {
+		return nil
+	}
+	mid := len(entries) / 2
+	node := &treeNode[E]{
+		element: entries[mid].Element,
+		count:   entries[mid].Count,
+		left:    ms.buildBalanced(entries[:mid]),
+		right:   ms.buildBalanced(entries[mid+1:]),
+	}
+	ms.updateHeight(node)
+	return node
+}
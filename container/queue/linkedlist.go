@@ -74,10 +74,9 @@ func (ll *LinkedList[E]) String() string {
 
 // Add adds an element to the tail of the queue
 func (ll *LinkedList[E]) Add(element E) error {
-	if ll.isFull() {
+	if !ll.Offer(element) {
 		return common.FullContainerError("LinkedListQueue", ll.maxCap)
 	}
-	ll.list.Add(element)
 	return nil
 }
 
@@ -92,50 +91,52 @@ func (ll *LinkedList[E]) Offer(element E) bool {
 
 // Remove removes and returns the element at the head of the queue
 func (ll *LinkedList[E]) Remove() (E, error) {
-	if ll.IsEmpty() {
-		var zero E
-		return zero, common.EmptyContainerError("LinkedListQueue")
-	}
-	val, success := ll.list.RemoveFirst()
-	if !success {
-		var zero E
-		return zero, common.EmptyContainerError("LinkedListQueue")
+	val, ok := ll.Poll()
+	if !ok {
+		return val, common.EmptyContainerError("LinkedListQueue")
 	}
 	return val, nil
 }
 
 // Poll removes and returns the element at the head of the queue
+// Returns zero value and false if the queue is empty
 func (ll *LinkedList[E]) Poll() (E, bool) {
-	val, success := ll.list.RemoveFirst()
-	return val, success
+	return ll.list.RemoveFirst()
 }
 
 // Element returns the element at the head of the queue without removing it
 func (ll *LinkedList[E]) Element() (E, error) {
-	return ll.list.GetFirst()
+	val, ok := ll.Peek()
+	if !ok {
+		return val, common.EmptyContainerError("LinkedListQueue")
+	}
+	return val, nil
 }
 
 // Peek returns the element at the head of the queue without removing it
+// Returns zero value and false if the queue is empty
 func (ll *LinkedList[E]) Peek() (E, bool) {
-	val, err := ll.list.GetFirst()
-	return val, err == nil
+	if ll.IsEmpty() {
+		var zero E
+		return zero, false
+	}
+	val, _ := ll.list.GetFirst()
+	return val, true
 }
 
 // AddFirst adds an element to the head of the queue
 func (ll *LinkedList[E]) AddFirst(element E) error {
-	if ll.isFull() {
+	if !ll.OfferFirst(element) {
 		return common.FullContainerError("LinkedListQueue", ll.maxCap)
 	}
-	ll.list.AddFirst(element)
 	return nil
 }
 
 // AddLast adds an element to the tail of the queue
 func (ll *LinkedList[E]) AddLast(element E) error {
-	if ll.isFull() {
+	if !ll.OfferLast(element) {
 		return common.FullContainerError("LinkedListQueue", ll.maxCap)
 	}
-	ll.list.AddLast(element)
 	return nil
 }
 
@@ -159,72 +160,63 @@ func (ll *LinkedList[E]) OfferLast(element E) bool {
 
 // RemoveFirst removes and returns the element at the head of the queue
 func (ll *LinkedList[E]) RemoveFirst() (E, error) {
-	if ll.IsEmpty() {
-		var zero E
-		return zero, common.EmptyContainerError("LinkedListQueue")
-	}
-	val, success := ll.list.RemoveFirst()
-	if !success {
-		var zero E
-		return zero, common.EmptyContainerError("LinkedListQueue")
+	val, ok := ll.PollFirst()
+	if !ok {
+		return val, common.EmptyContainerError("LinkedListQueue")
 	}
 	return val, nil
 }
 
 // RemoveLast removes and returns the element at the tail of the queue
 func (ll *LinkedList[E]) RemoveLast() (E, error) {
-	if ll.IsEmpty() {
-		var zero E
-		return zero, common.EmptyContainerError("LinkedListQueue")
-	}
-	val, success := ll.list.RemoveLast()
-	if !success {
-		var zero E
-		return zero, common.EmptyContainerError("LinkedListQueue")
+	val, ok := ll.PollLast()
+	if !ok {
+		return val, common.EmptyContainerError("LinkedListQueue")
 	}
 	return val, nil
 }
 
 // PollFirst removes and returns the element at the head of the queue
 func (ll *LinkedList[E]) PollFirst() (E, bool) {
-	val, err := ll.RemoveFirst()
-	return val, err == nil
+	return ll.list.RemoveFirst()
 }
 
 // PollLast removes and returns the element at the tail of the queue
 func (ll *LinkedList[E]) PollLast() (E, bool) {
-	val, err := ll.RemoveLast()
-	return val, err == nil
+	return ll.list.RemoveLast()
 }
 
 // GetFirst returns the element at the head of the queue without removing it
 func (ll *LinkedList[E]) GetFirst() (E, error) {
-	if ll.IsEmpty() {
-		var zero E
-		return zero, common.EmptyContainerError("LinkedListQueue")
+	val, ok := ll.PeekFirst()
+	if !ok {
+		return val, common.EmptyContainerError("LinkedListQueue")
 	}
-	return ll.list.GetFirst()
+	return val, nil
 }
 
 // GetLast returns the element at the tail of the queue without removing it
 func (ll *LinkedList[E]) GetLast() (E, error) {
-	if ll.IsEmpty() {
-		var zero E
-		return zero, common.EmptyContainerError("LinkedListQueue")
+	val, ok := ll.PeekLast()
+	if !ok {
+		return val, common.EmptyContainerError("LinkedListQueue")
 	}
-	return ll.list.GetLast()
+	return val, nil
 }
 
 // PeekFirst returns the element at the head of the queue without removing it
 func (ll *LinkedList[E]) PeekFirst() (E, bool) {
-	val, err := ll.GetFirst()
-	return val, err == nil
+	return ll.Peek()
 }
 
 // PeekLast returns the element at the tail of the queue without removing it
 func (ll *LinkedList[E]) PeekLast() (E, bool) {
-	val, err := ll.GetLast()
-	return val, err == nil
+	if ll.IsEmpty() {
+		var zero E
+		return zero, false
+	}
+	val, _ := ll.list.GetLast()
+	return val, true
 }
 
 // ToSlice returns a slice containing all elements in the queue
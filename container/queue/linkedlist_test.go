@@ -417,4 +417,40 @@ func TestLinkedListQueue_EmptyOperations(t *testing.T) {
 	if success {
 		t.Error("PollLast should return false when queue is empty")
 	}
-}
\ No newline at end of file
+}
+func TestLinkedListQueue_BoolAndErrorVariantsAgree(t *testing.T) {
+	q := WithCapacity[int](2)
+
+	if !q.Offer(1) || !q.OfferFirst(0) {
+		t.Error("Offer should succeed while the queue has room")
+	}
+	if q.Offer(2) || q.OfferLast(2) {
+		t.Error("Offer should return false when the queue is full")
+	}
+	if err := q.Add(2); !errors.Is(err, common.ErrFullContainer) {
+		t.Errorf("Add on full queue should return FullContainerError, got %v", err)
+	}
+	if err := q.AddFirst(2); !errors.Is(err, common.ErrFullContainer) {
+		t.Errorf("AddFirst on full queue should return FullContainerError, got %v", err)
+	}
+
+	if val, ok := q.PeekLast(); !ok || val != 1 {
+		t.Errorf("PeekLast should return (1, true), got (%d, %v)", val, ok)
+	}
+	if val, err := q.Element(); err != nil || val != 0 {
+		t.Errorf("Element should return (0, nil), got (%d, %v)", val, err)
+	}
+
+	q.Poll()
+	q.Poll()
+
+	if _, err := q.Element(); !errors.Is(err, common.ErrEmptyContainer) {
+		t.Errorf("Element on empty queue should return EmptyContainerError, got %v", err)
+	}
+	if _, err := q.Remove(); !errors.Is(err, common.ErrEmptyContainer) {
+		t.Errorf("Remove on empty queue should return EmptyContainerError, got %v", err)
+	}
+	if _, err := q.GetLast(); !errors.Is(err, common.ErrEmptyContainer) {
+		t.Errorf("GetLast on empty queue should return EmptyContainerError, got %v", err)
+	}
+}
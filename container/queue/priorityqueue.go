@@ -133,17 +133,16 @@ func (pq *PriorityQueue[E]) String() string {
 }
 
 // Add adds an element to the queue
+// Returns an error if the queue is full; use Offer to avoid the error allocation
 func (pq *PriorityQueue[E]) Add(element E) error {
-	if pq.isFull() {
+	if !pq.Offer(element) {
 		return common.FullContainerError("PriorityQueue", pq.maxCap)
 	}
-
-	pq.heap = append(pq.heap, element)
-	pq.heapifyUp(len(pq.heap) - 1)
 	return nil
 }
 
 // Offer adds an element to the queue
+// Returns false if the queue is full
 func (pq *PriorityQueue[E]) Offer(element E) bool {
 	if pq.isFull() {
 		return false
@@ -155,43 +154,55 @@ func (pq *PriorityQueue[E]) Offer(element E) bool {
 }
 
 // Remove removes and returns the highest priority element from the queue
+// Returns an error if the queue is empty; use Poll to avoid the error allocation
 func (pq *PriorityQueue[E]) Remove() (E, error) {
+	element, ok := pq.Poll()
+	if !ok {
+		return element, common.EmptyContainerError("PriorityQueue")
+	}
+	return element, nil
+}
+
+// Poll removes and returns the highest priority element from the queue
+// Returns zero value and false if the queue is empty
+func (pq *PriorityQueue[E]) Poll() (E, bool) {
 	if pq.IsEmpty() {
 		var zero E
-		return zero, common.EmptyContainerError("PriorityQueue")
+		return zero, false
 	}
 
 	root := pq.heap[0]
 	lastIndex := len(pq.heap) - 1
 	pq.heap[0] = pq.heap[lastIndex]
+	// Clear the vacated slot to prevent memory leak
+	pq.heap[lastIndex] = common.ZeroValue[E]()
 	pq.heap = pq.heap[:lastIndex]
 
 	if len(pq.heap) > 0 {
 		pq.heapifyDown(0)
 	}
 
-	return root, nil
-}
-
-// Poll removes and returns the highest priority element from the queue
-func (pq *PriorityQueue[E]) Poll() (E, bool) {
-	element, err := pq.Remove()
-	return element, err == nil
+	return root, true
 }
 
 // Element returns the highest priority element from the queue without removing it
+// Returns an error if the queue is empty; use Peek to avoid the error allocation
 func (pq *PriorityQueue[E]) Element() (E, error) {
-	if pq.IsEmpty() {
-		var zero E
-		return zero, common.EmptyContainerError("PriorityQueue")
+	element, ok := pq.Peek()
+	if !ok {
+		return element, common.EmptyContainerError("PriorityQueue")
 	}
-	return pq.heap[0], nil
+	return element, nil
 }
 
 // Peek returns the highest priority element from the queue without removing it
+// Returns zero value and false if the queue is empty
 func (pq *PriorityQueue[E]) Peek() (E, bool) {
-	element, err := pq.Element()
-	return element, err == nil
+	if pq.IsEmpty() {
+		var zero E
+		return zero, false
+	}
+	return pq.heap[0], true
 }
 
 // ToSlice returns a slice containing all elements in the queue
@@ -204,8 +215,18 @@ func (pq *PriorityQueue[E]) ToSlice() []E {
 
 // ToSortedSlice returns a slice of queue elements sorted by priority
 func (pq *PriorityQueue[E]) ToSortedSlice() []E {
+	result := make([]E, 0, len(pq.heap))
+	pq.ForEachOrdered(func(element E) {
+		result = append(result, element)
+	})
+	return result
+}
+
+// ForEachOrdered visits the elements in priority order without modifying the queue
+// It drains a temporary copy of the heap, so no result slice is allocated
+func (pq *PriorityQueue[E]) ForEachOrdered(fn func(E)) {
 	if pq.IsEmpty() {
-		return []E{}
+		return
 	}
 
 	// Create a copy of the priority queue
@@ -216,13 +237,10 @@ func (pq *PriorityQueue[E]) ToSortedSlice() []E {
 	}
 	copy(tempPQ.heap, pq.heap)
 
-	result := make([]E, 0, len(pq.heap))
 	for !tempPQ.IsEmpty() {
-		element, _ := tempPQ.Remove()
-		result = append(result, element)
+		element, _ := tempPQ.Poll()
+		fn(element)
 	}
-
-	return result
 }
 
 // Internal method: heapify up operation to maintain heap property
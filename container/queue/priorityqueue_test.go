@@ -336,6 +336,44 @@ func TestPriorityQueue_ToSortedSlice(t *testing.T) {
 	}
 }
 
+func TestPriorityQueue_ForEachOrdered(t *testing.T) {
+	pq := NewPriorityQueue[TestInt]()
+	pq.ForEachOrdered(func(TestInt) {
+		t.Error("ForEachOrdered on an empty queue should not call fn")
+	})
+
+	for _, v := range []TestInt{5, 3, 8, 1, 4} {
+		pq.Add(v)
+	}
+	before := pq.ToSlice()
+
+	var visited []TestInt
+	pq.ForEachOrdered(func(e TestInt) {
+		visited = append(visited, e)
+	})
+
+	expectedOrder := []TestInt{1, 3, 4, 5, 8}
+	if len(visited) != len(expectedOrder) {
+		t.Fatalf("Expected %d elements, got %d", len(expectedOrder), len(visited))
+	}
+	for i, expected := range expectedOrder {
+		if visited[i] != expected {
+			t.Errorf("Expected %v at position %d, got %v", expected, i, visited[i])
+		}
+	}
+
+	// The heap layout must be untouched
+	after := pq.ToSlice()
+	for i := range before {
+		if before[i] != after[i] {
+			t.Errorf("Heap changed at index %d: %v -> %v", i, before[i], after[i])
+		}
+	}
+	if top, _ := pq.Peek(); top != 1 {
+		t.Errorf("Expected Peek 1 after ForEachOrdered, got %v", top)
+	}
+}
+
 func TestPriorityQueue_Clear(t *testing.T) {
 	pq := NewPriorityQueue[TestInt]()
 
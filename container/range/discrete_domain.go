@@ -0,0 +1,139 @@
+package ranges
+
+import "math"
+
+// DiscreteDomain describes a type whose values have well-defined neighbours, such as integers
+// It lets range sets recognise that [1..5] and [6..10] leave no gap between them
+type DiscreteDomain[T comparable] interface {
+	// Next returns the value immediately after value, or false if value is the maximum
+	Next(value T) (T, bool)
+
+	// Previous returns the value immediately before value, or false if value is the minimum
+	Previous(value T) (T, bool)
+
+	// MinValue returns the smallest value of the domain, or false if it is unbounded below
+	MinValue() (T, bool)
+
+	// MaxValue returns the largest value of the domain, or false if it is unbounded above
+	MaxValue() (T, bool)
+
+	// Distance returns the number of Next steps from start to end, negative if end precedes start
+	// Results beyond the int64 range saturate at math.MinInt64 or math.MaxInt64
+	Distance(start, end T) int64
+}
+
+// Integer is the set of built-in integer types usable with IntegerDomain
+type Integer interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr
+}
+
+// integerDomain is the DiscreteDomain of a built-in integer type
+type integerDomain[T Integer] struct{}
+
+// IntegerDomain returns the DiscreteDomain of the integer type T
+func IntegerDomain[T Integer]() DiscreteDomain[T] {
+	return integerDomain[T]{}
+}
+
+// Next returns value+1, or false if it would overflow
+func (integerDomain[T]) Next(value T) (T, bool) {
+	next := value + 1
+	if next < value {
+		return value, false
+	}
+	return next, true
+}
+
+// Previous returns value-1, or false if it would underflow
+func (integerDomain[T]) Previous(value T) (T, bool) {
+	previous := value - 1
+	if previous > value {
+		return value, false
+	}
+	return previous, true
+}
+
+// MinValue returns the smallest value of T
+func (d integerDomain[T]) MinValue() (T, bool) {
+	max, _ := d.MaxValue()
+	if min := max + 1; min < max {
+		return min, true // signed: wraps to the most negative value
+	}
+	return 0, true
+}
+
+// MaxValue returns the largest value of T
+func (integerDomain[T]) MaxValue() (T, bool) {
+	max := T(1)
+	for max<<1|1 > max {
+		max = max<<1 | 1
+	}
+	return max, true
+}
+
+// Distance returns end-start, saturating at the int64 limits
+func (d integerDomain[T]) Distance(start, end T) int64 {
+	if end < start {
+		distance := d.Distance(end, start)
+		if distance == math.MaxInt64 {
+			return math.MinInt64
+		}
+		return -distance
+	}
+	// Two's complement subtraction is exact for any difference of 64-bit or narrower values
+	distance := uint64(end) - uint64(start)
+	if distance > math.MaxInt64 {
+		return math.MaxInt64
+	}
+	return int64(distance)
+}
+
+// measureRanges returns the number of domain values covered by ranges, saturating at math.MaxInt64
+// Unbounded ends are clamped to the domain's minimum or maximum
+func measureRanges[T comparable](ranges []Range[T], domain DiscreteDomain[T]) int64 {
+	var total int64
+	for _, r := range ranges {
+		first, ok := firstValue(r, domain)
+		if !ok {
+			continue
+		}
+		last, ok := lastValue(r, domain)
+		if !ok {
+			continue
+		}
+		distance := domain.Distance(first, last)
+		if distance < 0 {
+			continue // an open range with no values between its bounds
+		}
+		if distance == math.MaxInt64 || total > math.MaxInt64-distance-1 {
+			return math.MaxInt64
+		}
+		total += distance + 1
+	}
+	return total
+}
+
+// firstValue returns the smallest domain value in r
+func firstValue[T comparable](r Range[T], domain DiscreteDomain[T]) (T, bool) {
+	lower, lowerType, hasLower := r.LowerBound()
+	if !hasLower {
+		return domain.MinValue()
+	}
+	if lowerType == Open {
+		return domain.Next(lower)
+	}
+	return lower, true
+}
+
+// lastValue returns the largest domain value in r
+func lastValue[T comparable](r Range[T], domain DiscreteDomain[T]) (T, bool) {
+	upper, upperType, hasUpper := r.UpperBound()
+	if !hasUpper {
+		return domain.MaxValue()
+	}
+	if upperType == Open {
+		return domain.Previous(upper)
+	}
+	return upper, true
+}
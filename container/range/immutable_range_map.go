@@ -38,15 +38,20 @@ func NewImmutableRangeMapWithComparatorStrategy[K comparable, V any](strategy co
 
 // NewImmutableRangeMapFromEntries creates a new ImmutableRangeMap from existing entries
 func NewImmutableRangeMapFromEntries[K comparable, V any](entries []Entry[K, V]) RangeMap[K, V] {
-	// Create a mutable map to handle overlapping ranges
-	mutableMap := NewTreeRangeMap[K, V]()
+	// Create a mutable map to handle overlapping ranges, ordered like the ranges themselves
+	ranges := make([]Range[K], len(entries))
+	for i, entry := range entries {
+		ranges[i] = entry.Range
+	}
+	comparator := comparatorOf(ranges)
+	mutableMap := NewTreeRangeMapWithComparator[K, V](comparator)
 	for _, entry := range entries {
 		mutableMap.Put(entry.Range, entry.Value)
 	}
 
 	return &ImmutableRangeMap[K, V]{
 		entries:    convertToEntries(mutableMap.AsMapOfRanges()),
-		comparator: DefaultComparator[K],
+		comparator: comparator,
 	}
 }
 
@@ -260,3 +265,42 @@ func (irm *ImmutableRangeMap[K, V]) Entries() []common.Entry[Range[K], V] {
     }
     return entries
 }
+
+// ForEach calls f for each range-value pair in ascending range order
+func (irm *ImmutableRangeMap[K, V]) ForEach(f func(Range[K], V)) {
+	for _, e := range irm.entries {
+		f(e.Range, e.Value)
+	}
+}
+
+// EntryIterator returns an iterator over the range-value pairs in ascending range order
+func (irm *ImmutableRangeMap[K, V]) EntryIterator() common.Iterator[common.Entry[Range[K], V]] {
+	return &immutableRangeMapEntryIterator[K, V]{entries: irm.entries}
+}
+
+// immutableRangeMapEntryIterator implements an Iterator over ImmutableRangeMap entries
+type immutableRangeMapEntryIterator[K comparable, V any] struct {
+	entries []Entry[K, V]
+	next    int
+}
+
+// HasNext returns true if there are more entries to iterate
+func (it *immutableRangeMapEntryIterator[K, V]) HasNext() bool {
+	return it.next < len(it.entries)
+}
+
+// Next returns the next range-value pair
+func (it *immutableRangeMapEntryIterator[K, V]) Next() (common.Entry[Range[K], V], bool) {
+	if it.next >= len(it.entries) {
+		var zero common.Entry[Range[K], V]
+		return zero, false
+	}
+	e := it.entries[it.next]
+	it.next++
+	return common.NewEntry(e.Range, e.Value), true
+}
+
+// Remove removes the current entry (not supported on an immutable map)
+func (it *immutableRangeMapEntryIterator[K, V]) Remove() bool {
+	return false
+}
@@ -1,6 +1,7 @@
 package ranges
 
 import (
+    "fmt"
     "strings"
     "github.com/chenjianyu/collections/container/common"
 )
@@ -38,23 +39,104 @@ func NewImmutableRangeSetWithComparatorStrategy[T comparable](strategy common.Co
 
 // NewImmutableRangeSetFromRanges creates a new ImmutableRangeSet from existing ranges
 func NewImmutableRangeSetFromRanges[T comparable](ranges []Range[T]) RangeSet[T] {
-	// Create a mutable set to handle merging and sorting
-	mutableSet := NewTreeRangeSet[T]()
+	// Create a mutable set to handle merging and sorting, ordered like the ranges themselves
+	comparator := comparatorOf(ranges)
+	mutableSet := NewTreeRangeSetWithComparator(comparator)
 	for _, r := range ranges {
 		mutableSet.Add(r)
 	}
 
 	return &ImmutableRangeSet[T]{
 		ranges:     mutableSet.AsRanges(),
-		comparator: DefaultComparator[T],
+		comparator: comparator,
 	}
 }
 
+// ImmutableRangeSetBuilder accumulates ranges and builds a normalized ImmutableRangeSet
+// Ranges are coalesced as they are added, so Build only snapshots the result
+// By default nil and empty ranges are skipped; RejectEmpty turns them into an error reported by Build
+type ImmutableRangeSetBuilder[T comparable] struct {
+	set         RangeSet[T]
+	comparator  Comparator[T]
+	rejectEmpty bool
+	count       int
+	err         error
+}
+
+// NewImmutableRangeSetBuilder creates a builder ordered like the first range added to it
+func NewImmutableRangeSetBuilder[T comparable]() *ImmutableRangeSetBuilder[T] {
+	return &ImmutableRangeSetBuilder[T]{}
+}
+
+// NewImmutableRangeSetBuilderWithComparator creates a builder using the given comparator
+func NewImmutableRangeSetBuilderWithComparator[T comparable](cmp Comparator[T]) *ImmutableRangeSetBuilder[T] {
+	return &ImmutableRangeSetBuilder[T]{
+		set:        NewTreeRangeSetWithComparator(cmp),
+		comparator: cmp,
+	}
+}
+
+// RejectEmpty makes the builder record an error for nil or empty ranges instead of skipping them
+func (b *ImmutableRangeSetBuilder[T]) RejectEmpty() *ImmutableRangeSetBuilder[T] {
+	b.rejectEmpty = true
+	return b
+}
+
+// Add coalesces r into the ranges added so far
+// Once an error has been recorded further ranges are ignored
+func (b *ImmutableRangeSetBuilder[T]) Add(r Range[T]) *ImmutableRangeSetBuilder[T] {
+	index := b.count
+	b.count++
+	if b.err != nil {
+		return b
+	}
+	if r == nil || r.IsEmpty() {
+		if b.rejectEmpty {
+			b.err = common.InvalidArgumentError(fmt.Sprintf("range %d", index), "must not be nil or empty")
+		}
+		return b
+	}
+	if b.set == nil {
+		b.comparator = comparatorOf([]Range[T]{r})
+		b.set = NewTreeRangeSetWithComparator(b.comparator)
+	}
+	b.set.Add(r)
+	return b
+}
+
+// AddAll adds each of ranges in order
+func (b *ImmutableRangeSetBuilder[T]) AddAll(ranges []Range[T]) *ImmutableRangeSetBuilder[T] {
+	for _, r := range ranges {
+		b.Add(r)
+	}
+	return b
+}
+
+// Build returns an ImmutableRangeSet of the sorted, disconnected ranges added so far,
+// or the first error recorded by Add; the builder may keep being used afterwards
+func (b *ImmutableRangeSetBuilder[T]) Build() (RangeSet[T], error) {
+	if b.err != nil {
+		return nil, b.err
+	}
+	if b.set == nil {
+		return NewImmutableRangeSet[T](), nil
+	}
+	return &ImmutableRangeSet[T]{
+		ranges:     b.set.AsRanges(),
+		comparator: b.comparator,
+	}, nil
+}
+
 // Size returns the number of ranges in this set
 func (irs *ImmutableRangeSet[T]) Size() int {
 	return len(irs.ranges)
 }
 
+// RangeCount returns the number of disconnected ranges without copying them
+func (irs *ImmutableRangeSet[T]) RangeCount() int {
+	return len(irs.ranges)
+}
+
 // IsEmpty returns true if this range set is empty
 func (irs *ImmutableRangeSet[T]) IsEmpty() bool {
 	return len(irs.ranges) == 0
@@ -177,6 +259,14 @@ func (irs *ImmutableRangeSet[T]) ContainsRange(rangeToCheck Range[T]) bool {
 	return false
 }
 
+// Intersects returns true if any range in this set shares at least one value with r
+func (irs *ImmutableRangeSet[T]) Intersects(r Range[T]) bool {
+	if r == nil || r.IsEmpty() {
+		return false
+	}
+	return intersectsRanges(irs.ranges, r, irs.comparator)
+}
+
 // Encloses returns true if this range set encloses the other range set
 func (irs *ImmutableRangeSet[T]) Encloses(other RangeSet[T]) bool {
 	if other == nil || other.IsEmpty() {
@@ -199,6 +289,11 @@ func (irs *ImmutableRangeSet[T]) AsRanges() []Range[T] {
 	return result
 }
 
+// Measure returns the number of values of domain covered by this range set
+func (irs *ImmutableRangeSet[T]) Measure(domain DiscreteDomain[T]) int64 {
+	return measureRanges(irs.ranges, domain)
+}
+
 // Complement returns the complement of this range set
 func (irs *ImmutableRangeSet[T]) Complement() RangeSet[T] {
 	// Create a mutable set to compute complement
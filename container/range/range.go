@@ -33,6 +33,10 @@ type Range[T comparable] interface {
 	// enclosed by both this range and other
 	IsConnected(other Range[T]) bool
 	
+	// Overlaps returns true if this range and other share at least one value
+	// Unlike Intersection, no range is constructed
+	Overlaps(other Range[T]) bool
+	
 	// Intersection returns the maximal range enclosed by both this range and other
 	// Returns nil if no such range exists
 	Intersection(other Range[T]) Range[T]
@@ -79,11 +83,18 @@ type RangeSet[T comparable] interface {
 	// ContainsRange returns true if the range is entirely contained in this set
 	ContainsRange(rangeToCheck Range[T]) bool
 	
+	// Intersects returns true if any range in this set shares at least one value with r
+	Intersects(r Range[T]) bool
+	
 	// Encloses returns true if this range set encloses the other range set
 	Encloses(other RangeSet[T]) bool
 	
 	// AsRanges returns a view of the disconnected ranges that make up this range set
 	AsRanges() []Range[T]
+
+	// Measure returns the number of values of domain covered by this range set
+	// Unbounded ranges are clamped to the domain's limits; the result saturates at math.MaxInt64
+	Measure(domain DiscreteDomain[T]) int64
 	
 	// Complement returns the complement of this range set
 	Complement() RangeSet[T]
@@ -144,6 +155,12 @@ type RangeMap[K comparable, V any] interface {
 
     // Entries returns all range-value pairs as common entries with Range[K] as key
     Entries() []common.Entry[Range[K], V]
+
+	// ForEach calls f for each range-value pair in ascending range order without copying the map
+	ForEach(f func(Range[K], V))
+
+	// EntryIterator returns an iterator over the range-value pairs in ascending range order
+	EntryIterator() common.Iterator[common.Entry[Range[K], V]]
 }
 
 // Entry represents a range-value pair in a RangeMap
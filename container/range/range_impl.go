@@ -111,6 +111,17 @@ func Singleton[T comparable](value T) Range[T] {
 	return ClosedRange(value, value)
 }
 
+// comparatorOf returns the comparator stored in the first of ranges, falling back to
+// DefaultComparator when none of them carries one
+func comparatorOf[T comparable](ranges []Range[T]) Comparator[T] {
+	for _, r := range ranges {
+		if impl, ok := r.(*rangeImpl[T]); ok && impl.comparator != nil {
+			return impl.comparator
+		}
+	}
+	return DefaultComparator[T]
+}
+
 // LowerBound returns the lower bound of this range
 func (r *rangeImpl[T]) LowerBound() (T, BoundType, bool) {
 	return r.lowerBound, r.lowerType, r.hasLowerBound
@@ -146,8 +157,12 @@ func (r *rangeImpl[T]) ContainsRange(other Range[T]) bool {
 		return true
 	}
 	
-	// Check lower bound
-	if lowerBound, lowerType, hasLower := other.LowerBound(); hasLower {
+	// Check lower bound; an unbounded side only fits inside an unbounded side
+	lowerBound, lowerType, hasLower := other.LowerBound()
+	if !hasLower && r.hasLowerBound {
+		return false
+	}
+	if hasLower {
 		if !r.Contains(lowerBound) {
 			return false
 		}
@@ -160,7 +175,11 @@ func (r *rangeImpl[T]) ContainsRange(other Range[T]) bool {
 	}
 	
 	// Check upper bound
-	if upperBound, upperType, hasUpper := other.UpperBound(); hasUpper {
+	upperBound, upperType, hasUpper := other.UpperBound()
+	if !hasUpper && r.hasUpperBound {
+		return false
+	}
+	if hasUpper {
 		if !r.Contains(upperBound) {
 			return false
 		}
@@ -182,6 +201,27 @@ func (r *rangeImpl[T]) IsConnected(other Range[T]) bool {
 	return !r.Intersection(other).IsEmpty() || r.isAdjacent(other)
 }
 
+// Overlaps returns true if this range and other share at least one value
+func (r *rangeImpl[T]) Overlaps(other Range[T]) bool {
+	if other == nil || r.IsEmpty() || other.IsEmpty() {
+		return false
+	}
+	otherLower, otherLowerType, otherHasLower := other.LowerBound()
+	otherUpper, otherUpperType, otherHasUpper := other.UpperBound()
+	return lowerBelowUpper(r.comparator, r.lowerBound, r.lowerType, r.hasLowerBound, otherUpper, otherUpperType, otherHasUpper) &&
+		lowerBelowUpper(r.comparator, otherLower, otherLowerType, otherHasLower, r.upperBound, r.upperType, r.hasUpperBound)
+}
+
+// lowerBelowUpper reports whether some value lies at or above the lower bound and at or below the upper bound
+// A missing bound is unbounded in its direction
+func lowerBelowUpper[T comparable](cmp Comparator[T], lower T, lowerType BoundType, hasLower bool, upper T, upperType BoundType, hasUpper bool) bool {
+	if !hasLower || !hasUpper {
+		return true
+	}
+	c := cmp(lower, upper)
+	return c < 0 || (c == 0 && lowerType == Closed && upperType == Closed)
+}
+
 // isAdjacent checks if two ranges are adjacent (touching but not overlapping)
 func (r *rangeImpl[T]) isAdjacent(other Range[T]) bool {
 	otherImpl, ok := other.(*rangeImpl[T])
@@ -1,7 +1,10 @@
 package ranges
 
 import (
+	"errors"
+	"math"
 	"testing"
+	"github.com/chenjianyu/collections/container/common"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -558,4 +561,376 @@ func TestRangeEdgeCases(t *testing.T) {
 		
 		assert.False(t, rs.IsEmpty())
 	})
-}
\ No newline at end of file
+}
+func TestRangeReverseComparator(t *testing.T) {
+	reverse := func(a, b int) int { return b - a }
+
+	// In reverse order 10 comes before 1, so [10..1] covers 10, 9, ..., 1
+	r := NewRangeWithComparator(10, Closed, 1, Closed, reverse)
+	assert.False(t, r.IsEmpty(), "[10..1] is non-empty under a reverse comparator")
+	assert.True(t, r.Contains(10))
+	assert.True(t, r.Contains(5))
+	assert.True(t, r.Contains(1))
+	assert.False(t, r.Contains(11))
+	assert.False(t, r.Contains(0))
+
+	open := NewRangeWithComparator(10, Open, 1, Open, reverse)
+	assert.False(t, open.Contains(10))
+	assert.True(t, open.Contains(9))
+	assert.False(t, open.Contains(1))
+
+	// Natural-order range with the same bounds is empty
+	assert.True(t, NewRange(10, Closed, 1, Closed).IsEmpty())
+
+	// ContainsRange
+	inner := NewRangeWithComparator(8, Closed, 3, Closed, reverse)
+	assert.True(t, r.ContainsRange(inner))
+	assert.False(t, inner.ContainsRange(r))
+
+	// IsConnected: overlapping, touching and disjoint ranges
+	overlapping := NewRangeWithComparator(4, Closed, -5, Closed, reverse)
+	touching := NewRangeWithComparator(1, Open, -5, Closed, reverse)
+	disjoint := NewRangeWithComparator(0, Closed, -5, Closed, reverse)
+	assert.True(t, r.IsConnected(overlapping))
+	assert.True(t, r.IsConnected(touching))
+	assert.True(t, touching.IsConnected(r))
+	assert.False(t, r.IsConnected(disjoint))
+
+	// Intersection keeps the reverse ordering of the bounds
+	intersection := r.Intersection(overlapping)
+	lower, lowerType, _ := intersection.LowerBound()
+	upper, upperType, _ := intersection.UpperBound()
+	assert.Equal(t, 4, lower)
+	assert.Equal(t, Closed, lowerType)
+	assert.Equal(t, 1, upper)
+	assert.Equal(t, Closed, upperType)
+	assert.True(t, intersection.Contains(2))
+	assert.False(t, intersection.Contains(5))
+	assert.True(t, r.Intersection(disjoint).IsEmpty())
+
+	// Span covers both ranges in reverse order
+	span := r.Span(disjoint)
+	lower, _, _ = span.LowerBound()
+	upper, _, _ = span.UpperBound()
+	assert.Equal(t, 10, lower)
+	assert.Equal(t, -5, upper)
+	assert.True(t, span.Contains(0))
+	assert.False(t, span.Contains(11))
+
+	// Range sets built with the reverse comparator
+	rs := NewTreeRangeSetWithComparator[int](reverse)
+	rs.Add(NewRangeWithComparator(10, Closed, 6, Closed, reverse))
+	rs.Add(NewRangeWithComparator(7, Closed, 2, Closed, reverse))
+	rs.Add(NewRangeWithComparator(-3, Closed, -8, Closed, reverse))
+	assert.Equal(t, 2, rs.Size(), "overlapping reverse ranges should merge")
+	assert.True(t, rs.ContainsValue(9))
+	assert.True(t, rs.ContainsValue(2))
+	assert.False(t, rs.ContainsValue(0))
+	assert.True(t, rs.ContainsValue(-5))
+	ranges := rs.AsRanges()
+	first, _, _ := ranges[0].LowerBound()
+	assert.Equal(t, 10, first, "ranges should be ordered by the reverse comparator")
+
+	// Immutable collections built from reverse ranges adopt their comparator
+	irs := NewImmutableRangeSetFromRanges([]Range[int]{
+		NewRangeWithComparator(3, Closed, 1, Closed, reverse),
+		NewRangeWithComparator(9, Closed, 5, Closed, reverse),
+	})
+	assert.True(t, irs.ContainsValue(7))
+	assert.False(t, irs.ContainsValue(4))
+	first, _, _ = irs.AsRanges()[0].LowerBound()
+	assert.Equal(t, 9, first)
+
+	irm := NewImmutableRangeMapFromEntries([]Entry[int, string]{
+		{Range: NewRangeWithComparator(9, Closed, 5, Closed, reverse), Value: "high"},
+		{Range: NewRangeWithComparator(3, Closed, 1, Closed, reverse), Value: "low"},
+	})
+	value, ok := irm.Get(7)
+	assert.True(t, ok)
+	assert.Equal(t, "high", value)
+	_, ok = irm.Get(4)
+	assert.False(t, ok)
+}
+
+func TestTreeRangeSetCoalescing(t *testing.T) {
+	rs := NewTreeRangeSetCoalescing[int](IntegerDomain[int]())
+	rs.Add(ClosedRange(1, 5))
+	rs.Add(ClosedRange(6, 10))
+	assert.Equal(t, 1, rs.Size(), "[1..5] and [6..10] should coalesce")
+	assert.Equal(t, "{[1..10]}", rs.String())
+
+	// Open bounds are normalised through the domain: (10..15) starts at 11
+	rs.Add(OpenRange(10, 15))
+	assert.Equal(t, "{[1..15)}", rs.String())
+
+	// [20..25) ends at 24, which is not adjacent to 26
+	rs.Add(ClosedOpen(20, 25))
+	rs.Add(ClosedRange(26, 30))
+	assert.Equal(t, 3, rs.Size())
+
+	// Filling the gap at 25 joins everything after it; 15..19 is still missing
+	rs.Add(Singleton(25))
+	assert.Equal(t, "{[1..15), [20..30]}", rs.String())
+	rs.Add(ClosedRange(15, 19))
+	assert.Equal(t, "{[1..30]}", rs.String())
+
+	// Set operations keep coalescing
+	other := NewTreeRangeSetCoalescing[int](IntegerDomain[int]())
+	other.Add(ClosedRange(31, 40))
+	union := rs.Union(other)
+	assert.Equal(t, "{[1..40]}", union.String())
+
+	// Without a domain touching-but-disjoint integer ranges stay separate
+	plain := NewTreeRangeSet[int]()
+	plain.Add(ClosedRange(1, 5))
+	plain.Add(ClosedRange(6, 10))
+	assert.Equal(t, 2, plain.Size())
+}
+
+func TestIntegerDomain(t *testing.T) {
+	domain := IntegerDomain[int8]()
+	next, ok := domain.Next(5)
+	assert.True(t, ok)
+	assert.Equal(t, int8(6), next)
+	_, ok = domain.Next(127)
+	assert.False(t, ok, "Next of the maximum value should report false")
+	_, ok = domain.Previous(-128)
+	assert.False(t, ok, "Previous of the minimum value should report false")
+
+	unsigned := IntegerDomain[uint]()
+	_, ok = unsigned.Previous(0)
+	assert.False(t, ok)
+}
+
+func TestRangeSetMeasure(t *testing.T) {
+	domain := IntegerDomain[int]()
+	allocated := NewTreeRangeSet[int]()
+	allocated.Add(ClosedOpen(0, 100))   // 0..99
+	allocated.Add(Singleton(200))       // 200
+	allocated.Add(OpenClosed(300, 310)) // 301..310
+	allocated.Add(OpenRange(400, 401))  // no integers
+	assert.Equal(t, int64(111), allocated.Measure(domain))
+
+	immutable := NewImmutableRangeSetFromRanges(allocated.AsRanges())
+	assert.Equal(t, int64(111), immutable.Measure(domain))
+
+	assert.Equal(t, int64(0), NewTreeRangeSet[int]().Measure(domain))
+
+	// Unbounded ranges are clamped to the domain limits
+	small := NewTreeRangeSet[int8]()
+	small.Add(AtLeast[int8](100))
+	assert.Equal(t, int64(28), small.Measure(IntegerDomain[int8]()))
+	small.Add(LessThan[int8](0))
+	assert.Equal(t, int64(156), small.Measure(IntegerDomain[int8]()))
+
+	huge := NewTreeRangeSet[uint64]()
+	huge.Add(All[uint64]())
+	assert.Equal(t, int64(math.MaxInt64), huge.Measure(IntegerDomain[uint64]()))
+}
+
+func TestIntegerDomainLimits(t *testing.T) {
+	min8, _ := IntegerDomain[int8]().MinValue()
+	max8, _ := IntegerDomain[int8]().MaxValue()
+	assert.Equal(t, int8(-128), min8)
+	assert.Equal(t, int8(127), max8)
+	minU, _ := IntegerDomain[uint16]().MinValue()
+	maxU, _ := IntegerDomain[uint16]().MaxValue()
+	assert.Equal(t, uint16(0), minU)
+	assert.Equal(t, uint16(65535), maxU)
+
+	assert.Equal(t, int64(255), IntegerDomain[int8]().Distance(-128, 127))
+	assert.Equal(t, int64(-3), IntegerDomain[uint]().Distance(5, 2))
+	assert.Equal(t, int64(math.MaxInt64), IntegerDomain[int64]().Distance(math.MinInt64, math.MaxInt64))
+	assert.Equal(t, int64(math.MinInt64), IntegerDomain[int64]().Distance(math.MaxInt64, math.MinInt64))
+}
+
+func TestTreeRangeSetAsRangesCache(t *testing.T) {
+	rs := NewTreeRangeSet[int]().(*TreeRangeSet[int])
+	rs.Add(ClosedRange(1, 5))
+	rs.Add(ClosedRange(10, 15))
+	assert.Equal(t, 2, len(rs.AsRanges()))
+
+	first := rs.AsRanges()
+	second := rs.AsRanges()
+	assert.Equal(t, 2, len(first))
+	assert.True(t, &first[0] == &second[0], "unchanged set should return the cached snapshot")
+
+	rs.Add(ClosedRange(20, 25))
+	third := rs.AsRanges()
+	assert.Equal(t, 3, len(third))
+	assert.Equal(t, 2, len(first), "earlier snapshot must not change")
+	assert.False(t, &first[0] == &third[0])
+
+	rs.Remove(ClosedRange(1, 5))
+	assert.Equal(t, 2, len(rs.AsRanges()))
+	assert.Equal(t, 2, len(rs.AsRanges()))
+
+	rs.Clear()
+	assert.Equal(t, 0, len(rs.AsRanges()))
+	assert.Equal(t, 0, rs.RangeCount())
+	assert.Equal(t, 3, len(third))
+}
+
+func TestRangeOverlapsAndIntersects(t *testing.T) {
+	r := ClosedRange(1, 5)
+	assert.True(t, r.Overlaps(ClosedRange(5, 10)), "shared closed endpoint overlaps")
+	assert.False(t, r.Overlaps(OpenClosed(5, 10)), "open endpoint does not overlap")
+	assert.False(t, ClosedOpen(1, 5).Overlaps(ClosedRange(5, 10)))
+	assert.True(t, r.Overlaps(ClosedRange(2, 3)))
+	assert.True(t, r.Overlaps(AtMost(1)))
+	assert.False(t, r.Overlaps(LessThan(1)))
+	assert.True(t, r.Overlaps(All[int]()))
+	assert.False(t, r.Overlaps(ClosedRange(6, 10)))
+	assert.False(t, r.Overlaps(OpenRange(3, 3)), "empty range overlaps nothing")
+	assert.False(t, r.Overlaps(nil))
+
+	rs := NewTreeRangeSet[int]()
+	rs.Add(ClosedRange(1, 5))
+	rs.Add(ClosedRange(10, 15))
+	rs.Add(AtLeast(100))
+	assert.True(t, rs.Intersects(ClosedRange(4, 8)))
+	assert.True(t, rs.Intersects(ClosedRange(15, 20)))
+	assert.False(t, rs.Intersects(OpenRange(5, 10)))
+	assert.False(t, rs.Intersects(ClosedRange(16, 99)))
+	assert.True(t, rs.Intersects(GreaterThan(50)))
+	assert.True(t, rs.Intersects(LessThan(2)))
+	assert.False(t, rs.Intersects(LessThan(1)))
+	assert.False(t, rs.Intersects(OpenRange(3, 3)))
+	assert.False(t, NewTreeRangeSet[int]().Intersects(All[int]()))
+
+	irs := NewImmutableRangeSetFromRanges(rs.AsRanges())
+	assert.True(t, irs.Intersects(ClosedRange(12, 12)))
+	assert.False(t, irs.Intersects(ClosedRange(6, 9)))
+
+	reverse := func(a, b int) int { return b - a }
+	rrs := NewTreeRangeSetWithComparator[int](reverse)
+	rrs.Add(NewRangeWithComparator(10, Closed, 6, Closed, reverse))
+	rrs.Add(NewRangeWithComparator(-3, Closed, -8, Closed, reverse))
+	assert.True(t, rrs.Intersects(NewRangeWithComparator(7, Closed, 0, Closed, reverse)))
+	assert.False(t, rrs.Intersects(NewRangeWithComparator(5, Closed, -2, Closed, reverse)))
+}
+
+func TestTreeRangeMapPutSplitsOverlappedEntries(t *testing.T) {
+	rm := NewTreeRangeMap[int, string]()
+	rm.Put(ClosedRange(1, 10), "y")
+	rm.Put(ClosedRange(3, 7), "x")
+
+	entries := rm.Entries()
+	assert.Equal(t, 3, len(entries))
+	assert.Equal(t, "[1..3)", entries[0].Key.String())
+	assert.Equal(t, "y", entries[0].Value)
+	assert.Equal(t, "[3..7]", entries[1].Key.String())
+	assert.Equal(t, "x", entries[1].Value)
+	assert.Equal(t, "(7..10]", entries[2].Key.String())
+	assert.Equal(t, "y", entries[2].Value)
+
+	for key, want := range map[int]string{1: "y", 2: "y", 3: "x", 7: "x", 8: "y", 10: "y"} {
+		got, ok := rm.Get(key)
+		assert.True(t, ok)
+		assert.Equal(t, want, got, "key %d", key)
+	}
+
+	// Adjacent entries are untouched and unbounded bases keep both flanks
+	rm = NewTreeRangeMap[int, string]()
+	rm.Put(All[int](), "base")
+	rm.Put(ClosedRange(0, 5), "override")
+	rm.Put(OpenClosed(5, 8), "next")
+	assert.Equal(t, 4, rm.Size())
+	value, _ := rm.Get(-100)
+	assert.Equal(t, "base", value)
+	value, _ = rm.Get(5)
+	assert.Equal(t, "override", value)
+	value, _ = rm.Get(6)
+	assert.Equal(t, "next", value)
+	value, _ = rm.Get(100)
+	assert.Equal(t, "base", value)
+
+	// Remove keeps the unbounded flank too
+	rm.Remove(LessThan(0))
+	_, ok := rm.Get(-1)
+	assert.False(t, ok)
+	value, _ = rm.Get(0)
+	assert.Equal(t, "override", value)
+}
+
+func TestRangeMapForEachAndEntryIterator(t *testing.T) {
+	rm := NewTreeRangeMap[int, string]()
+	rm.Put(ClosedRange(10, 15), "b")
+	rm.Put(ClosedRange(1, 5), "a")
+	rm.Put(ClosedRange(20, 25), "c")
+
+	var values []string
+	rm.ForEach(func(r Range[int], v string) {
+		values = append(values, v+r.String())
+	})
+	assert.Equal(t, []string{"a[1..5]", "b[10..15]", "c[20..25]"}, values)
+
+	values = nil
+	it := rm.EntryIterator()
+	for it.HasNext() {
+		e, ok := it.Next()
+		assert.True(t, ok)
+		values = append(values, e.Value)
+		if e.Value == "b" {
+			assert.True(t, it.Remove())
+			assert.False(t, it.Remove(), "second Remove without Next should fail")
+		}
+	}
+	assert.Equal(t, []string{"a", "b", "c"}, values)
+	_, ok := it.Next()
+	assert.False(t, ok)
+	assert.Equal(t, 2, rm.Size())
+	_, ok = rm.Get(12)
+	assert.False(t, ok)
+
+	irm := NewImmutableRangeMapFromEntries([]Entry[int, string]{
+		{Range: ClosedRange(5, 6), Value: "y"},
+		{Range: ClosedRange(1, 2), Value: "x"},
+	})
+	values = nil
+	irm.ForEach(func(_ Range[int], v string) { values = append(values, v) })
+	assert.Equal(t, []string{"x", "y"}, values)
+	iit := irm.EntryIterator()
+	e, ok := iit.Next()
+	assert.True(t, ok)
+	assert.Equal(t, "x", e.Value)
+	assert.False(t, iit.Remove())
+	assert.True(t, iit.HasNext())
+	assert.Equal(t, 2, irm.Size())
+}
+
+func TestImmutableRangeSetBuilder(t *testing.T) {
+	rs, err := NewImmutableRangeSetBuilder[int]().
+		Add(ClosedRange(5, 10)).
+		Add(ClosedRange(1, 3)).
+		Add(nil).
+		AddAll([]Range[int]{OpenRange(4, 4), ClosedRange(2, 6), ClosedRange(20, 30)}).
+		Build()
+	assert.NoError(t, err)
+	ranges := rs.AsRanges()
+	assert.Equal(t, 2, len(ranges))
+	assert.Equal(t, "[1..10]", ranges[0].String())
+	assert.Equal(t, "[20..30]", ranges[1].String())
+
+	empty, err := NewImmutableRangeSetBuilder[int]().Build()
+	assert.NoError(t, err)
+	assert.True(t, empty.IsEmpty())
+
+	strict := NewImmutableRangeSetBuilder[int]().RejectEmpty().
+		Add(ClosedRange(1, 2)).
+		Add(ClosedOpen(3, 3)).
+		Add(ClosedRange(5, 6))
+	rs, err = strict.Build()
+	assert.Nil(t, rs)
+	assert.True(t, errors.Is(err, common.ErrInvalidArgument))
+	assert.Contains(t, err.Error(), "range 1")
+
+	desc := func(a, b int) int { return b - a }
+	rs, err = NewImmutableRangeSetBuilderWithComparator(desc).
+		Add(NewRangeWithComparator(10, Closed, 5, Closed, desc)).
+		Add(NewRangeWithComparator(4, Closed, 1, Closed, desc)).
+		Build()
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(rs.AsRanges()))
+	assert.True(t, rs.ContainsValue(7))
+}
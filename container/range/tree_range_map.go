@@ -135,6 +135,7 @@ func (trm *TreeRangeMap[K, V]) GetEntry(key K) (Range[K], V, bool) {
 }
 
 // Remove removes all mappings from the specified range
+// Entries that only partly overlap the range keep their value outside it
 func (trm *TreeRangeMap[K, V]) Remove(rangeToRemove Range[K]) {
 	if rangeToRemove == nil || rangeToRemove.IsEmpty() {
 		return
@@ -143,30 +144,7 @@ func (trm *TreeRangeMap[K, V]) Remove(rangeToRemove Range[K]) {
 	trm.mutex.Lock()
 	defer trm.mutex.Unlock()
 	
-	var newEntries []Entry[K, V]
-	
-	for _, entry := range trm.entries {
-		if !entry.Range.IsConnected(rangeToRemove) {
-			// No overlap, keep the entry
-			newEntries = append(newEntries, entry)
-		} else if rangeToRemove.ContainsRange(entry.Range) {
-			// Range to remove completely contains the entry, remove it
-			continue
-		} else {
-			// Partial overlap - split the range
-			splitRanges := trm.splitRange(entry.Range, rangeToRemove)
-			for _, splitRange := range splitRanges {
-				if !splitRange.IsEmpty() {
-					newEntries = append(newEntries, Entry[K, V]{
-						Range: splitRange,
-						Value: entry.Value,
-					})
-				}
-			}
-		}
-	}
-	
-	trm.entries = newEntries
+	trm.removeOverlapping(rangeToRemove)
 	trm.sortEntries()
 }
 
@@ -258,13 +236,85 @@ func (trm *TreeRangeMap[K, V]) Entries() []common.Entry[Range[K], V] {
     return entries
 }
 
-// Helper method to remove overlapping ranges
+// ForEach calls f for each range-value pair in ascending range order
+// f must not modify the map
+func (trm *TreeRangeMap[K, V]) ForEach(f func(Range[K], V)) {
+	trm.mutex.RLock()
+	defer trm.mutex.RUnlock()
+
+	for _, e := range trm.entries {
+		f(e.Range, e.Value)
+	}
+}
+
+// EntryIterator returns a live iterator over the range-value pairs in ascending range order
+// Each step reads the current entries, so no copy of the map is made; Remove deletes the
+// entry last returned by Next
+func (trm *TreeRangeMap[K, V]) EntryIterator() common.Iterator[common.Entry[Range[K], V]] {
+	return &treeRangeMapEntryIterator[K, V]{trm: trm, last: -1}
+}
+
+// treeRangeMapEntryIterator implements a live Iterator over TreeRangeMap entries
+type treeRangeMapEntryIterator[K comparable, V any] struct {
+	trm  *TreeRangeMap[K, V]
+	next int // index of the next entry to return
+	last int // index of the entry last returned, or -1
+}
+
+// HasNext returns true if there are more entries to iterate
+func (it *treeRangeMapEntryIterator[K, V]) HasNext() bool {
+	it.trm.mutex.RLock()
+	defer it.trm.mutex.RUnlock()
+	return it.next < len(it.trm.entries)
+}
+
+// Next returns the next range-value pair
+func (it *treeRangeMapEntryIterator[K, V]) Next() (common.Entry[Range[K], V], bool) {
+	it.trm.mutex.RLock()
+	defer it.trm.mutex.RUnlock()
+
+	if it.next >= len(it.trm.entries) {
+		var zero common.Entry[Range[K], V]
+		return zero, false
+	}
+	e := it.trm.entries[it.next]
+	it.last = it.next
+	it.next++
+	return common.NewEntry(e.Range, e.Value), true
+}
+
+// Remove removes the entry last returned by Next
+func (it *treeRangeMapEntryIterator[K, V]) Remove() bool {
+	it.trm.mutex.Lock()
+	defer it.trm.mutex.Unlock()
+
+	if it.last < 0 || it.last >= len(it.trm.entries) {
+		return false
+	}
+	entries := it.trm.entries
+	copy(entries[it.last:], entries[it.last+1:])
+	entries[len(entries)-1] = Entry[K, V]{}
+	it.trm.entries = entries[:len(entries)-1]
+	it.next = it.last
+	it.last = -1
+	return true
+}
+
+// Helper method to remove the part of every entry that overlaps rangeKey
+// The flanking pieces of a partly overlapped entry keep that entry's value
 func (trm *TreeRangeMap[K, V]) removeOverlapping(rangeKey Range[K]) {
 	var newEntries []Entry[K, V]
 	
 	for _, entry := range trm.entries {
-		if !entry.Range.IsConnected(rangeKey) {
+		if !entry.Range.Overlaps(rangeKey) {
 			newEntries = append(newEntries, entry)
+			continue
+		}
+		for _, piece := range trm.splitRange(entry.Range, rangeKey) {
+			newEntries = append(newEntries, Entry[K, V]{
+				Range: piece,
+				Value: entry.Value,
+			})
 		}
 	}
 	
@@ -272,11 +322,10 @@ func (trm *TreeRangeMap[K, V]) removeOverlapping(rangeKey Range[K]) {
 }
 
 // Helper method to split a range by removing the overlapping part
+// Returns the non-empty pieces of original below and above toRemove
 func (trm *TreeRangeMap[K, V]) splitRange(original Range[K], toRemove Range[K]) []Range[K] {
-	var result []Range[K]
-	
 	// If ranges don't overlap, return original
-	if !original.IsConnected(toRemove) {
+	if !original.Overlaps(toRemove) {
 		return []Range[K]{original}
 	}
 	
@@ -285,61 +334,57 @@ func (trm *TreeRangeMap[K, V]) splitRange(original Range[K], toRemove Range[K])
 		return []Range[K]{}
 	}
 	
+	var result []Range[K]
+	
 	// Get bounds of both ranges
 	origLower, origLowerType, hasOrigLower := original.LowerBound()
 	origUpper, origUpperType, hasOrigUpper := original.UpperBound()
 	removeLower, removeLowerType, hasRemoveLower := toRemove.LowerBound()
 	removeUpper, removeUpperType, hasRemoveUpper := toRemove.UpperBound()
 	
-	// Create left part (before the removal range)
-	if hasOrigLower && hasRemoveLower {
-		cmp := trm.comparator(origLower, removeLower)
-		if cmp < 0 || (cmp == 0 && origLowerType == Closed && removeLowerType == Open) {
-			// There's a left part
-			var leftUpper K
-			var leftUpperType BoundType
-			
-			if removeLowerType == Closed {
-				leftUpper = removeLower
-				leftUpperType = Open
-			} else {
-				leftUpper = removeLower
-				leftUpperType = Closed
-			}
-			
-			leftRange := NewRangeWithComparator(origLower, origLowerType, leftUpper, leftUpperType, trm.comparator)
-			if !leftRange.IsEmpty() {
-				result = append(result, leftRange)
-			}
+	// Create left part (before the removal range); an unbounded original keeps an unbounded left part
+	if hasRemoveLower {
+		left := &rangeImpl[K]{
+			hasLowerBound: hasOrigLower,
+			lowerBound:    origLower,
+			lowerType:     origLowerType,
+			hasUpperBound: true,
+			upperBound:    removeLower,
+			upperType:     flipBoundType(removeLowerType),
+			comparator:    trm.comparator,
+		}
+		if !left.IsEmpty() {
+			result = append(result, left)
 		}
 	}
 	
 	// Create right part (after the removal range)
-	if hasOrigUpper && hasRemoveUpper {
-		cmp := trm.comparator(removeUpper, origUpper)
-		if cmp < 0 || (cmp == 0 && removeUpperType == Open && origUpperType == Closed) {
-			// There's a right part
-			var rightLower K
-			var rightLowerType BoundType
-			
-			if removeUpperType == Closed {
-				rightLower = removeUpper
-				rightLowerType = Open
-			} else {
-				rightLower = removeUpper
-				rightLowerType = Closed
-			}
-			
-			rightRange := NewRangeWithComparator(rightLower, rightLowerType, origUpper, origUpperType, trm.comparator)
-			if !rightRange.IsEmpty() {
-				result = append(result, rightRange)
-			}
+	if hasRemoveUpper {
+		right := &rangeImpl[K]{
+			hasLowerBound: true,
+			lowerBound:    removeUpper,
+			lowerType:     flipBoundType(removeUpperType),
+			hasUpperBound: hasOrigUpper,
+			upperBound:    origUpper,
+			upperType:     origUpperType,
+			comparator:    trm.comparator,
+		}
+		if !right.IsEmpty() {
+			result = append(result, right)
 		}
 	}
 	
 	return result
 }
 
+// flipBoundType returns the bound type of the complementary side of a cut point
+func flipBoundType(t BoundType) BoundType {
+	if t == Closed {
+		return Open
+	}
+	return Closed
+}
+
 // Helper method to compare two ranges for sorting
 func (trm *TreeRangeMap[K, V]) compareRanges(a, b Range[K]) int {
 	// Compare by lower bound first
@@ -12,7 +12,13 @@ import (
 type TreeRangeSet[T comparable] struct {
 	ranges     []Range[T]
 	comparator Comparator[T]
+	domain     DiscreteDomain[T] // Optional; when set, ranges with no value between them are merged
 	mutex      sync.RWMutex
+
+	// version is bumped on every mutation; snapshot caches AsRanges for snapshotVersion
+	version         uint64
+	snapshot        []Range[T]
+	snapshotVersion uint64
 }
 
 // NewTreeRangeSet creates a new TreeRangeSet with default comparator
@@ -39,6 +45,17 @@ func NewTreeRangeSetWithComparatorStrategy[T comparable](strategy common.Compara
     }
 }
 
+// NewTreeRangeSetCoalescing creates a new TreeRangeSet over a discrete domain
+// Ranges that leave no domain value between them are merged on Add, so adding [1..5]
+// and [6..10] to an integer set yields [1..10]
+func NewTreeRangeSetCoalescing[T comparable](domain DiscreteDomain[T]) RangeSet[T] {
+	return &TreeRangeSet[T]{
+		ranges:     make([]Range[T], 0),
+		comparator: DefaultComparator[T],
+		domain:     domain,
+	}
+}
+
 // Size returns the number of ranges in this set
 func (ts *TreeRangeSet[T]) Size() int {
 	ts.mutex.RLock()
@@ -46,6 +63,13 @@ func (ts *TreeRangeSet[T]) Size() int {
 	return len(ts.ranges)
 }
 
+// RangeCount returns the number of disconnected ranges without copying them
+func (ts *TreeRangeSet[T]) RangeCount() int {
+	ts.mutex.RLock()
+	defer ts.mutex.RUnlock()
+	return len(ts.ranges)
+}
+
 // IsEmpty returns true if this range set is empty
 func (ts *TreeRangeSet[T]) IsEmpty() bool {
 	ts.mutex.RLock()
@@ -58,6 +82,7 @@ func (ts *TreeRangeSet[T]) Clear() {
 	ts.mutex.Lock()
 	defer ts.mutex.Unlock()
 	ts.ranges = ts.ranges[:0]
+	ts.version++
 }
 
 // String returns the string representation of this range set
@@ -96,6 +121,7 @@ func (ts *TreeRangeSet[T]) AddRange(lower T, lowerType BoundType, upper T, upper
 
 // addInternal adds a range without locking (internal use)
 func (ts *TreeRangeSet[T]) addInternal(rangeToAdd Range[T]) {
+	ts.version++
 	if len(ts.ranges) == 0 {
 		ts.ranges = append(ts.ranges, rangeToAdd)
 		return
@@ -106,7 +132,7 @@ func (ts *TreeRangeSet[T]) addInternal(rangeToAdd Range[T]) {
 	var newRanges []Range[T]
 	
 	for _, existing := range ts.ranges {
-		if existing.IsConnected(rangeToAdd) {
+		if existing.IsConnected(rangeToAdd) || ts.isDiscreteAdjacent(existing, rangeToAdd) {
 			toMerge = append(toMerge, existing)
 		} else {
 			newRanges = append(newRanges, existing)
@@ -124,6 +150,51 @@ func (ts *TreeRangeSet[T]) addInternal(rangeToAdd Range[T]) {
 	ts.ranges = ts.sortRanges(newRanges)
 }
 
+// isDiscreteAdjacent reports whether a and b leave no domain value between them
+// Always false when the set has no discrete domain
+func (ts *TreeRangeSet[T]) isDiscreteAdjacent(a, b Range[T]) bool {
+	if ts.domain == nil {
+		return false
+	}
+	return ts.followsDirectly(a, b) || ts.followsDirectly(b, a)
+}
+
+// followsDirectly reports whether the first value of b is the successor of the last value of a
+func (ts *TreeRangeSet[T]) followsDirectly(a, b Range[T]) bool {
+	upper, upperType, hasUpper := a.UpperBound()
+	lower, lowerType, hasLower := b.LowerBound()
+	if !hasUpper || !hasLower {
+		return false
+	}
+
+	last, ok := upper, true
+	if upperType == Open {
+		last, ok = ts.domain.Previous(upper)
+	}
+	if !ok {
+		return false
+	}
+	first, ok := lower, true
+	if lowerType == Open {
+		first, ok = ts.domain.Next(lower)
+	}
+	if !ok {
+		return false
+	}
+
+	successor, ok := ts.domain.Next(last)
+	return ok && ts.comparator(successor, first) == 0
+}
+
+// newEmpty creates an empty TreeRangeSet with the same comparator and domain
+func (ts *TreeRangeSet[T]) newEmpty() *TreeRangeSet[T] {
+	return &TreeRangeSet[T]{
+		ranges:     make([]Range[T], 0),
+		comparator: ts.comparator,
+		domain:     ts.domain,
+	}
+}
+
 // Remove removes a range from this range set
 func (ts *TreeRangeSet[T]) Remove(rangeToRemove Range[T]) {
 	if rangeToRemove == nil || rangeToRemove.IsEmpty() {
@@ -144,6 +215,7 @@ func (ts *TreeRangeSet[T]) RemoveRange(lower T, lowerType BoundType, upper T, up
 
 // removeInternal removes a range without locking (internal use)
 func (ts *TreeRangeSet[T]) removeInternal(rangeToRemove Range[T]) {
+	ts.version++
 	var newRanges []Range[T]
 	
 	for _, existing := range ts.ranges {
@@ -259,6 +331,29 @@ func (ts *TreeRangeSet[T]) ContainsRange(rangeToCheck Range[T]) bool {
 	return false
 }
 
+// Intersects returns true if any range in this set shares at least one value with r
+func (ts *TreeRangeSet[T]) Intersects(r Range[T]) bool {
+	if r == nil || r.IsEmpty() {
+		return false
+	}
+	
+	ts.mutex.RLock()
+	defer ts.mutex.RUnlock()
+	
+	return intersectsRanges(ts.ranges, r, ts.comparator)
+}
+
+// intersectsRanges reports whether r overlaps any of the sorted, disconnected ranges
+// Only the first range not entirely below r can overlap it, so a binary search suffices
+func intersectsRanges[T comparable](ranges []Range[T], r Range[T], cmp Comparator[T]) bool {
+	lower, lowerType, hasLower := r.LowerBound()
+	i := sort.Search(len(ranges), func(i int) bool {
+		upper, upperType, hasUpper := ranges[i].UpperBound()
+		return lowerBelowUpper(cmp, lower, lowerType, hasLower, upper, upperType, hasUpper)
+	})
+	return i < len(ranges) && ranges[i].Overlaps(r)
+}
+
 // Encloses returns true if this range set encloses the other range set
 func (ts *TreeRangeSet[T]) Encloses(other RangeSet[T]) bool {
 	if other == nil || other.IsEmpty() {
@@ -275,13 +370,33 @@ func (ts *TreeRangeSet[T]) Encloses(other RangeSet[T]) bool {
 }
 
 // AsRanges returns a view of the disconnected ranges that make up this range set
+// The slice is a snapshot cached until the next mutation, so repeated calls on an
+// unchanged set do not copy; callers must not modify it
 func (ts *TreeRangeSet[T]) AsRanges() []Range[T] {
+	ts.mutex.RLock()
+	if ts.snapshot != nil && ts.snapshotVersion == ts.version {
+		result := ts.snapshot
+		ts.mutex.RUnlock()
+		return result
+	}
+	ts.mutex.RUnlock()
+
+	ts.mutex.Lock()
+	defer ts.mutex.Unlock()
+	if ts.snapshot == nil || ts.snapshotVersion != ts.version {
+		ts.snapshot = make([]Range[T], len(ts.ranges))
+		copy(ts.snapshot, ts.ranges)
+		ts.snapshotVersion = ts.version
+	}
+	return ts.snapshot
+}
+
+// Measure returns the number of values of domain covered by this range set
+func (ts *TreeRangeSet[T]) Measure(domain DiscreteDomain[T]) int64 {
 	ts.mutex.RLock()
 	defer ts.mutex.RUnlock()
-	
-	result := make([]Range[T], len(ts.ranges))
-	copy(result, ts.ranges)
-	return result
+
+	return measureRanges(ts.ranges, domain)
 }
 
 // Complement returns the complement of this range set
@@ -289,7 +404,7 @@ func (ts *TreeRangeSet[T]) Complement() RangeSet[T] {
 	ts.mutex.RLock()
 	defer ts.mutex.RUnlock()
 	
-	complement := NewTreeRangeSetWithComparator(ts.comparator)
+	complement := ts.newEmpty()
 	
 	if len(ts.ranges) == 0 {
 		// If this set is empty, complement is all values
@@ -380,7 +495,7 @@ func (ts *TreeRangeSet[T]) Complement() RangeSet[T] {
 
 // Union returns the union of this range set with another
 func (ts *TreeRangeSet[T]) Union(other RangeSet[T]) RangeSet[T] {
-	result := NewTreeRangeSetWithComparator(ts.comparator)
+	result := ts.newEmpty()
 	
 	// Add all ranges from this set
 	for _, r := range ts.AsRanges() {
@@ -397,7 +512,7 @@ func (ts *TreeRangeSet[T]) Union(other RangeSet[T]) RangeSet[T] {
 
 // Intersection returns the intersection of this range set with another
 func (ts *TreeRangeSet[T]) Intersection(other RangeSet[T]) RangeSet[T] {
-	result := NewTreeRangeSetWithComparator(ts.comparator)
+	result := ts.newEmpty()
 	
 	thisRanges := ts.AsRanges()
 	otherRanges := other.AsRanges()
@@ -416,7 +531,7 @@ func (ts *TreeRangeSet[T]) Intersection(other RangeSet[T]) RangeSet[T] {
 
 // Difference returns the difference of this range set with another
 func (ts *TreeRangeSet[T]) Difference(other RangeSet[T]) RangeSet[T] {
-	result := NewTreeRangeSetWithComparator(ts.comparator)
+	result := ts.newEmpty()
 	
 	// Start with all ranges from this set
 	for _, r := range ts.AsRanges() {
@@ -2,178 +2,65 @@ package set
 
 import (
 	"fmt"
-	"math/rand"
 	"strings"
-	"sync"
-	"time"
 
 	"github.com/chenjianyu/collections/container/common"
-)
-
-const (
-	maxLevels = 32
-	p         = 0.25
+	maps "github.com/chenjianyu/collections/container/map"
 )
 
 // ConcurrentSkipListSet is a thread-safe set implementation based on skip list
-// Elements are stored in sorted order
+// Elements are stored in sorted order; reads take no locks and writers only lock
+// the nodes they relink, by delegating to a ConcurrentSkipListMap
 type ConcurrentSkipListSet[E comparable] struct {
-	head       *skipListNode[E]
+	m          *maps.ConcurrentSkipListMap[E, struct{}]
 	comparator func(a, b E) int
-	size       int
-	mutex      sync.RWMutex
-	rand       *rand.Rand
-}
-
-// skipListNode represents a node in the skip list
-type skipListNode[E comparable] struct {
-	value E
-	next  []*skipListNode[E]
 }
 
 // NewConcurrentSkipListSet creates a new ConcurrentSkipListSet using default comparator
 // Default comparator prefers Comparable.CompareTo, otherwise falls back to natural generic ordering
 func NewConcurrentSkipListSet[E comparable]() *ConcurrentSkipListSet[E] {
-    head := &skipListNode[E]{
-        next: make([]*skipListNode[E], maxLevels),
-    }
-
-    return &ConcurrentSkipListSet[E]{
-        head:       head,
-        comparator: common.CompareNatural[E],
-        size:       0,
-        rand:       rand.New(rand.NewSource(time.Now().UnixNano())),
-    }
+	return NewConcurrentSkipListSetWithComparator(common.CompareNatural[E])
 }
 
 // NewConcurrentSkipListSetWithComparator creates a ConcurrentSkipListSet with specified comparator
+// A nil comparator falls back to natural ordering
 func NewConcurrentSkipListSetWithComparator[E comparable](comparator func(a, b E) int) *ConcurrentSkipListSet[E] {
-	head := &skipListNode[E]{
-		next: make([]*skipListNode[E], maxLevels),
+	if comparator == nil {
+		comparator = common.CompareNatural[E]
 	}
-
 	return &ConcurrentSkipListSet[E]{
-		head:       head,
+		m:          maps.NewConcurrentSkipListMapWithComparator[E, struct{}](comparator),
 		comparator: comparator,
-		size:       0,
-		rand:       rand.New(rand.NewSource(time.Now().UnixNano())),
 	}
 }
 
 // NewConcurrentSkipListSetWithComparatorStrategy creates a ConcurrentSkipListSet with a ComparatorStrategy
 func NewConcurrentSkipListSetWithComparatorStrategy[E comparable](strategy common.ComparatorStrategy[E]) *ConcurrentSkipListSet[E] {
-    head := &skipListNode[E]{
-        next: make([]*skipListNode[E], maxLevels),
-    }
-
-    return &ConcurrentSkipListSet[E]{
-        head: head,
-        comparator: func(a, b E) int {
-            return strategy.Compare(a, b)
-        },
-        size: 0,
-        rand: rand.New(rand.NewSource(time.Now().UnixNano())),
-    }
-}
-
-// randomLevel generates a random level for new nodes
-func (s *ConcurrentSkipListSet[E]) randomLevel() int {
-	level := 1
-	for s.rand.Float64() < p && level < maxLevels {
-		level++
-	}
-	return level
-}
-
-// findPredecessors finds the predecessors of the target value at each level
-func (s *ConcurrentSkipListSet[E]) findPredecessors(target E) []*skipListNode[E] {
-	predecessors := make([]*skipListNode[E], maxLevels)
-	current := s.head
-
-	for level := maxLevels - 1; level >= 0; level-- {
-		for current.next[level] != nil && s.comparator(current.next[level].value, target) < 0 {
-			current = current.next[level]
-		}
-		predecessors[level] = current
-	}
-
-	return predecessors
+	return NewConcurrentSkipListSetWithComparator(func(a, b E) int {
+		return strategy.Compare(a, b)
+	})
 }
 
-// Add adds an element to the set
-// Returns false if the set already contains the element, otherwise returns true
+// Add adds an element to the set, returning true if it was not already present
 func (s *ConcurrentSkipListSet[E]) Add(element E) bool {
-	s.mutex.Lock()
-	defer s.mutex.Unlock()
-
-	predecessors := s.findPredecessors(element)
-
-	// Check if element already exists
-	if predecessors[0].next[0] != nil && s.comparator(predecessors[0].next[0].value, element) == 0 {
-		return false
-	}
-
-	// Create new node
-	level := s.randomLevel()
-	newNode := &skipListNode[E]{
-		value: element,
-		next:  make([]*skipListNode[E], level),
-	}
-
-	// Insert the new node
-	for i := 0; i < level; i++ {
-		newNode.next[i] = predecessors[i].next[i]
-		predecessors[i].next[i] = newNode
-	}
-
-	s.size++
-	return true
+	_, existed := s.m.Put(element, struct{}{})
+	return !existed
 }
 
-// Remove removes the specified element from the set
-// Returns true if the set contained the element, otherwise returns false
+// Remove removes an element from the set, returning true if it was present
 func (s *ConcurrentSkipListSet[E]) Remove(element E) bool {
-	s.mutex.Lock()
-	defer s.mutex.Unlock()
-
-	predecessors := s.findPredecessors(element)
-
-	// Check if element exists
-	nodeToRemove := predecessors[0].next[0]
-	if nodeToRemove == nil || s.comparator(nodeToRemove.value, element) != 0 {
-		return false
-	}
-
-	// Remove the node from all levels
-	for level := 0; level < len(nodeToRemove.next); level++ {
-		predecessors[level].next[level] = nodeToRemove.next[level]
-	}
-
-	s.size--
-	return true
+	_, removed := s.m.Remove(element)
+	return removed
 }
 
 // Contains checks if the set contains the specified element
 func (s *ConcurrentSkipListSet[E]) Contains(element E) bool {
-	s.mutex.RLock()
-	defer s.mutex.RUnlock()
-
-	current := s.head
-	for level := maxLevels - 1; level >= 0; level-- {
-		for current.next[level] != nil && s.comparator(current.next[level].value, element) < 0 {
-			current = current.next[level]
-		}
-	}
-
-	next := current.next[0]
-	return next != nil && s.comparator(next.value, element) == 0
+	return s.m.ContainsKey(element)
 }
 
 // Size returns the number of elements in the set
 func (s *ConcurrentSkipListSet[E]) Size() int {
-	s.mutex.RLock()
-	defer s.mutex.RUnlock()
-	return s.size
+	return s.m.Size()
 }
 
 // IsEmpty checks if the set is empty
@@ -182,43 +69,58 @@ func (s *ConcurrentSkipListSet[E]) IsEmpty() bool {
 }
 
 // Clear clears all elements from the set
+// Elements added concurrently with Clear may or may not be removed
 func (s *ConcurrentSkipListSet[E]) Clear() {
-	s.mutex.Lock()
-	defer s.mutex.Unlock()
-
-	// Reset head node
-	for i := 0; i < maxLevels; i++ {
-		s.head.next[i] = nil
-	}
-	s.size = 0
+	s.m.Clear()
 }
 
-// ToSlice returns a slice containing all elements in the set
+// ToSlice returns a slice containing all elements in the set in ascending order
 func (s *ConcurrentSkipListSet[E]) ToSlice() []E {
-	s.mutex.RLock()
-	defer s.mutex.RUnlock()
+	return s.m.Keys()
+}
+
+// ForEach executes the given operation for each element in ascending order
+// The traversal is weakly consistent and never blocks writers
+func (s *ConcurrentSkipListSet[E]) ForEach(fn func(E)) {
+	s.m.ForEach(func(element E, _ struct{}) {
+		fn(element)
+	})
+}
 
-	result := make([]E, 0, s.size)
-	current := s.head.next[0]
+// First returns the lowest element, or false if the set is empty
+func (s *ConcurrentSkipListSet[E]) First() (E, bool) {
+	return entryKey(s.m.FirstEntry())
+}
 
-	for current != nil {
-		result = append(result, current.value)
-		current = current.next[0]
-	}
+// Last returns the highest element, or false if the set is empty
+func (s *ConcurrentSkipListSet[E]) Last() (E, bool) {
+	return entryKey(s.m.LastEntry())
+}
 
-	return result
+// Ceiling returns the least element greater than or equal to element
+func (s *ConcurrentSkipListSet[E]) Ceiling(element E) (E, bool) {
+	return entryKey(s.m.CeilingEntry(element))
 }
 
-// ForEach executes the given operation for each element in the set
-func (s *ConcurrentSkipListSet[E]) ForEach(fn func(E)) {
-	s.mutex.RLock()
-	defer s.mutex.RUnlock()
+// Floor returns the greatest element less than or equal to element
+func (s *ConcurrentSkipListSet[E]) Floor(element E) (E, bool) {
+	return entryKey(s.m.FloorEntry(element))
+}
+
+// ForEachInRange calls fn for each element with from <= element < to in ascending order
+// Iteration stops early when fn returns false
+func (s *ConcurrentSkipListSet[E]) ForEachInRange(from, to E, fn func(E) bool) {
+	s.m.ForEachInRange(from, to, func(element E, _ struct{}) bool {
+		return fn(element)
+	})
+}
 
-	current := s.head.next[0]
-	for current != nil {
-		fn(current.value)
-		current = current.next[0]
+// entryKey extracts the key of a navigation result
+func entryKey[E comparable](entry common.Entry[E, struct{}], ok bool) (E, bool) {
+	if !ok {
+		return common.ZeroValue[E](), false
 	}
+	return entry.Key, true
 }
 
 // Union returns a new set containing all elements from this set and the other set
@@ -277,9 +179,6 @@ func (s *ConcurrentSkipListSet[E]) IsSupersetOf(other Set[E]) bool {
 
 // Iterator returns an iterator for the set
 func (s *ConcurrentSkipListSet[E]) Iterator() common.Iterator[E] {
-	s.mutex.RLock()
-	defer s.mutex.RUnlock()
-
 	elements := s.ToSlice()
 	return &concurrentSkipListSetIterator[E]{
 		elements: elements,
@@ -1,6 +1,7 @@
 package set
 
 import (
+	"fmt"
 	"sync"
 	"testing"
 )
@@ -298,3 +299,111 @@ func TestConcurrentSkipListSet_Concurrency(t *testing.T) {
 		t.Errorf("Expected size %d after concurrent operations, got %d", expectedSize, set.Size())
 	}
 }
+
+func TestConcurrentSkipListSet_Navigation(t *testing.T) {
+	set := NewConcurrentSkipListSet[int]()
+	if _, ok := set.First(); ok {
+		t.Error("First on an empty set should return false")
+	}
+	if _, ok := set.Floor(10); ok {
+		t.Error("Floor on an empty set should return false")
+	}
+
+	for _, v := range []int{30, 10, 50, 20, 40} {
+		set.Add(v)
+	}
+
+	if v, ok := set.First(); !ok || v != 10 {
+		t.Errorf("Expected First 10, got %d, %v", v, ok)
+	}
+	if v, ok := set.Last(); !ok || v != 50 {
+		t.Errorf("Expected Last 50, got %d, %v", v, ok)
+	}
+	if v, ok := set.Ceiling(25); !ok || v != 30 {
+		t.Errorf("Expected Ceiling(25) 30, got %d, %v", v, ok)
+	}
+	if v, ok := set.Ceiling(30); !ok || v != 30 {
+		t.Errorf("Expected Ceiling(30) 30, got %d, %v", v, ok)
+	}
+	if _, ok := set.Ceiling(51); ok {
+		t.Error("Ceiling above the last element should return false")
+	}
+	if v, ok := set.Floor(25); !ok || v != 20 {
+		t.Errorf("Expected Floor(25) 20, got %d, %v", v, ok)
+	}
+	if _, ok := set.Floor(9); ok {
+		t.Error("Floor below the first element should return false")
+	}
+}
+
+func TestConcurrentSkipListSet_ForEachInRange(t *testing.T) {
+	set := NewConcurrentSkipListSet[string]()
+	for _, id := range []string{"s-05", "s-01", "s-09", "s-03", "s-07"} {
+		set.Add(id)
+	}
+
+	var ids []string
+	set.ForEachInRange("s-02", "s-08", func(id string) bool {
+		ids = append(ids, id)
+		return true
+	})
+	if fmt.Sprint(ids) != "[s-03 s-05 s-07]" {
+		t.Errorf("Expected [s-03 s-05 s-07], got %v", ids)
+	}
+
+	ids = nil
+	set.ForEachInRange("s-00", "s-99", func(id string) bool {
+		ids = append(ids, id)
+		return len(ids) < 2
+	})
+	if len(ids) != 2 {
+		t.Errorf("Returning false should stop the scan, got %v", ids)
+	}
+}
+
+func TestConcurrentSkipListSet_ConcurrentAddRemoveScan(t *testing.T) {
+	set := NewConcurrentSkipListSet[int]()
+	const numGoroutines = 8
+	const numOperations = 500
+
+	var wg sync.WaitGroup
+	for i := 0; i < numGoroutines; i++ {
+		wg.Add(1)
+		go func(start int) {
+			defer wg.Done()
+			for j := 0; j < numOperations; j++ {
+				v := start*numOperations + j
+				set.Add(v)
+				if j%2 == 1 {
+					set.Remove(v)
+				}
+			}
+		}(i)
+	}
+	for i := 0; i < numGoroutines; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 20; j++ {
+				prev := -1
+				set.ForEachInRange(0, numGoroutines*numOperations, func(v int) bool {
+					if v <= prev {
+						t.Errorf("Range scan out of order: %d after %d", v, prev)
+					}
+					prev = v
+					return true
+				})
+			}
+		}()
+	}
+	wg.Wait()
+
+	if set.Size() != numGoroutines*numOperations/2 {
+		t.Errorf("Expected size %d, got %d", numGoroutines*numOperations/2, set.Size())
+	}
+	set.ForEach(func(v int) {
+		if v%2 == 1 {
+			t.Errorf("Removed element %d still present", v)
+		}
+	})
+}
@@ -3,12 +3,15 @@ package set
 
 import (
 	"fmt"
+	"sort"
 	"strings"
 
 	"github.com/chenjianyu/collections/container/common"
 )
 
 // HashSet is a Set implementation based on hash table
+// Iteration order of ToSlice, ForEach, Iterator and String is unspecified;
+// only SortedToSlice and ForEachSorted yield a deterministic order
 type HashSet[E comparable] struct {
 	buckets      [][]E
 	size         int
@@ -42,6 +45,21 @@ func FromSliceWithHashStrategy[E comparable](slice []E, hashStrategy common.Hash
 	return set
 }
 
+// FromIterator creates a new HashSet holding the distinct remaining elements of it
+// A nil iterator yields an empty set
+func FromIterator[E comparable](it common.Iterator[E]) *HashSet[E] {
+	set := New[E]()
+	if it == nil {
+		return set
+	}
+	for it.HasNext() {
+		if element, ok := it.Next(); ok {
+			set.Add(element)
+		}
+	}
+	return set
+}
+
 // Add adds an element to the set
 func (s *HashSet[E]) Add(element E) bool {
 	index := s.hash(element) % len(s.buckets)
@@ -75,6 +93,18 @@ func (s *HashSet[E]) Remove(element E) bool {
 	return false
 }
 
+// RemoveAllReturning removes the given elements and returns those that were present,
+// in the order they appear in elems; duplicates in elems are reported once
+func (s *HashSet[E]) RemoveAllReturning(elems []E) []E {
+	removed := make([]E, 0)
+	for _, element := range elems {
+		if s.Remove(element) {
+			removed = append(removed, element)
+		}
+	}
+	return removed
+}
+
 // Contains checks if the set contains the specified element
 func (s *HashSet[E]) Contains(element E) bool {
 	index := s.hash(element) % len(s.buckets)
@@ -94,6 +124,11 @@ func (s *HashSet[E]) Size() int {
 	return s.size
 }
 
+// Stats returns the set's size and bucket count; the load factor is elements per bucket
+func (s *HashSet[E]) Stats() common.ContainerStats {
+	return common.NewContainerStats(s.size, s.size, len(s.buckets))
+}
+
 // IsEmpty checks if the set is empty
 func (s *HashSet[E]) IsEmpty() bool {
 	return s.size == 0
@@ -105,7 +140,41 @@ func (s *HashSet[E]) Clear() {
 	s.size = 0
 }
 
-// ToSlice returns a slice containing all elements in the set
+// EnsureCapacity grows the bucket table so that capacity elements fit at a load factor of
+// at most 0.75, rehashing the current elements once
+// Bulk loaders call it before adding many elements; the table never shrinks
+func (s *HashSet[E]) EnsureCapacity(capacity int) {
+	buckets := 16
+	for buckets*3/4 < capacity {
+		buckets <<= 1
+	}
+	if buckets <= len(s.buckets) {
+		return
+	}
+	old := s.buckets
+	s.buckets = make([][]E, buckets)
+	for _, bucket := range old {
+		for _, element := range bucket {
+			index := s.hash(element) % buckets
+			s.buckets[index] = append(s.buckets[index], element)
+		}
+	}
+}
+
+// reset empties the set in place, keeping the bucket storage for reuse
+// Every slot up to each bucket's capacity is zeroed, including those left behind by Remove
+func (s *HashSet[E]) reset() {
+	for i, bucket := range s.buckets {
+		bucket = bucket[:cap(bucket)]
+		for j := range bucket {
+			bucket[j] = common.ZeroValue[E]()
+		}
+		s.buckets[i] = bucket[:0]
+	}
+	s.size = 0
+}
+
+// ToSlice returns a slice containing all elements in the set in unspecified order
 func (s *HashSet[E]) ToSlice() []E {
 	result := make([]E, 0, s.size)
 	for _, bucket := range s.buckets {
@@ -114,7 +183,7 @@ func (s *HashSet[E]) ToSlice() []E {
 	return result
 }
 
-// ForEach executes the given operation on each element in the set
+// ForEach executes the given operation on each element in the set in unspecified order
 func (s *HashSet[E]) ForEach(fn func(E)) {
 	for _, bucket := range s.buckets {
 		for _, element := range bucket {
@@ -123,6 +192,31 @@ func (s *HashSet[E]) ForEach(fn func(E)) {
 	}
 }
 
+// SortedToSlice returns a slice containing all elements in natural order (see common.CompareNatural)
+func (s *HashSet[E]) SortedToSlice() []E {
+	return s.sortedSlice(common.CompareNatural[E])
+}
+
+// ForEachSorted executes the given operation on each element in the order defined by cmp
+// A nil cmp falls back to natural order
+func (s *HashSet[E]) ForEachSorted(cmp func(a, b E) int, fn func(E)) {
+	if cmp == nil {
+		cmp = common.CompareNatural[E]
+	}
+	for _, element := range s.sortedSlice(cmp) {
+		fn(element)
+	}
+}
+
+// sortedSlice returns a snapshot of the elements sorted with cmp
+func (s *HashSet[E]) sortedSlice(cmp func(a, b E) int) []E {
+	result := s.ToSlice()
+	sort.SliceStable(result, func(i, j int) bool {
+		return cmp(result[i], result[j]) < 0
+	})
+	return result
+}
+
 // Union returns the union of this set and another set
 func (s *HashSet[E]) Union(other Set[E]) Set[E] {
 	result := NewWithHashStrategy(s.hashStrategy)
@@ -218,6 +312,21 @@ func (s *HashSet[E]) String() string {
 	return builder.String()
 }
 
+// StringN returns a representation like String that shows at most n elements
+// The remaining elements are summarised as "… (+k more)"
+func (s *HashSet[E]) StringN(n int) string {
+	b := common.NewLimitedStringBuilder("[", n)
+	for _, bucket := range s.buckets {
+		for _, element := range bucket {
+			if b.Full() {
+				return b.Finish("]", s.size)
+			}
+			b.Addf("%v", element)
+		}
+	}
+	return b.Finish("]", s.size)
+}
+
 // Iterator returns an iterator for traversing elements in the set
 func (s *HashSet[E]) Iterator() common.Iterator[E] {
 	return &hashSetIterator[E]{
@@ -276,3 +385,25 @@ func (s *HashSet[E]) hash(element E) int {
 	}
 	return hashValue
 }
+
+// MarshalBinary encodes the set elements using common.EncodeElements
+func (s *HashSet[E]) MarshalBinary() ([]byte, error) {
+	return common.EncodeElements(s.ToSlice())
+}
+
+// UnmarshalBinary replaces the set contents with the elements decoded from data
+// The set keeps its hash strategy; a zero-value HashSet uses the default one
+func (s *HashSet[E]) UnmarshalBinary(data []byte) error {
+	elements, err := common.DecodeElements[E](data)
+	if err != nil {
+		return err
+	}
+	if s.hashStrategy == nil {
+		s.hashStrategy = common.NewComparableHashStrategy[E]()
+	}
+	s.Clear()
+	for _, element := range elements {
+		s.Add(element)
+	}
+	return nil
+}
@@ -280,4 +280,25 @@ func BenchmarkHashSetFunctionalStrategy(b *testing.B) {
 		set.Contains("test")
 		set.Remove("test")
 	}
-}
\ No newline at end of file
+}
+func TestHashSetWithEntryHashStrategy(t *testing.T) {
+	type weight struct{ value int }
+	// The strategy compares what the pointers refer to, where == would compare addresses
+	set := NewWithHashStrategy(common.NewEntryHashStrategy[string, *weight]())
+
+	if !set.Add(common.NewEntry("a->b", &weight{1})) {
+		t.Error("Should add the first entry")
+	}
+	if set.Add(common.NewEntry("a->b", &weight{1})) {
+		t.Error("Should not add an equal entry twice")
+	}
+	if !set.Add(common.NewEntry("a->b", &weight{2})) {
+		t.Error("Should add an entry with a different value")
+	}
+	if !set.Contains(common.NewEntry("a->b", &weight{2})) {
+		t.Error("Should find an equal entry")
+	}
+	if set.Size() != 2 {
+		t.Errorf("Expected size 2, got %d", set.Size())
+	}
+}
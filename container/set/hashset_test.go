@@ -1,8 +1,11 @@
 package set
 
 import (
+	"errors"
 	"strings"
 	"testing"
+
+	"github.com/chenjianyu/collections/container/common"
 )
 
 func TestHashSet_New(t *testing.T) {
@@ -228,6 +231,50 @@ func TestHashSet_ForEach(t *testing.T) {
 	}
 }
 
+func TestHashSet_SortedToSlice(t *testing.T) {
+	set := FromSlice([]int{3, 1, 4, 1, 5, 9, 2, 6})
+
+	expected := []int{1, 2, 3, 4, 5, 6, 9}
+	for round := 0; round < 3; round++ {
+		result := set.SortedToSlice()
+		if len(result) != len(expected) {
+			t.Fatalf("Expected %d elements, got %d", len(expected), len(result))
+		}
+		for i, e := range expected {
+			if result[i] != e {
+				t.Errorf("Expected %v, got %v", expected, result)
+				break
+			}
+		}
+	}
+
+	if got := New[string]().SortedToSlice(); len(got) != 0 {
+		t.Errorf("Expected empty slice, got %v", got)
+	}
+}
+
+func TestHashSet_ForEachSorted(t *testing.T) {
+	set := FromSlice([]string{"pear", "apple", "fig", "banana"})
+
+	var desc []string
+	set.ForEachSorted(func(a, b string) int {
+		return strings.Compare(b, a)
+	}, func(e string) {
+		desc = append(desc, e)
+	})
+	if strings.Join(desc, ",") != "pear,fig,banana,apple" {
+		t.Errorf("Expected descending order, got %v", desc)
+	}
+
+	var natural []string
+	set.ForEachSorted(nil, func(e string) {
+		natural = append(natural, e)
+	})
+	if strings.Join(natural, ",") != "apple,banana,fig,pear" {
+		t.Errorf("Expected natural order for nil comparator, got %v", natural)
+	}
+}
+
 func TestHashSet_SetOperations(t *testing.T) {
 	set1 := New[int]()
 	set2 := New[int]()
@@ -381,3 +428,109 @@ func TestHashSet_Iterator(t *testing.T) {
 		t.Error("Remove before Next should fail")
 	}
 }
+
+func TestHashSet_BinaryRoundTrip(t *testing.T) {
+	set := FromSlice([]string{"x", "y", "z"})
+
+	var _ common.Serializable = set
+	data, err := set.MarshalBinary()
+	if err != nil {
+		t.Fatalf("MarshalBinary failed: %v", err)
+	}
+
+	var restored HashSet[string]
+	if err := restored.UnmarshalBinary(data); err != nil {
+		t.Fatalf("UnmarshalBinary failed: %v", err)
+	}
+	if restored.Size() != 3 || !restored.IsSupersetOf(set) {
+		t.Errorf("Expected %v, got %v", set.SortedToSlice(), restored.SortedToSlice())
+	}
+
+	if err := restored.UnmarshalBinary([]byte{0}); !errors.Is(err, common.ErrMalformedData) {
+		t.Errorf("Expected ErrMalformedData, got %v", err)
+	}
+}
+
+func TestHashSet_StringN(t *testing.T) {
+	set := FromSlice([]int{1, 2, 3, 4})
+	got := set.StringN(2)
+	if !strings.HasSuffix(got, ", … (+2 more)]") || strings.Count(got, ",") != 2 {
+		t.Errorf("Expected two elements and a summary, got %q", got)
+	}
+	if set.StringN(4) != set.String() {
+		t.Errorf("StringN at the size should match String, got %q", set.StringN(4))
+	}
+}
+
+func TestHashSet_FromIterator(t *testing.T) {
+	ts := NewTreeSet[int]()
+	for _, v := range []int{3, 1, 2} {
+		ts.Add(v)
+	}
+
+	set := FromIterator(ts.Iterator())
+	if set.Size() != 3 || !set.IsSupersetOf(ts) {
+		t.Errorf("Expected the TreeSet's elements, got %v", set.SortedToSlice())
+	}
+	if FromIterator[int](nil).Size() != 0 {
+		t.Error("A nil iterator should yield an empty set")
+	}
+}
+
+func TestHashSet_Stats(t *testing.T) {
+	set := New[int]()
+	for i := 0; i < 10; i++ {
+		set.Add(i)
+	}
+	var reporter common.StatsReporter = set
+	stats := reporter.Stats()
+	if stats.Len != 10 || stats.Distinct != 10 || stats.Capacity != len(set.buckets) {
+		t.Errorf("unexpected stats %+v", stats)
+	}
+	if want := float64(10) / float64(len(set.buckets)); stats.LoadFactor != want {
+		t.Errorf("load factor should be %v, got %v", want, stats.LoadFactor)
+	}
+}
+
+func TestHashSet_EnsureCapacity(t *testing.T) {
+	set := New[int]()
+	for i := 0; i < 20; i++ {
+		set.Add(i)
+	}
+	set.EnsureCapacity(1000)
+	if len(set.buckets) < 1000*4/3 {
+		t.Errorf("EnsureCapacity(1000) should allocate at least %d buckets, got %d", 1000*4/3, len(set.buckets))
+	}
+	if set.Size() != 20 {
+		t.Errorf("EnsureCapacity should keep the size, got %d", set.Size())
+	}
+	for i := 0; i < 20; i++ {
+		if !set.Contains(i) {
+			t.Errorf("element %d should survive rehashing", i)
+		}
+	}
+
+	buckets := len(set.buckets)
+	set.EnsureCapacity(10)
+	if len(set.buckets) != buckets {
+		t.Error("EnsureCapacity should never shrink the table")
+	}
+}
+
+func TestHashSet_RemoveAllReturning(t *testing.T) {
+	s := New[int]()
+	for i := 1; i <= 5; i++ {
+		s.Add(i)
+	}
+
+	removed := s.RemoveAllReturning([]int{4, 9, 2, 4})
+	if len(removed) != 2 || removed[0] != 4 || removed[1] != 2 {
+		t.Errorf("Expected [4 2], got %v", removed)
+	}
+	if s.Size() != 3 || s.Contains(2) || s.Contains(4) {
+		t.Errorf("Expected {1 3 5}, got %v", s)
+	}
+	if removed := s.RemoveAllReturning([]int{7}); len(removed) != 0 {
+		t.Errorf("Expected nothing removed, got %v", removed)
+	}
+}
@@ -286,6 +286,16 @@ func (s *LinkedHashSet[E]) String() string {
 	return builder.String()
 }
 
+// StringN returns a representation like String that shows at most n elements in insertion order
+// The remaining elements are summarised as "… (+k more)"
+func (s *LinkedHashSet[E]) StringN(n int) string {
+	b := common.NewLimitedStringBuilder("[", n)
+	for current := s.head; current != nil && !b.Full(); current = current.next {
+		b.Addf("%v", current.data)
+	}
+	return b.Finish("]", s.size)
+}
+
 // Iterator returns an iterator for traversing elements in insertion order
 func (s *LinkedHashSet[E]) Iterator() common.Iterator[E] {
 	return &linkedHashSetIterator[E]{
@@ -344,3 +354,25 @@ func (it *linkedHashSetIterator[E]) Remove() bool {
 	it.lastRet = nil
 	return removed
 }
+
+// MarshalBinary encodes the set elements in insertion order using common.EncodeElements
+func (s *LinkedHashSet[E]) MarshalBinary() ([]byte, error) {
+	return common.EncodeElements(s.ToSlice())
+}
+
+// UnmarshalBinary replaces the set contents with the elements decoded from data, preserving their order
+// The set keeps its hash strategy; a zero-value LinkedHashSet uses the default one
+func (s *LinkedHashSet[E]) UnmarshalBinary(data []byte) error {
+	elements, err := common.DecodeElements[E](data)
+	if err != nil {
+		return err
+	}
+	if s.hashStrategy == nil {
+		s.hashStrategy = common.NewComparableHashStrategy[E]()
+	}
+	s.Clear()
+	for _, element := range elements {
+		s.Add(element)
+	}
+	return nil
+}
@@ -2,6 +2,8 @@ package set
 
 import (
 	"testing"
+
+	"github.com/chenjianyu/collections/container/common"
 )
 
 func TestLinkedHashSet_New(t *testing.T) {
@@ -440,4 +442,34 @@ func TestLinkedHashSet_LargeDataset(t *testing.T) {
 		}
 		expectedIndex++
 	}
-}
\ No newline at end of file
+}
+func TestLinkedHashSet_BinaryRoundTrip(t *testing.T) {
+	set := NewLinkedHashSet[int]()
+	for _, v := range []int{5, 1, 4} {
+		set.Add(v)
+	}
+
+	var _ common.Serializable = set
+	data, err := set.MarshalBinary()
+	if err != nil {
+		t.Fatalf("MarshalBinary failed: %v", err)
+	}
+
+	var restored LinkedHashSet[int]
+	if err := restored.UnmarshalBinary(data); err != nil {
+		t.Fatalf("UnmarshalBinary failed: %v", err)
+	}
+	if restored.String() != set.String() {
+		t.Errorf("Insertion order should survive a round trip: expected %v, got %v", set, &restored)
+	}
+}
+
+func TestLinkedHashSet_StringN(t *testing.T) {
+	set := NewLinkedHashSet[int]()
+	for _, v := range []int{3, 1, 2} {
+		set.Add(v)
+	}
+	if got := set.StringN(2); got != "[3, 1, … (+1 more)]" {
+		t.Errorf("Expected truncated listing in insertion order, got %q", got)
+	}
+}
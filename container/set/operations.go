@@ -0,0 +1,99 @@
+package set
+
+// UnionAllSets returns a new set containing every element of the given sets
+func UnionAllSets[E comparable](sets ...Set[E]) Set[E] {
+	result := New[E]()
+	for _, s := range sets {
+		if s == nil {
+			continue
+		}
+		s.ForEach(func(element E) {
+			result.Add(element)
+		})
+	}
+	return result
+}
+
+// IntersectAllSets returns a new set containing the elements present in all the given sets
+// Returns an empty set if no sets are given or any of them is empty
+func IntersectAllSets[E comparable](sets ...Set[E]) Set[E] {
+	result := New[E]()
+	if len(sets) == 0 {
+		return result
+	}
+
+	// Drive the intersection from the smallest set
+	smallest := -1
+	for i, s := range sets {
+		if s == nil || s.IsEmpty() {
+			return result
+		}
+		if smallest < 0 || s.Size() < sets[smallest].Size() {
+			smallest = i
+		}
+	}
+
+	sets[smallest].ForEach(func(element E) {
+		for i, s := range sets {
+			if i != smallest && !s.Contains(element) {
+				return
+			}
+		}
+		result.Add(element)
+	})
+	return result
+}
+
+// Cluster groups the elements of s into clusters joined by related, taking the transitive closure
+// Two elements share a cluster if a chain of related pairs connects them; related should be symmetric
+// Each pair is tested at most once (O(n²) predicate calls), so this suits small sets
+// Clusters and their elements follow the iteration order of s
+func Cluster[E comparable](s Set[E], related func(a, b E) bool) [][]E {
+	if s == nil || s.IsEmpty() {
+		return [][]E{}
+	}
+	elements := s.ToSlice()
+
+	// Union-find over element indexes, with path halving and union by size
+	parent := make([]int, len(elements))
+	size := make([]int, len(elements))
+	for i := range parent {
+		parent[i] = i
+		size[i] = 1
+	}
+	find := func(i int) int {
+		for parent[i] != i {
+			parent[i] = parent[parent[i]]
+			i = parent[i]
+		}
+		return i
+	}
+
+	for i := range elements {
+		for j := i + 1; j < len(elements); j++ {
+			ri, rj := find(i), find(j)
+			if ri == rj || !related(elements[i], elements[j]) {
+				continue
+			}
+			if size[ri] < size[rj] {
+				ri, rj = rj, ri
+			}
+			parent[rj] = ri
+			size[ri] += size[rj]
+		}
+	}
+
+	clusters := make([][]E, 0)
+	index := make(map[int]int)
+	for i, element := range elements {
+		root := find(i)
+		c, ok := index[root]
+		if !ok {
+			c = len(clusters)
+			index[root] = c
+			clusters = append(clusters, make([]E, 0, size[root]))
+		}
+		clusters[c] = append(clusters[c], element)
+	}
+	return clusters
+}
@@ -0,0 +1,81 @@
+package set
+
+import (
+	"testing"
+)
+
+func TestUnionAllSets(t *testing.T) {
+	a := FromSlice([]int{1, 2, 3})
+	b := NewTreeSet[int]()
+	b.Add(3)
+	b.Add(4)
+	c := LinkedHashSetFromSlice([]int{5, 1})
+
+	union := UnionAllSets[int](a, b, c, nil)
+	if union.Size() != 5 {
+		t.Errorf("Union size should be 5, got %d", union.Size())
+	}
+	for i := 1; i <= 5; i++ {
+		if !union.Contains(i) {
+			t.Errorf("Union should contain %d", i)
+		}
+	}
+
+	if !UnionAllSets[int]().IsEmpty() {
+		t.Error("Union of no sets should be empty")
+	}
+}
+
+func TestIntersectAllSets(t *testing.T) {
+	a := FromSlice([]int{1, 2, 3, 4, 5})
+	b := FromSlice([]int{2, 3, 4, 6})
+	c := FromSlice([]int{4, 3, 7})
+
+	intersection := IntersectAllSets[int](a, b, c)
+	if intersection.Size() != 2 || !intersection.Contains(3) || !intersection.Contains(4) {
+		t.Errorf("Intersection should be {3, 4}, got %v", intersection)
+	}
+
+	if !IntersectAllSets[int](a, New[int](), b).IsEmpty() {
+		t.Error("Intersection with an empty set should be empty")
+	}
+	if !IntersectAllSets[int]().IsEmpty() {
+		t.Error("Intersection of no sets should be empty")
+	}
+	if single := IntersectAllSets[int](a); single.Size() != a.Size() {
+		t.Errorf("Intersection of a single set should equal it, got %v", single)
+	}
+}
+
+func TestCluster(t *testing.T) {
+	s := LinkedHashSetFromSlice([]int{1, 10, 2, 11, 3, 20, 30, 31})
+	closeBy := func(a, b int) bool {
+		d := a - b
+		return d == 1 || d == -1
+	}
+
+	clusters := Cluster[int](s, closeBy)
+	expected := [][]int{{1, 2, 3}, {10, 11}, {20}, {30, 31}}
+	if len(clusters) != len(expected) {
+		t.Fatalf("Expected %d clusters, got %v", len(expected), clusters)
+	}
+	for i, cluster := range expected {
+		if len(clusters[i]) != len(cluster) {
+			t.Errorf("Cluster %d should be %v, got %v", i, cluster, clusters[i])
+			continue
+		}
+		for j, element := range cluster {
+			if clusters[i][j] != element {
+				t.Errorf("Cluster %d should be %v, got %v", i, cluster, clusters[i])
+				break
+			}
+		}
+	}
+
+	if all := Cluster[int](s, func(a, b int) bool { return true }); len(all) != 1 || len(all[0]) != s.Size() {
+		t.Errorf("Relating everything should give one cluster, got %v", all)
+	}
+	if none := Cluster[int](New[int](), closeBy); len(none) != 0 {
+		t.Errorf("Empty set should give no clusters, got %v", none)
+	}
+}
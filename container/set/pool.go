@@ -0,0 +1,48 @@
+package set
+
+import (
+	"sync"
+
+	"github.com/chenjianyu/collections/container/common"
+)
+
+// Pool recycles HashSet instances through a sync.Pool
+// Sets returned by Get are always empty, use the pool's hash strategy and keep their bucket storage from earlier use
+type Pool[E comparable] struct {
+	pool         sync.Pool
+	hashStrategy common.HashStrategy[E]
+}
+
+// NewPool creates a Pool of HashSets using the default hash strategy
+func NewPool[E comparable]() *Pool[E] {
+	return NewPoolWithHashStrategy(common.NewComparableHashStrategy[E]())
+}
+
+// NewPoolWithHashStrategy creates a Pool of HashSets using hashStrategy
+// A nil strategy falls back to the default hash strategy
+func NewPoolWithHashStrategy[E comparable](hashStrategy common.HashStrategy[E]) *Pool[E] {
+	if hashStrategy == nil {
+		hashStrategy = common.NewComparableHashStrategy[E]()
+	}
+	return &Pool[E]{hashStrategy: hashStrategy}
+}
+
+// Get returns an empty HashSet, reusing a pooled one when available
+func (p *Pool[E]) Get() *HashSet[E] {
+	if v := p.pool.Get(); v != nil {
+		return v.(*HashSet[E])
+	}
+	return NewWithHashStrategy(p.hashStrategy)
+}
+
+// Put empties s and returns it to the pool; nil sets are ignored
+// s adopts the pool's hash strategy whatever strategy it was built with, so Get always
+// returns sets that hash like the pool; the caller must not use s after Put
+func (p *Pool[E]) Put(s *HashSet[E]) {
+	if s == nil {
+		return
+	}
+	s.reset()
+	s.hashStrategy = p.hashStrategy
+	p.pool.Put(s)
+}
@@ -0,0 +1,99 @@
+package set
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/chenjianyu/collections/container/common"
+)
+
+func TestPoolGetReturnsEmptySet(t *testing.T) {
+	pool := NewPool[int]()
+
+	s := pool.Get()
+	if !s.IsEmpty() {
+		t.Errorf("Expected empty set from Get, got size %d", s.Size())
+	}
+	for i := 0; i < 100; i++ {
+		s.Add(i)
+	}
+	pool.Put(s)
+
+	reused := pool.Get()
+	if !reused.IsEmpty() {
+		t.Errorf("Expected recycled set to be empty, got %v", reused)
+	}
+	if reused.Contains(5) {
+		t.Error("Recycled set should not contain old elements")
+	}
+	if !reused.Add(5) || reused.Add(5) || reused.Size() != 1 {
+		t.Errorf("Expected recycled set to behave like a fresh set, got %v", reused)
+	}
+}
+
+func TestPoolRestoresHashStrategy(t *testing.T) {
+	caseInsensitive := common.NewFunctionalHashStrategy(
+		func(s string) uint64 { return common.Hash(strings.ToLower(s)) },
+		func(a, b string) bool { return strings.EqualFold(a, b) },
+	)
+	pool := NewPoolWithHashStrategy(caseInsensitive)
+
+	// A set built with another strategy adopts the pool's strategy once pooled
+	pool.Put(New[string]())
+	s := pool.Get()
+	s.Add("Go")
+	if s.Add("GO") {
+		t.Error("Expected pooled set to use the pool's hash strategy")
+	}
+
+	pool.Put(nil)
+	if NewPoolWithHashStrategy[string](nil).Get() == nil {
+		t.Error("Expected a nil strategy to fall back to the default")
+	}
+}
+
+func TestPoolPutDropsRemovedElements(t *testing.T) {
+	pool := NewPool[*int]()
+	s := pool.Get()
+	values := make([]*int, 200)
+	for i := range values {
+		v := i
+		values[i] = &v
+		s.Add(values[i])
+	}
+	for _, p := range values[:150] {
+		s.Remove(p)
+	}
+
+	pool.Put(s)
+	for i, bucket := range s.buckets {
+		for j, p := range bucket[:cap(bucket)] {
+			if p != nil {
+				t.Fatalf("Bucket %d slot %d still references a former element", i, j)
+			}
+		}
+	}
+}
+
+func BenchmarkPool(b *testing.B) {
+	b.Run("Pooled", func(b *testing.B) {
+		pool := NewPool[int]()
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			s := pool.Get()
+			for j := 0; j < 32; j++ {
+				s.Add(j)
+			}
+			pool.Put(s)
+		}
+	})
+	b.Run("New", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			s := New[int]()
+			for j := 0; j < 32; j++ {
+				s.Add(j)
+			}
+		}
+	})
+}
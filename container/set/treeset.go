@@ -37,6 +37,7 @@ type TreeSet[E comparable] struct {
 type treeNode[E comparable] struct {
 	value  E
 	color  bool // true for red, false for black
+	size   int  // number of nodes in this subtree, for rank and select
 	left   *treeNode[E]
 	right  *treeNode[E]
 	parent *treeNode[E]
@@ -72,6 +73,38 @@ func NewTreeSetWithComparatorStrategy[E comparable](strategy common.ComparatorSt
     }
 }
 
+// NewTreeSetFromSorted creates a TreeSet from elements already sorted in ascending order by comparator
+// The balanced tree is built directly in O(n) instead of inserting one element at a time
+// Adjacent duplicates are dropped; input that is not sorted falls back to ordinary insertion
+// A nil comparator uses the same natural ordering as NewTreeSet
+func NewTreeSetFromSorted[E comparable](sorted []E, comparator func(a, b E) int) *TreeSet[E] {
+	if comparator == nil {
+		comparator = common.CompareNatural[E]
+	}
+	ts := NewTreeSetWithComparator(comparator)
+
+	unique := make([]E, 0, len(sorted))
+	for i, element := range sorted {
+		if i > 0 {
+			cmp := comparator(sorted[i-1], element)
+			if cmp > 0 {
+				for _, e := range sorted {
+					ts.Add(e)
+				}
+				return ts
+			}
+			if cmp == 0 {
+				continue
+			}
+		}
+		unique = append(unique, element)
+	}
+
+	ts.root = buildTreeFromSorted(unique, 0, len(unique)-1, 0, computeRedLevel(len(unique)), nil)
+	ts.size = len(unique)
+	return ts
+}
+
 // Size returns the number of elements in the set
 func (ts *TreeSet[E]) Size() int {
 	return ts.size
@@ -100,6 +133,7 @@ func (ts *TreeSet[E]) Add(element E) bool {
 		ts.root = &treeNode[E]{
 			value: element,
 			color: false, // root is always black
+			size:  1,
 		}
 		ts.size++
 		return true
@@ -115,9 +149,11 @@ func (ts *TreeSet[E]) Add(element E) bool {
 				newNode := &treeNode[E]{
 					value:  element,
 					color:  true, // new node is red
+					size:   1,
 					parent: node,
 				}
 				node.left = newNode
+				ts.adjustSizes(node, 1)
 				ts.insertFixup(newNode)
 				ts.size++
 				return true
@@ -128,9 +164,11 @@ func (ts *TreeSet[E]) Add(element E) bool {
 				newNode := &treeNode[E]{
 					value:  element,
 					color:  true, // new node is red
+					size:   1,
 					parent: node,
 				}
 				node.right = newNode
+				ts.adjustSizes(node, 1)
 				ts.insertFixup(newNode)
 				ts.size++
 				return true
@@ -153,6 +191,105 @@ func (ts *TreeSet[E]) Remove(element E) bool {
 	return true
 }
 
+// Rank returns the number of elements in the set that are strictly less than element
+// element need not be in the set; runs in O(log n) using per-node subtree sizes
+func (ts *TreeSet[E]) Rank(element E) int {
+	rank := 0
+	node := ts.root
+	for node != nil {
+		cmp := ts.comparator(element, node.value)
+		if cmp <= 0 {
+			node = node.left
+		} else {
+			rank += nodeSize(node.left) + 1
+			node = node.right
+		}
+	}
+	return rank
+}
+
+// Select returns the k-th smallest element, counting from 0, so Select(Rank(e)) is e for any e in the set
+// Returns false if k is out of range; runs in O(log n)
+func (ts *TreeSet[E]) Select(k int) (E, bool) {
+	if k < 0 || k >= ts.size {
+		var zero E
+		return zero, false
+	}
+	node := ts.root
+	for node != nil {
+		left := nodeSize(node.left)
+		if k < left {
+			node = node.left
+		} else if k == left {
+			return node.value, true
+		} else {
+			k -= left + 1
+			node = node.right
+		}
+	}
+	var zero E
+	return zero, false
+}
+
+// RetainAll removes all elements that are not contained in the other set
+// The kept elements are collected in a single in-order traversal and the tree is
+// rebuilt from them in O(n), avoiding a rebalancing delete per removed element
+// Returns true if the set changed
+func (ts *TreeSet[E]) RetainAll(other Set[E]) bool {
+	kept := make([]E, 0)
+	ts.inorderTraversal(ts.root, func(value E) {
+		if other.Contains(value) {
+			kept = append(kept, value)
+		}
+	})
+	if len(kept) == ts.size {
+		return false
+	}
+
+	ts.root = buildTreeFromSorted(kept, 0, len(kept)-1, 0, computeRedLevel(len(kept)), nil)
+	ts.size = len(kept)
+	return true
+}
+
+// RemoveAllReturning removes the given elements and returns those that were present,
+// in the order they appear in elems; duplicates in elems are reported once
+// A small batch is deleted element by element in O(k log n); once elems is at least a quarter
+// of the set, the survivors are instead rebuilt into a balanced tree in O(n), like RetainAll
+func (ts *TreeSet[E]) RemoveAllReturning(elems []E) []E {
+	removed := make([]E, 0)
+	if len(elems)*4 < ts.size {
+		for _, element := range elems {
+			if node := ts.findNode(element); node != nil {
+				ts.deleteNode(node)
+				ts.size--
+				removed = append(removed, element)
+			}
+		}
+		return removed
+	}
+
+	marked := make(map[E]bool)
+	for _, element := range elems {
+		if node := ts.findNode(element); node != nil && !marked[node.value] {
+			marked[node.value] = true
+			removed = append(removed, element)
+		}
+	}
+	if len(removed) == 0 {
+		return removed
+	}
+
+	kept := make([]E, 0, ts.size-len(removed))
+	ts.inorderTraversal(ts.root, func(value E) {
+		if !marked[value] {
+			kept = append(kept, value)
+		}
+	})
+	ts.root = buildTreeFromSorted(kept, 0, len(kept)-1, 0, computeRedLevel(len(kept)), nil)
+	ts.size = len(kept)
+	return removed
+}
+
 // ToSlice returns a slice containing all elements in the set
 func (ts *TreeSet[E]) ToSlice() []E {
 	result := make([]E, 0, ts.size)
@@ -257,6 +394,65 @@ func (it *treeSetIterator[E]) Remove() bool {
 	return false // Not supported
 }
 
+// IntersectionIterator returns an iterator over the elements common to this set and other, in ascending order
+// Both trees are walked in step and elements are produced on demand, so no result set is built;
+// elements are ordered by this set's comparator, and the sets must not be modified while iterating
+func (ts *TreeSet[E]) IntersectionIterator(other *TreeSet[E]) common.Iterator[E] {
+	it := &treeSetIntersectionIterator[E]{
+		left:       ts,
+		right:      other,
+		comparator: ts.comparator,
+	}
+	if other != nil {
+		it.a = ts.firstNode()
+		it.b = other.firstNode()
+	}
+	return it
+}
+
+// treeSetIntersectionIterator lazily merges two in-order walks, yielding elements present in both
+type treeSetIntersectionIterator[E comparable] struct {
+	left, right *TreeSet[E]
+	a, b        *treeNode[E]
+	comparator  func(a, b E) int
+	pending     bool
+	next        E
+}
+
+// HasNext returns true if there are more common elements
+func (it *treeSetIntersectionIterator[E]) HasNext() bool {
+	for !it.pending && it.a != nil && it.b != nil {
+		c := it.comparator(it.a.value, it.b.value)
+		switch {
+		case c < 0:
+			it.a = it.left.successor(it.a)
+		case c > 0:
+			it.b = it.right.successor(it.b)
+		default:
+			it.next = it.a.value
+			it.pending = true
+			it.a = it.left.successor(it.a)
+			it.b = it.right.successor(it.b)
+		}
+	}
+	return it.pending
+}
+
+// Next returns the next common element
+func (it *treeSetIntersectionIterator[E]) Next() (E, bool) {
+	if !it.HasNext() {
+		var zero E
+		return zero, false
+	}
+	it.pending = false
+	return it.next, true
+}
+
+// Remove removes the current element (not supported)
+func (it *treeSetIntersectionIterator[E]) Remove() bool {
+	return false // Not supported
+}
+
 // String returns the string representation of the set
 func (ts *TreeSet[E]) String() string {
 	if ts.IsEmpty() {
@@ -279,6 +475,16 @@ func (ts *TreeSet[E]) String() string {
 	return sb.String()
 }
 
+// StringN returns a representation like String that shows at most n elements in sorted order
+// The remaining elements are summarised as "… (+k more)"
+func (ts *TreeSet[E]) StringN(n int) string {
+	b := common.NewLimitedStringBuilder("{", n)
+	for node := ts.firstNode(); node != nil && !b.Full(); node = ts.successor(node) {
+		b.Addf("%v", node.value)
+	}
+	return b.Finish("}", ts.size)
+}
+
 // Internal method: find node with specified value
 func (ts *TreeSet[E]) findNode(element E) *treeNode[E] {
 	node := ts.root
@@ -304,6 +510,33 @@ func (ts *TreeSet[E]) inorderTraversal(node *treeNode[E], fn func(E)) {
 	}
 }
 
+// Internal method: build a balanced red-black tree from sorted, duplicate-free elements
+// All nodes are black except those on redLevel, the only level that may be incomplete
+func buildTreeFromSorted[E comparable](elements []E, lo, hi, level, redLevel int, parent *treeNode[E]) *treeNode[E] {
+	if lo > hi {
+		return nil
+	}
+	mid := (lo + hi) / 2
+	node := &treeNode[E]{
+		value:  elements[mid],
+		color:  level == redLevel,
+		size:   hi - lo + 1,
+		parent: parent,
+	}
+	node.left = buildTreeFromSorted(elements, lo, mid-1, level+1, redLevel, node)
+	node.right = buildTreeFromSorted(elements, mid+1, hi, level+1, redLevel, node)
+	return node
+}
+
+// Internal method: find the level at which nodes are colored red when building from n sorted elements
+func computeRedLevel(n int) int {
+	level := 0
+	for m := n - 1; m >= 0; m = m/2 - 1 {
+		level++
+	}
+	return level
+}
+
 // Internal method: left rotation
 func (ts *TreeSet[E]) rotateLeft(node *treeNode[E]) {
 	right := node.right
@@ -321,6 +554,8 @@ func (ts *TreeSet[E]) rotateLeft(node *treeNode[E]) {
 	}
 	right.left = node
 	node.parent = right
+	right.size = node.size
+	node.size = nodeSize(node.left) + nodeSize(node.right) + 1
 }
 
 // Internal method: right rotation
@@ -340,6 +575,8 @@ func (ts *TreeSet[E]) rotateRight(node *treeNode[E]) {
 	}
 	left.right = node
 	node.parent = left
+	left.size = node.size
+	node.size = nodeSize(node.left) + nodeSize(node.right) + 1
 }
 
 // Internal method: fix red-black tree properties after insertion
@@ -399,9 +636,11 @@ func (ts *TreeSet[E]) deleteNode(node *treeNode[E]) {
 		x = y.right
 	}
 
+	xParent := y.parent
 	if x != nil {
 		x.parent = y.parent
 	}
+	ts.adjustSizes(y.parent, -1)
 
 	if y.parent == nil {
 		ts.root = x
@@ -415,11 +654,27 @@ func (ts *TreeSet[E]) deleteNode(node *treeNode[E]) {
 		node.value = y.value
 	}
 
-	if !y.color && x != nil {
-		ts.deleteFixup(x)
+	// Removing a black node shortens its paths, even when it was a leaf and x is nil
+	if !y.color {
+		ts.deleteFixup(x, xParent)
+	}
+}
+
+// Internal method: add delta to the subtree size of node and each of its ancestors
+func (ts *TreeSet[E]) adjustSizes(node *treeNode[E], delta int) {
+	for ; node != nil; node = node.parent {
+		node.size += delta
 	}
 }
 
+// Internal method: return the number of nodes in the subtree rooted at node
+func nodeSize[E comparable](node *treeNode[E]) int {
+	if node == nil {
+		return 0
+	}
+	return node.size
+}
+
 // Internal method: find successor node
 func (ts *TreeSet[E]) successor(node *treeNode[E]) *treeNode[E] {
 	if node.right != nil {
@@ -438,68 +693,109 @@ func (ts *TreeSet[E]) successor(node *treeNode[E]) *treeNode[E] {
 	return parent
 }
 
+// Internal method: return the node holding the smallest element, or nil if the set is empty
+func (ts *TreeSet[E]) firstNode() *treeNode[E] {
+	node := ts.root
+	if node == nil {
+		return nil
+	}
+	for node.left != nil {
+		node = node.left
+	}
+	return node
+}
+
 // Internal method: fix red-black tree properties after deletion
-func (ts *TreeSet[E]) deleteFixup(node *treeNode[E]) {
-	for node != ts.root && !node.color {
-		if node == node.parent.left {
-			sibling := node.parent.right
-			if sibling.color {
+// node is the child that replaced the removed black node and may be nil, so its parent is passed
+// explicitly, as with the nil sentinel in CLRS
+func (ts *TreeSet[E]) deleteFixup(node, parent *treeNode[E]) {
+	for node != ts.root && !isRedNode(node) {
+		if node == parent.left {
+			sibling := parent.right
+			if isRedNode(sibling) {
 				sibling.color = false
-				node.parent.color = true
-				ts.rotateLeft(node.parent)
-				sibling = node.parent.right
+				parent.color = true
+				ts.rotateLeft(parent)
+				sibling = parent.right
 			}
-			if (sibling.left == nil || !sibling.left.color) &&
-				(sibling.right == nil || !sibling.right.color) {
+			if !isRedNode(sibling.left) && !isRedNode(sibling.right) {
 				sibling.color = true
-				node = node.parent
+				node = parent
+				parent = node.parent
 			} else {
-				if sibling.right == nil || !sibling.right.color {
-					if sibling.left != nil {
-						sibling.left.color = false
-					}
+				if !isRedNode(sibling.right) {
+					sibling.left.color = false
 					sibling.color = true
 					ts.rotateRight(sibling)
-					sibling = node.parent.right
+					sibling = parent.right
 				}
-				sibling.color = node.parent.color
-				node.parent.color = false
+				sibling.color = parent.color
+				parent.color = false
 				if sibling.right != nil {
 					sibling.right.color = false
 				}
-				ts.rotateLeft(node.parent)
+				ts.rotateLeft(parent)
 				node = ts.root
+				parent = nil
 			}
 		} else {
-			sibling := node.parent.left
-			if sibling.color {
+			sibling := parent.left
+			if isRedNode(sibling) {
 				sibling.color = false
-				node.parent.color = true
-				ts.rotateRight(node.parent)
-				sibling = node.parent.left
+				parent.color = true
+				ts.rotateRight(parent)
+				sibling = parent.left
 			}
-			if (sibling.right == nil || !sibling.right.color) &&
-				(sibling.left == nil || !sibling.left.color) {
+			if !isRedNode(sibling.right) && !isRedNode(sibling.left) {
 				sibling.color = true
-				node = node.parent
+				node = parent
+				parent = node.parent
 			} else {
-				if sibling.left == nil || !sibling.left.color {
-					if sibling.right != nil {
-						sibling.right.color = false
-					}
+				if !isRedNode(sibling.left) {
+					sibling.right.color = false
 					sibling.color = true
 					ts.rotateLeft(sibling)
-					sibling = node.parent.left
+					sibling = parent.left
 				}
-				sibling.color = node.parent.color
-				node.parent.color = false
+				sibling.color = parent.color
+				parent.color = false
 				if sibling.left != nil {
 					sibling.left.color = false
 				}
-				ts.rotateRight(node.parent)
+				ts.rotateRight(parent)
 				node = ts.root
+				parent = nil
 			}
 		}
 	}
-	node.color = false
+	if node != nil {
+		node.color = false
+	}
+}
+
+// Internal method: report whether node is red; nil leaves are black
+func isRedNode[E comparable](node *treeNode[E]) bool {
+	return node != nil && node.color
+}
+
+// MarshalBinary encodes the set elements in sorted order using common.EncodeElements
+func (ts *TreeSet[E]) MarshalBinary() ([]byte, error) {
+	return common.EncodeElements(ts.ToSlice())
+}
+
+// UnmarshalBinary replaces the set contents with the elements decoded from data
+// The set keeps its comparator; a zero-value TreeSet uses natural ordering
+func (ts *TreeSet[E]) UnmarshalBinary(data []byte) error {
+	elements, err := common.DecodeElements[E](data)
+	if err != nil {
+		return err
+	}
+	if ts.comparator == nil {
+		ts.comparator = common.CompareNatural[E]
+	}
+	ts.Clear()
+	for _, element := range elements {
+		ts.Add(element)
+	}
+	return nil
 }
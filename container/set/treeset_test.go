@@ -1,7 +1,10 @@
 package set
 
 import (
+	"math/rand"
 	"testing"
+
+	"github.com/chenjianyu/collections/container/common"
 )
 
 func TestTreeSet_New(t *testing.T) {
@@ -416,4 +419,411 @@ func TestTreeSet_EdgeCases(t *testing.T) {
 	if ts.Size() != 1 {
 		t.Errorf("TreeSet size should be 1 after adding same element multiple times, got %d", ts.Size())
 	}
-}
\ No newline at end of file
+}
+// checkRedBlack verifies ordering, parent links, subtree sizes and red-black properties of the tree
+func checkRedBlack[E comparable](t *testing.T, ts *TreeSet[E]) {
+	t.Helper()
+	if ts.root == nil {
+		return
+	}
+	if ts.root.color {
+		t.Error("Root should be black")
+	}
+	if ts.root.parent != nil {
+		t.Error("Root should have no parent")
+	}
+
+	count := 0
+	var walk func(node *treeNode[E]) int
+	walk = func(node *treeNode[E]) int {
+		if node == nil {
+			return 1
+		}
+		count++
+		if node.size != nodeSize(node.left)+nodeSize(node.right)+1 {
+			t.Errorf("Subtree size of %v is %d, want %d", node.value, node.size, nodeSize(node.left)+nodeSize(node.right)+1)
+		}
+		for _, child := range []*treeNode[E]{node.left, node.right} {
+			if child == nil {
+				continue
+			}
+			if child.parent != node {
+				t.Errorf("Parent link of %v is broken", child.value)
+			}
+			if node.color && child.color {
+				t.Errorf("Red node %v has a red child %v", node.value, child.value)
+			}
+		}
+		if node.left != nil && ts.comparator(node.left.value, node.value) >= 0 {
+			t.Errorf("Left child %v is not less than %v", node.left.value, node.value)
+		}
+		if node.right != nil && ts.comparator(node.right.value, node.value) <= 0 {
+			t.Errorf("Right child %v is not greater than %v", node.right.value, node.value)
+		}
+		left := walk(node.left)
+		right := walk(node.right)
+		if left != right {
+			t.Errorf("Black height mismatch at %v: %d vs %d", node.value, left, right)
+		}
+		if !node.color {
+			left++
+		}
+		return left
+	}
+	walk(ts.root)
+
+	if count != ts.Size() {
+		t.Errorf("Tree has %d nodes but size is %d", count, ts.Size())
+	}
+}
+
+func TestTreeSet_RetainAll(t *testing.T) {
+	ts := NewTreeSet[int]()
+	for i := 0; i < 100000; i++ {
+		ts.Add(i)
+	}
+
+	keep := New[int]()
+	for i := 0; i < 100; i++ {
+		keep.Add(i * 997)
+	}
+	keep.Add(-1) // not in ts
+
+	if !ts.RetainAll(keep) {
+		t.Error("RetainAll should return true when elements are removed")
+	}
+	if ts.Size() != 100 {
+		t.Errorf("TreeSet size should be 100, got %d", ts.Size())
+	}
+	slice := ts.ToSlice()
+	for i, val := range slice {
+		if val != i*997 {
+			t.Errorf("Element at index %d should be %d, got %d", i, i*997, val)
+			break
+		}
+	}
+	checkRedBlack(t, ts)
+
+	// The rebuilt tree keeps working as a normal red-black tree
+	ts.Add(5)
+	ts.Add(-10)
+	checkRedBlack(t, ts)
+	if !ts.Contains(5) || !ts.Contains(997) || ts.Contains(998) {
+		t.Error("Contains should work after RetainAll")
+	}
+
+	if ts.RetainAll(ts.Union(keep)) {
+		t.Error("RetainAll should return false when nothing is removed")
+	}
+
+	for n := 0; n < 40; n++ {
+		small := NewTreeSet[int]()
+		for i := 0; i < 50; i++ {
+			small.Add(i)
+		}
+		subset := New[int]()
+		for i := 0; i < n; i++ {
+			subset.Add(i)
+		}
+		small.RetainAll(subset)
+		if small.Size() != n {
+			t.Errorf("TreeSet size should be %d, got %d", n, small.Size())
+		}
+		checkRedBlack(t, small)
+	}
+}
+
+func TestNewTreeSetFromSorted(t *testing.T) {
+	for n := 0; n < 70; n++ {
+		sorted := make([]int, n)
+		for i := range sorted {
+			sorted[i] = i * 2
+		}
+		ts := NewTreeSetFromSorted(sorted, nil)
+		if ts.Size() != n {
+			t.Errorf("TreeSet size should be %d, got %d", n, ts.Size())
+		}
+		checkRedBlack(t, ts)
+		for i, val := range ts.ToSlice() {
+			if val != i*2 {
+				t.Errorf("Element at index %d should be %d, got %d", i, i*2, val)
+				break
+			}
+		}
+	}
+
+	// Duplicates are dropped and the tree keeps working after the bulk load
+	ts := NewTreeSetFromSorted([]int{1, 1, 2, 3, 3, 3, 9}, nil)
+	if ts.Size() != 4 {
+		t.Errorf("Duplicates should be dropped, got size %d", ts.Size())
+	}
+	ts.Add(5)
+	checkRedBlack(t, ts)
+	ts.Remove(1)
+	if !ts.Contains(5) || ts.Contains(1) || !ts.Contains(9) {
+		t.Error("Add and Remove should work on a bulk-loaded set")
+	}
+
+	// Custom comparator ordering
+	desc := NewTreeSetFromSorted([]string{"c", "b", "a"}, func(a, b string) int {
+		if a > b {
+			return -1
+		} else if a < b {
+			return 1
+		}
+		return 0
+	})
+	if got := desc.ToSlice(); len(got) != 3 || got[0] != "c" || got[2] != "a" {
+		t.Errorf("Order should follow the comparator, got %v", got)
+	}
+
+	// Unsorted input still yields a correct set
+	unsorted := NewTreeSetFromSorted([]int{5, 3, 9, 3, 1}, nil)
+	if unsorted.Size() != 4 {
+		t.Errorf("Unsorted input should fall back to insertion, got size %d", unsorted.Size())
+	}
+	checkRedBlack(t, unsorted)
+}
+
+func BenchmarkNewTreeSetFromSorted(b *testing.B) {
+	sorted := make([]int, 100000)
+	for i := range sorted {
+		sorted[i] = i
+	}
+	b.Run("FromSorted", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			NewTreeSetFromSorted(sorted, nil)
+		}
+	})
+	b.Run("Add", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			ts := NewTreeSet[int]()
+			for _, v := range sorted {
+				ts.Add(v)
+			}
+		}
+	})
+}
+
+func TestTreeSet_BinaryRoundTrip(t *testing.T) {
+	reverse := func(a, b int) int { return b - a }
+	ts := NewTreeSetWithComparator(reverse)
+	for _, v := range []int{2, 9, 4} {
+		ts.Add(v)
+	}
+
+	var _ common.Serializable = ts
+	data, err := ts.MarshalBinary()
+	if err != nil {
+		t.Fatalf("MarshalBinary failed: %v", err)
+	}
+
+	// The receiver's comparator decides the restored order
+	restored := NewTreeSetWithComparator(reverse)
+	if err := restored.UnmarshalBinary(data); err != nil {
+		t.Fatalf("UnmarshalBinary failed: %v", err)
+	}
+	if restored.String() != ts.String() {
+		t.Errorf("Expected %v, got %v", ts, restored)
+	}
+
+	var natural TreeSet[int]
+	if err := natural.UnmarshalBinary(data); err != nil {
+		t.Fatalf("UnmarshalBinary into a zero value failed: %v", err)
+	}
+	if got := natural.ToSlice(); len(got) != 3 || got[0] != 2 || got[2] != 9 {
+		t.Errorf("Expected natural order [2 4 9], got %v", got)
+	}
+}
+
+func TestTreeSet_StringN(t *testing.T) {
+	ts := NewTreeSet[int]()
+	for _, v := range []int{30, 10, 20, 40} {
+		ts.Add(v)
+	}
+	if got := ts.StringN(2); got != "{10, 20, … (+2 more)}" {
+		t.Errorf("Expected truncated listing in sorted order, got %q", got)
+	}
+	if got := NewTreeSet[int]().StringN(2); got != "{}" {
+		t.Errorf("Expected {}, got %q", got)
+	}
+}
+
+func TestTreeSet_RemoveAllReturning(t *testing.T) {
+	ts := NewTreeSet[int]()
+	for i := 0; i < 1000; i++ {
+		ts.Add(i)
+	}
+
+	removed := ts.RemoveAllReturning([]int{500, -1, 3, 500, 999})
+	if len(removed) != 3 || removed[0] != 500 || removed[1] != 3 || removed[2] != 999 {
+		t.Errorf("Expected [500 3 999], got %v", removed)
+	}
+	if ts.Size() != 997 {
+		t.Errorf("TreeSet size should be 997, got %d", ts.Size())
+	}
+	for _, v := range []int{3, 500, 999} {
+		if ts.Contains(v) {
+			t.Errorf("TreeSet should not contain %d", v)
+		}
+	}
+	slice := ts.ToSlice()
+	for i := 1; i < len(slice); i++ {
+		if slice[i-1] >= slice[i] {
+			t.Errorf("TreeSet should stay sorted, got %d before %d", slice[i-1], slice[i])
+			break
+		}
+	}
+	if !ts.Add(3) || !ts.Contains(3) {
+		t.Error("TreeSet should accept re-adding a removed element")
+	}
+	if removed := ts.RemoveAllReturning(nil); len(removed) != 0 {
+		t.Errorf("Expected nothing removed, got %v", removed)
+	}
+	checkRedBlack(t, ts)
+
+	// A batch of at least a quarter of the set takes the rebuild path
+	batch := make([]int, 0, 500)
+	for i := 0; i < 1000; i += 2 {
+		batch = append(batch, i)
+	}
+	if removed := ts.RemoveAllReturning(batch); len(removed) != 499 {
+		t.Errorf("Expected 499 even elements removed, got %d", len(removed))
+	}
+	checkRedBlack(t, ts)
+	if ts.Size() != 499 || ts.Contains(2) || !ts.Contains(1) {
+		t.Errorf("Only odd elements except 999 should remain, got %d elements", ts.Size())
+	}
+}
+
+func TestTreeSet_IntersectionIterator(t *testing.T) {
+	evens := NewTreeSet[int]()
+	thirds := NewTreeSet[int]()
+	for i := 0; i < 10000; i++ {
+		evens.Add(i * 2)
+		thirds.Add(i * 3)
+	}
+
+	it := evens.IntersectionIterator(thirds)
+	for want := 0; want < 60; want += 6 {
+		if !it.HasNext() {
+			t.Fatalf("Expected more common elements after %d", want-6)
+		}
+		got, ok := it.Next()
+		if !ok || got != want {
+			t.Fatalf("Expected %d, got %d (ok=%v)", want, got, ok)
+		}
+	}
+
+	count := 10
+	for it.HasNext() {
+		it.Next()
+		count++
+	}
+	// Common elements are multiples of 6 below 20000
+	if count != 3334 {
+		t.Errorf("Expected 3334 common elements, got %d", count)
+	}
+	if _, ok := it.Next(); ok {
+		t.Error("Next should report false once exhausted")
+	}
+
+	empty := NewTreeSet[int]()
+	if evens.IntersectionIterator(empty).HasNext() || empty.IntersectionIterator(evens).HasNext() {
+		t.Error("Intersection with an empty set should be empty")
+	}
+	if evens.IntersectionIterator(nil).HasNext() {
+		t.Error("Intersection with nil should be empty")
+	}
+}
+
+func TestTreeSet_RankSelect(t *testing.T) {
+	ts := NewTreeSet[int]()
+	if _, ok := ts.Select(0); ok {
+		t.Error("Select on an empty set should report false")
+	}
+	if ts.Rank(5) != 0 {
+		t.Errorf("Rank in an empty set should be 0, got %d", ts.Rank(5))
+	}
+
+	r := rand.New(rand.NewSource(3))
+	for i := 0; i < 3000; i++ {
+		if r.Intn(3) == 0 {
+			ts.Remove(r.Intn(1000))
+		} else {
+			ts.Add(r.Intn(1000))
+		}
+	}
+	checkRedBlack(t, ts)
+	ts.RemoveAllReturning([]int{1, 2, 3, 500})
+	for i := 0; i < 200; i++ {
+		ts.Add(r.Intn(1000))
+	}
+	checkRedBlack(t, ts)
+
+	sorted := ts.ToSlice()
+	for k, want := range sorted {
+		if got, ok := ts.Select(k); !ok || got != want {
+			t.Fatalf("Select(%d) should be %d, got %d (ok=%v)", k, want, got, ok)
+		}
+		if rank := ts.Rank(want); rank != k {
+			t.Fatalf("Rank(%d) should be %d, got %d", want, k, rank)
+		}
+	}
+	if _, ok := ts.Select(len(sorted)); ok {
+		t.Error("Select past the end should report false")
+	}
+	if _, ok := ts.Select(-1); ok {
+		t.Error("Select with a negative index should report false")
+	}
+	if ts.Rank(-1) != 0 || ts.Rank(1000) != len(sorted) {
+		t.Errorf("Rank outside the range should be 0 and %d, got %d and %d", len(sorted), ts.Rank(-1), ts.Rank(1000))
+	}
+
+	// Median of a stream via Select
+	stream := NewTreeSet[int]()
+	for _, v := range []int{5, 1, 9, 3, 7} {
+		stream.Add(v)
+	}
+	if median, _ := stream.Select(stream.Size() / 2); median != 5 {
+		t.Errorf("Median should be 5, got %d", median)
+	}
+}
+
+func TestTreeSet_RemoveKeepsRedBlackInvariants(t *testing.T) {
+	ts := NewTreeSet[int]()
+	reference := make(map[int]bool)
+	r := rand.New(rand.NewSource(5))
+	for i := 0; i < 20000; i++ {
+		v := r.Intn(2000)
+		if r.Intn(2) == 0 {
+			if ts.Add(v) == reference[v] {
+				t.Fatalf("Add(%d) disagrees with the reference", v)
+			}
+			reference[v] = true
+		} else {
+			if ts.Remove(v) != reference[v] {
+				t.Fatalf("Remove(%d) disagrees with the reference", v)
+			}
+			delete(reference, v)
+		}
+	}
+	checkRedBlack(t, ts)
+	if ts.Size() != len(reference) {
+		t.Fatalf("TreeSet size should be %d, got %d", len(reference), ts.Size())
+	}
+	for v := range reference {
+		if !ts.Contains(v) {
+			t.Fatalf("TreeSet should contain %d", v)
+		}
+	}
+
+	// Draining the set exercises black-leaf removals down to an empty tree
+	for v := range reference {
+		ts.Remove(v)
+	}
+	checkRedBlack(t, ts)
+	if !ts.IsEmpty() {
+		t.Errorf("TreeSet should be empty, got size %d", ts.Size())
+	}
+}
@@ -30,6 +30,15 @@ func WithCapacity[E any](capacity int) *ArrayStack[E] {
 	}
 }
 
+// NewBoundedStack creates an ArrayStack that holds at most capacity elements
+// Push returns a FullContainerError once the stack is full; a capacity less than 1 is treated as 1
+func NewBoundedStack[E any](capacity int) *ArrayStack[E] {
+	if capacity < 1 {
+		capacity = 1
+	}
+	return WithCapacity[E](capacity)
+}
+
 // FromSlice creates a new ArrayStack from a slice
 // The first element in the slice will be the bottom element, the last element will be the top element
 func FromSlice[E any](slice []E) *ArrayStack[E] {
@@ -71,9 +80,10 @@ func (s *ArrayStack[E]) Clear() {
 }
 
 // Contains checks if the stack contains the specified element
+// Elements are compared with common.Equal, as in the lists and PriorityQueue
 func (s *ArrayStack[E]) Contains(element E) bool {
 	for _, e := range s.elements {
-		if any(e) == any(element) {
+		if common.Equal(e, element) {
 			return true
 		}
 	}
@@ -140,7 +150,7 @@ func (s *ArrayStack[E]) Peek() (E, error) {
 // Search searches for an element in the stack
 func (s *ArrayStack[E]) Search(element E) int {
 	for i := len(s.elements) - 1; i >= 0; i-- {
-		if any(s.elements[i]) == any(element) {
+		if common.Equal(s.elements[i], element) {
 			return len(s.elements) - i
 		}
 	}
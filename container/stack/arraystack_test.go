@@ -1,7 +1,10 @@
 package stack
 
 import (
+	"errors"
 	"testing"
+
+	"github.com/chenjianyu/collections/container/common"
 )
 
 func TestArrayStack_Basic(t *testing.T) {
@@ -55,6 +58,48 @@ func TestArrayStack_WithCapacity(t *testing.T) {
 	}
 }
 
+func TestArrayStack_NewBoundedStack(t *testing.T) {
+	stack := NewBoundedStack[int](3)
+
+	for i := 1; i <= 3; i++ {
+		if err := stack.Push(i); err != nil {
+			t.Errorf("Push %d should not return error, got %v", i, err)
+		}
+	}
+
+	err := stack.Push(4)
+	if !errors.Is(err, common.ErrFullContainer) {
+		t.Errorf("Push on full stack should return ErrFullContainer, got %v", err)
+	}
+	if stack.Size() != 3 {
+		t.Errorf("Stack size should be 3, got %d", stack.Size())
+	}
+
+	// Popping frees a slot again
+	stack.Pop()
+	if err := stack.Push(4); err != nil {
+		t.Errorf("Push after Pop should succeed, got %v", err)
+	}
+
+	// Clear keeps the bound
+	stack.Clear()
+	for i := 0; i < 3; i++ {
+		stack.Push(i)
+	}
+	if err := stack.Push(3); err == nil {
+		t.Error("Push should fail after refilling a cleared bounded stack")
+	}
+
+	// Non-positive capacity is clamped to 1
+	tiny := NewBoundedStack[int](0)
+	if err := tiny.Push(1); err != nil {
+		t.Errorf("First push should succeed, got %v", err)
+	}
+	if err := tiny.Push(2); err == nil {
+		t.Error("Second push should fail for capacity clamped to 1")
+	}
+}
+
 func TestArrayStack_Pop(t *testing.T) {
 	stack := New[int]()
 	stack.Push(1)
@@ -146,6 +191,22 @@ func TestArrayStack_Contains(t *testing.T) {
 	}
 }
 
+func TestArrayStack_ContainsUncomparableElements(t *testing.T) {
+	stack := New[[]int]()
+	stack.Push([]int{1, 2})
+	stack.Push([]int{3})
+
+	if !stack.Contains([]int{1, 2}) {
+		t.Error("Stack should contain [1 2] by deep equality")
+	}
+	if stack.Contains([]int{2}) {
+		t.Error("Stack should not contain [2]")
+	}
+	if pos := stack.Search([]int{1, 2}); pos != 2 {
+		t.Errorf("Search([1 2]) should return 2, got %d", pos)
+	}
+}
+
 func TestArrayStack_ForEach(t *testing.T) {
 	stack := New[int]()
 	stack.Push(1)